@@ -4,6 +4,7 @@ import (
 	"dbcGoSDK/generated/dammv1"
 	"dbcGoSDK/generated/dammv2"
 	"dbcGoSDK/generated/dbc"
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
 	"math"
 	"math/big"
 
@@ -37,10 +38,22 @@ const (
 	// MaxCurvePoint
 	MaxCurvePoint = 16
 
+	// MaxTransactionSize
+	//  bytes of a serialized transaction, signatures included
+	MaxTransactionSize = 1232
+
 	// PartnerSurplusShare
 	//  80%
 	PartnerSurplusShare = 80
 
+	// ProtocolFeePercent
+	//  20% of the trading fee
+	ProtocolFeePercent = 20
+
+	// HostFeePercent
+	//  20% of the protocol fee
+	HostFeePercent = 20
+
 	// SwapBufferPercentage
 	//  25%
 	SwapBufferPercentage = 25
@@ -201,6 +214,17 @@ var (
 		solana.MustPublicKeyFromBase58("DbCRBj8McvPYHJG1ukj8RE15h2dCNUdTAESG49XpQ44u"),
 		solana.MustPublicKeyFromBase58("A8gMrEPJkacWkcb3DGwtJwTe16HktSEfvwtuDh2MCtck"),
 	}
+
+	// Lending programs of the vault strategies, withdraw2 pulls liquidity from a strategy through them.
+	VaultStrategyProgramIds = map[dynamic_vault.StrategyType]solana.PublicKey{
+		dynamic_vault.StrategyTypePortFinanceWithoutLM: solana.MustPublicKeyFromBase58("Port7uDYB3wk6GJAw4KT1WpTeMtSu9bTcChBHkX2LfR"),
+		dynamic_vault.StrategyTypePortFinanceWithLM:    solana.MustPublicKeyFromBase58("Port7uDYB3wk6GJAw4KT1WpTeMtSu9bTcChBHkX2LfR"),
+		dynamic_vault.StrategyTypeSolendWithoutLM:      solana.MustPublicKeyFromBase58("So1endDq2YkqhipRh3WViPa8hdiSpxWy6z3Z6tMCpAo"),
+		dynamic_vault.StrategyTypeSolendWithLM:         solana.MustPublicKeyFromBase58("So1endDq2YkqhipRh3WViPa8hdiSpxWy6z3Z6tMCpAo"),
+		dynamic_vault.StrategyTypeMango:                solana.MustPublicKeyFromBase58("mv3ekLzLbnVPNxjSKvqBpU3ZeZXPQdEC3bp5MDEBG68"),
+		dynamic_vault.StrategyTypeDrift:                solana.MustPublicKeyFromBase58("dRiftyHA39MWEi3m9aunc5MzRF1JYuBsbn6VPcn33UH"),
+		dynamic_vault.StrategyTypeMarginfi:             solana.MustPublicKeyFromBase58("MFv2hWf31Z9kbCa1snEPYctwafyhdvnV7FZnsebVacA"),
+	}
 )
 
 const (
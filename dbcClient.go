@@ -7,13 +7,18 @@ import (
 )
 
 type DynamicBondingCurveClient struct {
-	Conn       *rpc.Client
-	Commitment rpc.CommitmentType
-	State      *services.StateService
-	Pool       *services.PoolService
-	Partner    *services.PartnerService
-	Creator    *services.CreatorService
-	Migration  *services.MigrationService
+	Conn             *rpc.Client
+	Commitment       rpc.CommitmentType
+	State            *services.StateService
+	Pool             *services.PoolService
+	Partner          *services.PartnerService
+	Creator          *services.CreatorService
+	Migration        *services.MigrationService
+	Operator         *services.OperatorService
+	DammV2Position   *services.DammV2PositionService
+	DammV1LockEscrow *services.DammV1LockEscrowService
+	Vault            *services.VaultService
+	Router           *services.RouterService
 }
 
 func NewDynamicBondingCurveClient(
@@ -21,12 +26,17 @@ func NewDynamicBondingCurveClient(
 	commitment rpc.CommitmentType,
 ) *DynamicBondingCurveClient {
 	return &DynamicBondingCurveClient{
-		Conn:       conn,
-		Commitment: commitment,
-		State:      services.NewStateService(conn, commitment),
-		Pool:       services.NewPoolService(conn, commitment),
-		Partner:    services.NewPartnerService(conn, commitment),
-		Creator:    services.NewCreatorService(conn, commitment),
-		Migration:  services.NewMigrationService(conn, commitment),
+		Conn:             conn,
+		Commitment:       commitment,
+		State:            services.NewStateService(conn, commitment),
+		Pool:             services.NewPoolService(conn, commitment),
+		Partner:          services.NewPartnerService(conn, commitment),
+		Creator:          services.NewCreatorService(conn, commitment),
+		Migration:        services.NewMigrationService(conn, commitment),
+		Operator:         services.NewOperatorService(conn, commitment),
+		DammV2Position:   services.NewDammV2PositionService(conn, commitment),
+		DammV1LockEscrow: services.NewDammV1LockEscrowService(conn, commitment),
+		Vault:            services.NewVaultService(conn, commitment),
+		Router:           services.NewRouterService(conn, commitment),
 	}
 }
@@ -108,6 +108,21 @@ func DerivePositionNftAccount(positionNft solana.PublicKey) solana.PublicKey {
 	return pda
 }
 
+// DeriveDammV2PoolAddress derives DAMM V2 pool address.
+func DeriveDammV2PoolAddress(
+	config, tokenAMint, tokenBMint solana.PublicKey) solana.PublicKey {
+	pda, _, _ := solana.FindProgramAddress(
+		[][]byte{
+			[]byte(constants.SeedPool),
+			config.Bytes(),
+			GetFirstkey(tokenAMint, tokenBMint),
+			GetSecondkey(tokenAMint, tokenBMint),
+		},
+		constants.DammV2ProgramId,
+	)
+	return pda
+}
+
 func DeriveDammV2PoolAuthority() solana.PublicKey {
 	pda, _, _ := solana.FindProgramAddress(
 		[][]byte{
@@ -161,6 +176,18 @@ func DeriveDbcPartnerMetadata(feeClaimer solana.PublicKey) solana.PublicKey {
 	return pda
 }
 
+// DeriveClaimFeeOperator derives the claim fee operator address of an operator wallet.
+func DeriveClaimFeeOperator(operator solana.PublicKey) solana.PublicKey {
+	pda, _, _ := solana.FindProgramAddress(
+		[][]byte{
+			[]byte(constants.SeedClaimFeeOperator),
+			operator.Bytes(),
+		},
+		constants.DBCProgramId,
+	)
+	return pda
+}
+
 func DeriveDammV1MigrationMetadataAddress(virtualPool solana.PublicKey) solana.PublicKey {
 	pda, _, _ := solana.FindProgramAddress(
 		[][]byte{
@@ -188,7 +215,6 @@ func DeriveDammV1PoolAddress(
 	config, tokenAMint, tokenBMintt solana.PublicKey) solana.PublicKey {
 	pda, _, _ := solana.FindProgramAddress(
 		[][]byte{
-			[]byte(constants.SeedDammV1MigrationMetadata),
 			GetFirstkey(tokenAMint, tokenBMintt),
 			GetSecondkey(tokenAMint, tokenBMintt),
 			config.Bytes(),
@@ -0,0 +1,440 @@
+package helpers
+
+import (
+	"cmp"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/maths"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+)
+
+// GetPoolConfigFromConfigParameters builds the pool config the program would store for configParam,
+// deriving the migration sqrt price, swap base amount and migration base threshold from the curve.
+func GetPoolConfigFromConfigParameters(configParam dbc.ConfigParameters) (*dbc.PoolConfigAccount, error) {
+	if len(configParam.Curve) == 0 {
+		return nil, errors.New("curve is empty")
+	}
+
+	config := &dbc.PoolConfigAccount{
+		PoolFees: dbc.PoolFeesConfig{
+			BaseFee: dbc.BaseFeeConfig{
+				CliffFeeNumerator: configParam.PoolFees.BaseFee.CliffFeeNumerator,
+				SecondFactor:      configParam.PoolFees.BaseFee.SecondFactor,
+				ThirdFactor:       configParam.PoolFees.BaseFee.ThirdFactor,
+				FirstFactor:       configParam.PoolFees.BaseFee.FirstFactor,
+				BaseFeeMode:       configParam.PoolFees.BaseFee.BaseFeeMode,
+			},
+			ProtocolFeePercent: constants.ProtocolFeePercent,
+			ReferralFeePercent: constants.HostFeePercent,
+		},
+		CollectFeeMode:                configParam.CollectFeeMode,
+		MigrationOption:               configParam.MigrationOption,
+		ActivationType:                configParam.ActivationType,
+		TokenDecimal:                  configParam.TokenDecimal,
+		TokenType:                     configParam.TokenType,
+		PartnerLockedLpPercentage:     configParam.PartnerLockedLpPercentage,
+		PartnerLpPercentage:           configParam.PartnerLpPercentage,
+		CreatorLockedLpPercentage:     configParam.CreatorLockedLpPercentage,
+		CreatorLpPercentage:           configParam.CreatorLpPercentage,
+		MigrationFeeOption:            configParam.MigrationFeeOption,
+		CreatorTradingFeePercentage:   configParam.CreatorTradingFeePercentage,
+		TokenUpdateAuthority:          configParam.TokenUpdateAuthority,
+		MigrationFeePercentage:        configParam.MigrationFee.FeePercentage,
+		CreatorMigrationFeePercentage: configParam.MigrationFee.CreatorFeePercentage,
+		MigrationQuoteThreshold:       configParam.MigrationQuoteThreshold,
+		LockedVestingConfig: dbc.LockedVestingConfig{
+			AmountPerPeriod:                configParam.LockedVesting.AmountPerPeriod,
+			CliffDurationFromMigrationTime: configParam.LockedVesting.CliffDurationFromMigrationTime,
+			Frequency:                      configParam.LockedVesting.Frequency,
+			NumberOfPeriod:                 configParam.LockedVesting.NumberOfPeriod,
+			CliffUnlockAmount:              configParam.LockedVesting.CliffUnlockAmount,
+		},
+		MigratedCollectFeeMode: configParam.MigratedPoolFee.CollectFeeMode,
+		MigratedDynamicFee:     configParam.MigratedPoolFee.DynamicFee,
+		MigratedPoolFeeBps:     configParam.MigratedPoolFee.PoolFeeBps,
+		SqrtStartPrice:         configParam.SqrtStartPrice,
+	}
+
+	if dynamicFee := configParam.PoolFees.DynamicFee; dynamicFee != nil {
+		config.PoolFees.DynamicFee = dbc.DynamicFeeConfig{
+			Initialized:              1,
+			MaxVolatilityAccumulator: dynamicFee.MaxVolatilityAccumulator,
+			VariableFeeControl:       dynamicFee.VariableFeeControl,
+			BinStep:                  dynamicFee.BinStep,
+			FilterPeriod:             dynamicFee.FilterPeriod,
+			DecayPeriod:              dynamicFee.DecayPeriod,
+			ReductionFactor:          dynamicFee.ReductionFactor,
+			BinStepU128:              dynamicFee.BinStepU128,
+		}
+	}
+
+	if configParam.TokenSupply != nil {
+		config.FixedTokenSupplyFlag = 1
+		config.PreMigrationTokenSupply = configParam.TokenSupply.PreMigrationTokenSupply
+		config.PostMigrationTokenSupply = configParam.TokenSupply.PostMigrationTokenSupply
+	}
+
+	for i, point := range configParam.Curve {
+		if i >= len(config.Curve) {
+			return nil, fmt.Errorf("curve cannot have more than %d points", len(config.Curve))
+		}
+		config.Curve[i] = dbc.LiquidityDistributionConfig{
+			SqrtPrice: point.SqrtPrice,
+			Liquidity: point.Liquidity,
+		}
+	}
+
+	migrationSqrtPrice, err := GetMigrationThresholdPrice(
+		new(big.Int).SetUint64(configParam.MigrationQuoteThreshold),
+		configParam.SqrtStartPrice.BigInt(),
+		configParam.Curve,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("migration sqrt price: %w", err)
+	}
+	config.MigrationSqrtPrice = MustBigIntToUint128(migrationSqrtPrice)
+
+	swapBaseAmount, err := GetBaseTokenForSwap(
+		configParam.SqrtStartPrice.BigInt(),
+		migrationSqrtPrice,
+		configParam.Curve,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("swap base amount: %w", err)
+	}
+
+	migrationQuoteAmount := mulDivDown(
+		configParam.MigrationQuoteThreshold,
+		100-uint64(configParam.MigrationFee.FeePercentage),
+		100,
+	)
+	migrationBaseAmount, err := GetMigrationBaseToken(
+		new(big.Int).SetUint64(migrationQuoteAmount),
+		migrationSqrtPrice,
+		types.MigrationOption(configParam.MigrationOption),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("migration base amount: %w", err)
+	}
+
+	if !swapBaseAmount.IsUint64() || !migrationBaseAmount.IsUint64() {
+		return nil, fmt.Errorf(
+			"swapBaseAmount(%s) or migrationBaseAmount(%s) cannot fit into uint64",
+			swapBaseAmount, migrationBaseAmount,
+		)
+	}
+	config.SwapBaseAmount = swapBaseAmount.Uint64()
+	config.MigrationBaseThreshold = migrationBaseAmount.Uint64()
+
+	return config, nil
+}
+
+// Backtest replays trades against a fresh pool created from param.Config using the SDK swap maths,
+// so the fee scheduler, rate limiter and dynamic fee apply as they would on chain.
+// Trades fill partially at the migration threshold, later trades are skipped,
+// and trades the program would reject are recorded without changing the pool.
+func Backtest(param types.BacktestParam) (types.BacktestResult, error) {
+	config, err := GetPoolConfigFromConfigParameters(param.Config)
+	if err != nil {
+		return types.BacktestResult{}, err
+	}
+
+	pool := &dbc.VirtualPoolAccount{
+		SqrtPrice: config.SqrtStartPrice,
+		VolatilityTracker: dbc.VolatilityTracker{
+			SqrtPriceReference: config.SqrtStartPrice,
+		},
+	}
+	baseReserve := GetBaseTokenTotalSupply(pool, config)
+	if !baseReserve.IsUint64() {
+		return types.BacktestResult{}, fmt.Errorf("base token supply(%s) cannot fit into uint64", baseReserve)
+	}
+	pool.BaseReserve = baseReserve.Uint64()
+
+	tokenBaseDecimal := types.TokenDecimal(config.TokenDecimal)
+	pricePoint := func(elapsedSeconds uint64) types.BacktestPricePoint {
+		price, _ := GetPriceFromSqrtPrice(
+			pool.SqrtPrice.BigInt(),
+			tokenBaseDecimal,
+			param.TokenQuoteDecimal,
+		).Float64()
+		return types.BacktestPricePoint{
+			ElapsedSeconds: elapsedSeconds,
+			SqrtPrice:      pool.SqrtPrice.BigInt(),
+			Price:          price,
+			QuoteReserve:   pool.QuoteReserve,
+		}
+	}
+
+	result := types.BacktestResult{
+		PricePath: []types.BacktestPricePoint{pricePoint(0)},
+	}
+
+	for i, trade := range param.Trades {
+		if i > 0 && trade.ElapsedSeconds < param.Trades[i-1].ElapsedSeconds {
+			return types.BacktestResult{}, fmt.Errorf("trades must be sorted by elapsed seconds, trade %d is out of order", i)
+		}
+
+		if result.Migrated {
+			result.TradesSkipped++
+			continue
+		}
+
+		next, fees, err := backtestSwap(pool, config, trade)
+		if err != nil {
+			result.Rejections = append(result.Rejections, types.BacktestRejection{
+				TradeIndex: i,
+				Reason:     err.Error(),
+			})
+			continue
+		}
+
+		pool = next
+		addBacktestFees(&result.Fees, fees)
+		result.TradesExecuted++
+		result.PricePath = append(result.PricePath, pricePoint(trade.ElapsedSeconds))
+
+		if pool.QuoteReserve >= config.MigrationQuoteThreshold {
+			result.Migrated = true
+			result.TimeToMigration = trade.ElapsedSeconds
+		}
+	}
+
+	surplus, err := GetPoolSurplus(pool, config, 0)
+	if err != nil {
+		return types.BacktestResult{}, err
+	}
+
+	result.FinalBaseReserve = pool.BaseReserve
+	result.FinalQuoteReserve = pool.QuoteReserve
+	result.FinalSqrtPrice = pool.SqrtPrice.BigInt()
+	result.FinalPrice = result.PricePath[len(result.PricePath)-1].Price
+	result.Surplus = surplus
+
+	return result, nil
+}
+
+// backtestSwap applies one trade to a copy of pool, returning the new pool state and the fees it paid.
+func backtestSwap(
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	trade types.BacktestTrade,
+) (*dbc.VirtualPoolAccount, types.BacktestFees, error) {
+	next := *pool
+
+	currentPoint := new(big.Int).SetUint64(trade.ElapsedSeconds)
+	if types.ActivationType(config.ActivationType) == types.ActivationTypeSlot {
+		currentPoint = new(big.Int).SetUint64(trade.ElapsedSeconds * constants.TimestampDuration / constants.SlotDuration)
+	}
+
+	tracker, err := maths.UpdateVolatilityReferences(
+		config.PoolFees.DynamicFee,
+		next.VolatilityTracker,
+		next.SqrtPrice.BigInt(),
+		trade.ElapsedSeconds,
+	)
+	if err != nil {
+		return nil, types.BacktestFees{}, err
+	}
+	next.VolatilityTracker = tracker
+
+	quote, err := maths.SwapQuotePartialFill(
+		&next,
+		config,
+		trade.SwapBaseForQuote,
+		new(big.Int).SetUint64(trade.AmountIn),
+		0,
+		trade.HasReferral,
+		currentPoint,
+	)
+	if err != nil {
+		return nil, types.BacktestFees{}, err
+	}
+	swap := quote.SwapResult2
+
+	tradeDirection := types.TradeDirectionQuoteToBase
+	if trade.SwapBaseForQuote {
+		tradeDirection = types.TradeDirectionBaseToQuote
+	}
+	feeMode := maths.GetFeeMode(types.CollectFeeMode(config.CollectFeeMode), tradeDirection, trade.HasReferral)
+
+	// the curve pays out the output amount plus any fee taken from the output
+	curveOutput := swap.OutputAmount
+	if !feeMode.FeesOnInput {
+		curveOutput += swap.TradingFee + swap.ProtocolFee + swap.ReferralFee
+	}
+
+	if trade.SwapBaseForQuote {
+		if curveOutput > next.QuoteReserve {
+			return nil, types.BacktestFees{}, fmt.Errorf("output(%d) exceeds quote reserve(%d)", curveOutput, next.QuoteReserve)
+		}
+		next.BaseReserve += swap.ExcludedFeeInputAmount
+		next.QuoteReserve -= curveOutput
+	} else {
+		if curveOutput > next.BaseReserve {
+			return nil, types.BacktestFees{}, fmt.Errorf("output(%d) exceeds base reserve(%d)", curveOutput, next.BaseReserve)
+		}
+		next.QuoteReserve += swap.ExcludedFeeInputAmount
+		next.BaseReserve -= curveOutput
+	}
+	next.SqrtPrice = swap.NextSqrtPrice
+
+	if next.VolatilityTracker, err = maths.UpdateVolatilityAccumulator(
+		config.PoolFees.DynamicFee,
+		next.VolatilityTracker,
+		next.SqrtPrice.BigInt(),
+		trade.ElapsedSeconds,
+	); err != nil {
+		return nil, types.BacktestFees{}, err
+	}
+
+	creatorFee := mulDivDown(swap.TradingFee, uint64(config.CreatorTradingFeePercentage), 100)
+	partnerFee := swap.TradingFee - creatorFee
+
+	var fees types.BacktestFees
+	if feeMode.FeesOnBaseToken {
+		fees.PartnerBaseFee, fees.CreatorBaseFee = partnerFee, creatorFee
+		fees.ProtocolBaseFee, fees.ReferralBaseFee = swap.ProtocolFee, swap.ReferralFee
+		next.PartnerBaseFee += partnerFee
+		next.CreatorBaseFee += creatorFee
+		next.ProtocolBaseFee += swap.ProtocolFee
+	} else {
+		fees.PartnerQuoteFee, fees.CreatorQuoteFee = partnerFee, creatorFee
+		fees.ProtocolQuoteFee, fees.ReferralQuoteFee = swap.ProtocolFee, swap.ReferralFee
+		next.PartnerQuoteFee += partnerFee
+		next.CreatorQuoteFee += creatorFee
+		next.ProtocolQuoteFee += swap.ProtocolFee
+	}
+
+	return &next, fees, nil
+}
+
+func addBacktestFees(total *types.BacktestFees, fees types.BacktestFees) {
+	total.PartnerBaseFee += fees.PartnerBaseFee
+	total.PartnerQuoteFee += fees.PartnerQuoteFee
+	total.CreatorBaseFee += fees.CreatorBaseFee
+	total.CreatorQuoteFee += fees.CreatorQuoteFee
+	total.ProtocolBaseFee += fees.ProtocolBaseFee
+	total.ProtocolQuoteFee += fees.ProtocolQuoteFee
+	total.ReferralBaseFee += fees.ReferralBaseFee
+	total.ReferralQuoteFee += fees.ReferralQuoteFee
+}
+
+// GetBacktestSyntheticTrades generates a reproducible trade sequence from a seed.
+func GetBacktestSyntheticTrades(param types.BacktestSyntheticTradesParam) ([]types.BacktestTrade, error) {
+	if param.NumberOfTrades <= 0 {
+		return nil, errors.New("number of trades must be greater than 0")
+	}
+	if param.SellRatio < 0 || param.SellRatio > 1 {
+		return nil, fmt.Errorf("sell ratio(%v) must be between 0 and 1", param.SellRatio)
+	}
+	if param.Jitter < 0 || param.Jitter > 1 {
+		return nil, fmt.Errorf("jitter(%v) must be between 0 and 1", param.Jitter)
+	}
+	if param.BuyAmount == 0 {
+		return nil, errors.New("buy amount must be greater than 0")
+	}
+	if param.SellRatio > 0 && param.SellAmount == 0 {
+		return nil, errors.New("sell amount must be greater than 0 when sell ratio is set")
+	}
+
+	rng := rand.New(rand.NewPCG(param.Seed, param.Seed))
+	jitter := func(amount uint64) uint64 {
+		factor := 1 + param.Jitter*(2*rng.Float64()-1)
+		return max(uint64(float64(amount)*factor), 1)
+	}
+
+	trades := make([]types.BacktestTrade, param.NumberOfTrades)
+	for i := range trades {
+		trades[i].ElapsedSeconds = uint64(i) * param.IntervalSeconds
+		if rng.Float64() < param.SellRatio {
+			trades[i].SwapBaseForQuote = true
+			trades[i].AmountIn = jitter(param.SellAmount)
+			continue
+		}
+		trades[i].AmountIn = jitter(param.BuyAmount)
+	}
+
+	return trades, nil
+}
+
+// GetBacktestTradesFromSwapEvents turns a pool's recorded swap events into backtest trades,
+// timed from the first event and with input amounts multiplied by scale.
+func GetBacktestTradesFromSwapEvents(events []dbc.EvtSwap, scale float64) ([]types.BacktestTrade, error) {
+	trades := make([]backtestEventTrade, len(events))
+	for i, event := range events {
+		trades[i] = backtestEventTrade{
+			timestamp:      event.CurrentTimestamp,
+			tradeDirection: event.TradeDirection,
+			amountIn:       event.AmountIn,
+			hasReferral:    event.HasReferral,
+		}
+	}
+	return getBacktestTradesFromEvents(trades, scale)
+}
+
+// GetBacktestTradesFromSwap2Events is GetBacktestTradesFromSwapEvents for swap2 events,
+// replaying the fee inclusive input amount that was actually used.
+func GetBacktestTradesFromSwap2Events(events []dbc.EvtSwap2, scale float64) ([]types.BacktestTrade, error) {
+	trades := make([]backtestEventTrade, len(events))
+	for i, event := range events {
+		trades[i] = backtestEventTrade{
+			timestamp:      event.CurrentTimestamp,
+			tradeDirection: event.TradeDirection,
+			amountIn:       event.SwapResult.IncludedFeeInputAmount,
+			hasReferral:    event.HasReferral,
+		}
+	}
+	return getBacktestTradesFromEvents(trades, scale)
+}
+
+type backtestEventTrade struct {
+	timestamp      uint64
+	tradeDirection uint8
+	amountIn       uint64
+	hasReferral    bool
+}
+
+func getBacktestTradesFromEvents(events []backtestEventTrade, scale float64) ([]types.BacktestTrade, error) {
+	if len(events) == 0 {
+		return nil, errors.New("events cannot be empty")
+	}
+	if scale <= 0 {
+		return nil, fmt.Errorf("scale(%v) must be greater than 0", scale)
+	}
+
+	slices.SortStableFunc(events, func(a, b backtestEventTrade) int {
+		return cmp.Compare(a.timestamp, b.timestamp)
+	})
+
+	scaleRat, err := RatFromFloat64(scale)
+	if err != nil {
+		return nil, err
+	}
+	startTimestamp := events[0].timestamp
+
+	trades := make([]types.BacktestTrade, 0, len(events))
+	for _, event := range events {
+		amountIn := RatFloor(new(big.Rat).Mul(new(big.Rat).SetUint64(event.amountIn), scaleRat))
+		if !amountIn.IsUint64() {
+			return nil, fmt.Errorf("scaled amount(%s) cannot fit into uint64", amountIn)
+		}
+		if amountIn.Sign() == 0 {
+			continue
+		}
+
+		trades = append(trades, types.BacktestTrade{
+			ElapsedSeconds:   event.timestamp - startTimestamp,
+			SwapBaseForQuote: types.TradeDirection(event.tradeDirection) == types.TradeDirectionBaseToQuote,
+			AmountIn:         amountIn.Uint64(),
+			HasReferral:      event.hasReferral,
+		})
+	}
+
+	return trades, nil
+}
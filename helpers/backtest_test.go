@@ -0,0 +1,124 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBacktest(t *testing.T) {
+	config, err := helpers.BuildCurve(types.BuildCurveParam{
+		BuildCurveBaseParam: types.BuildCurveBaseParam{
+			TotalTokenSupply:  1_000_000_000,
+			MigrationOption:   types.MigrationOptionMET_DAMM_V2,
+			TokenBaseDecimal:  types.TokenDecimalSIX,
+			TokenQuoteDecimal: types.TokenDecimalNINE,
+			BaseFeeParams: types.BaseFeeParams{
+				BaseFeeMode: types.BaseFeeModeFeeSchedulerLinear,
+				FeeSchedulerParam: &types.FeeSchedulerParams{
+					StartingFeeBps: 100,
+					EndingFeeBps:   100,
+				},
+			},
+			DynamicFeeEnabled:           true,
+			ActivationType:              types.ActivationTypeTimestamp,
+			CollectFeeMode:              types.CollectFeeModeQuoteToken,
+			MigrationFeeOption:          types.MigrationFeeOptionFixedBps100,
+			TokenType:                   types.TokenTypeSPL,
+			PartnerLockedLpPercentage:   100,
+			CreatorTradingFeePercentage: 50,
+		},
+		PercentageSupplyOnMigration: 20,
+		MigrationQuoteThreshold:     85,
+	})
+	if err != nil {
+		t.Fatalf("BuildCurve errored: %s", err.Error())
+	}
+
+	t.Run("buys migrate the pool", func(t *testing.T) {
+		trades, err := helpers.GetBacktestSyntheticTrades(types.BacktestSyntheticTradesParam{
+			NumberOfTrades:  200,
+			IntervalSeconds: 10,
+			BuyAmount:       1_000_000_000,
+			Jitter:          0.5,
+			Seed:            7,
+		})
+		assert.NoError(t, err)
+
+		result, err := helpers.Backtest(types.BacktestParam{
+			Config:            config,
+			Trades:            trades,
+			TokenQuoteDecimal: types.TokenDecimalNINE,
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, result.Migrated)
+		assert.Empty(t, result.Rejections)
+		assert.Equal(t, len(trades), result.TradesExecuted+result.TradesSkipped)
+		assert.Positive(t, result.TradesSkipped)
+		assert.Equal(t, uint64(result.TradesExecuted-1)*10, result.TimeToMigration)
+		assert.GreaterOrEqual(t, result.FinalQuoteReserve, config.MigrationQuoteThreshold)
+
+		assert.Positive(t, result.Fees.PartnerQuoteFee)
+		assert.Positive(t, result.Fees.ProtocolQuoteFee)
+		assert.InDelta(t, result.Fees.PartnerQuoteFee, result.Fees.CreatorQuoteFee, float64(result.TradesExecuted))
+		assert.Zero(t, result.Fees.PartnerBaseFee)
+
+		assert.Len(t, result.PricePath, result.TradesExecuted+1)
+		for i := 1; i < len(result.PricePath); i++ {
+			assert.Greater(t, result.PricePath[i].Price, result.PricePath[i-1].Price)
+		}
+		assert.Equal(t, result.PricePath[len(result.PricePath)-1].Price, result.FinalPrice)
+	})
+
+	t.Run("rejected trades leave the pool untouched", func(t *testing.T) {
+		result, err := helpers.Backtest(types.BacktestParam{
+			Config: config,
+			Trades: []types.BacktestTrade{
+				{ElapsedSeconds: 0, SwapBaseForQuote: true, AmountIn: 1_000_000},
+				{ElapsedSeconds: 5, AmountIn: 2_000_000_000},
+				{ElapsedSeconds: 20, SwapBaseForQuote: true, AmountIn: 1_000_000},
+			},
+			TokenQuoteDecimal: types.TokenDecimalNINE,
+		})
+		assert.NoError(t, err)
+
+		assert.False(t, result.Migrated)
+		assert.Equal(t, 2, result.TradesExecuted)
+		assert.Len(t, result.Rejections, 1)
+		assert.Equal(t, 0, result.Rejections[0].TradeIndex)
+		assert.Less(t, result.PricePath[2].Price, result.PricePath[1].Price)
+		assert.Zero(t, result.Surplus.TotalSurplus)
+	})
+
+	t.Run("unsorted trades", func(t *testing.T) {
+		_, err := helpers.Backtest(types.BacktestParam{
+			Config: config,
+			Trades: []types.BacktestTrade{
+				{ElapsedSeconds: 10, AmountIn: 1_000},
+				{ElapsedSeconds: 5, AmountIn: 1_000},
+			},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetBacktestTradesFromSwapEvents(t *testing.T) {
+	trades, err := helpers.GetBacktestTradesFromSwapEvents([]dbc.EvtSwap{
+		{CurrentTimestamp: 1_700_000_030, TradeDirection: uint8(types.TradeDirectionBaseToQuote), AmountIn: 500},
+		{CurrentTimestamp: 1_700_000_000, TradeDirection: uint8(types.TradeDirectionQuoteToBase), AmountIn: 1_000, HasReferral: true},
+		{CurrentTimestamp: 1_700_000_010, TradeDirection: uint8(types.TradeDirectionQuoteToBase), AmountIn: 1},
+	}, 0.5)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []types.BacktestTrade{
+		{ElapsedSeconds: 0, AmountIn: 500, HasReferral: true},
+		{ElapsedSeconds: 30, SwapBaseForQuote: true, AmountIn: 250},
+	}, trades)
+
+	_, err = helpers.GetBacktestTradesFromSwapEvents(nil, 1)
+	assert.Error(t, err)
+}
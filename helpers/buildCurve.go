@@ -3,49 +3,68 @@ package helpers
 import (
 	"dbcGoSDK/constants"
 	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/maths"
 	"dbcGoSDK/types"
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
-
-	"github.com/ALTree/bigfloat"
 )
 
 // BuildCurve builds a custom constant product curve.
 func BuildCurve(param types.BuildCurveParam) (dbc.ConfigParameters, error) {
+	percentageSupplyOnMigration, err := RatFromFloat64(param.PercentageSupplyOnMigration)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+	migrationQuoteThreshold, err := RatFromFloat64(param.MigrationQuoteThreshold)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
-	migrationBaseSupply := new(big.Float).Quo(
-		new(big.Float).Mul(
-			new(big.Float).SetUint64(param.TotalTokenSupply),
-			big.NewFloat(param.PercentageSupplyOnMigration),
-		),
-		constants.HundredInBigFloat,
-	)
+	return BuildCurveRat(types.BuildCurveRatParam{
+		BuildCurveBaseParam:         param.BuildCurveBaseParam,
+		PercentageSupplyOnMigration: percentageSupplyOnMigration,
+		MigrationQuoteThreshold:     migrationQuoteThreshold,
+	})
+}
 
-	migrationQuoteAmount := GetMigrationQuoteAmountFromMigrationQuoteThreshold(
-		big.NewFloat(param.MigrationQuoteThreshold),
-		param.MigrationFee.FeePercentage,
+// BuildCurveRat builds a custom constant product curve from exact inputs.
+// Every intermediate value is a rational or an integer, so the output is reproducible across platforms.
+func BuildCurveRat(param types.BuildCurveRatParam) (dbc.ConfigParameters, error) {
+	if param.PercentageSupplyOnMigration == nil || param.MigrationQuoteThreshold == nil {
+		return dbc.ConfigParameters{},
+			errors.New("percentageSupplyOnMigration and migrationQuoteThreshold are required")
+	}
+
+	migrationFeePercent, err := RatFromFloat64(param.MigrationFee.FeePercentage)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+
+	migrationBaseSupply := new(big.Rat).Quo(
+		new(big.Rat).Mul(
+			new(big.Rat).SetUint64(param.TotalTokenSupply),
+			param.PercentageSupplyOnMigration,
+		),
+		big.NewRat(100, 1),
 	)
+	if migrationBaseSupply.Sign() <= 0 {
+		return dbc.ConfigParameters{}, errors.New("migration base supply must be greater than zero")
+	}
 
-	migrationPrice := new(big.Float).Quo(
-		migrationQuoteAmount, migrationBaseSupply,
+	migrationQuoteAmount := GetMigrationQuoteAmountFromMigrationQuoteThresholdRat(
+		param.MigrationQuoteThreshold,
+		migrationFeePercent,
 	)
 
-	migrateSqrtPrice := GetSqrtPriceFromPrice(
-		migrationPrice,
+	migrateSqrtPrice := GetSqrtPriceFromPriceRat(
+		new(big.Rat).Quo(migrationQuoteAmount, migrationBaseSupply),
 		param.TokenBaseDecimal,
 		param.TokenQuoteDecimal,
 	)
 
-	migrationQuoteAmountInLamportBigFloat := new(big.Float).Mul(
-		migrationQuoteAmount,
-		new(big.Float).SetFloat64(math.Pow10(int(param.TokenQuoteDecimal))),
-	)
-	migrationQuoteAmountInLamport, _ := migrationQuoteAmountInLamportBigFloat.Int(nil)
-
 	migrationBaseAmount, err := GetMigrationBaseToken(
-		migrationQuoteAmountInLamport,
+		ConvertToLamportsRat(migrationQuoteAmount, param.TokenQuoteDecimal),
 		migrateSqrtPrice,
 		param.MigrationOption,
 	)
@@ -53,55 +72,30 @@ func BuildCurve(param types.BuildCurveParam) (dbc.ConfigParameters, error) {
 		return dbc.ConfigParameters{}, err
 	}
 
-	totalSupply := ConvertToLamports(float64(param.TotalTokenSupply), param.TokenBaseDecimal)
-	if !totalSupply.IsUint64() {
-		return dbc.ConfigParameters{},
-			fmt.Errorf("cannot fit totalSupply(%s) into uint64", totalSupply)
-	}
-
-	totalLeftover := ConvertToLamports(
-		float64(param.Leftover), param.TokenBaseDecimal,
+	totalSupply := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.TotalTokenSupply), param.TokenBaseDecimal,
 	)
-
-	lockedVesting, err := GetLockedVestingParams(
-		param.LockedVestingParam.TotalLockedVestingAmount,
-		param.LockedVestingParam.NumberOfVestingPeriod,
-		param.LockedVestingParam.CliffUnlockAmount,
-		param.LockedVestingParam.TotalVestingDuration,
-		param.LockedVestingParam.CliffDurationFromMigrationTime,
-		param.TokenBaseDecimal,
+	totalLeftover := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.Leftover), param.TokenBaseDecimal,
 	)
+
+	lockedVesting, err := getLockedVestingFromBaseParam(param.BuildCurveBaseParam)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
 
-	totalVestingAmount := GetTotalVestingAmount(
-		dbc.LockedVestingParams{
-			AmountPerPeriod:                lockedVesting.AmountPerPeriod,
-			CliffDurationFromMigrationTime: lockedVesting.CliffDurationFromMigrationTime,
-			Frequency:                      lockedVesting.Frequency,
-			NumberOfPeriod:                 lockedVesting.NumberOfPeriod,
-			CliffUnlockAmount:              lockedVesting.CliffUnlockAmount,
-		},
-	)
-
 	swapAmount := new(big.Int).Sub(
 		new(big.Int).Sub(
 			new(big.Int).Sub(totalSupply, migrationBaseAmount),
-			totalVestingAmount,
+			GetTotalVestingAmount(lockedVesting),
 		),
 		totalLeftover,
 	)
 
-	migrationQuoteThresholdInLamport := ConvertToLamports(
+	migrationQuoteThresholdInLamport := ConvertToLamportsRat(
 		param.MigrationQuoteThreshold, param.TokenQuoteDecimal,
 	)
 
-	if !migrationQuoteThresholdInLamport.IsUint64() {
-		return dbc.ConfigParameters{},
-			fmt.Errorf("cannot fit migrationQuoteThresholdInLamport(%s) as uint64", migrationQuoteThresholdInLamport)
-	}
-
 	firstCurve, err := GetFirstCurve(
 		migrateSqrtPrice,
 		migrationBaseAmount,
@@ -144,122 +138,89 @@ func BuildCurve(param types.BuildCurveParam) (dbc.ConfigParameters, error) {
 		})
 	}
 
-	baseFee, err := GetBaseFeeParams(
-		param.BaseFeeParams,
-		param.TokenQuoteDecimal,
-		param.ActivationType,
+	return buildConfigParameters(
+		param.BuildCurveBaseParam,
+		migrationQuoteThresholdInLamport,
+		firstCurve.SqrtStartPrice,
+		totalSupply,
+		lockedVesting,
+		firstCurve.Curve,
 	)
+}
+
+// BuildCurveWithMarketCap builds a custom constant product curve by market cap.
+func BuildCurveWithMarketCap(
+	param types.BuildCurveWithMarketCapParam,
+) (dbc.ConfigParameters, error) {
+	initialMarketCap, err := RatFromFloat64(param.InitialMarketCap)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
-
-	var dynamicField *dbc.DynamicFeeParameters
-	if param.DynamicFeeEnabled {
-		baseFeeBp := param.BaseFeeParams.FeeSchedulerParam.EndingFeeBps
-		if param.BaseFeeParams.BaseFeeMode == types.BaseFeeModeRateLimiter {
-			baseFeeBp = param.BaseFeeParams.RateLimiterParam.BaseFeeBps
-		}
-		d, err := GetDynamicFeeParams(baseFeeBp, 0)
-		if err != nil {
-			return dbc.ConfigParameters{}, err
-		}
-		dynamicField = &d
+	migrationMarketCap, err := RatFromFloat64(param.MigrationMarketCap)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
 	}
 
-	return dbc.ConfigParameters{
-		PoolFees: dbc.PoolFeeParameters{
-			BaseFee: dbc.BaseFeeParameters{
-				CliffFeeNumerator: baseFee.CliffFeeNumerator,
-				FirstFactor:       baseFee.FirstFactor,
-				SecondFactor:      baseFee.SecondFactor,
-				ThirdFactor:       baseFee.ThirdFactor,
-				BaseFeeMode:       uint8(baseFee.BaseFeeMode),
-			},
-			DynamicFee: dynamicField,
-		},
-		ActivationType:            uint8(param.ActivationType),
-		CollectFeeMode:            uint8(param.CollectFeeMode),
-		MigrationOption:           uint8(param.MigrationFeeOption),
-		TokenType:                 uint8(param.TokenType),
-		TokenDecimal:              uint8(param.TokenBaseDecimal),
-		MigrationQuoteThreshold:   migrationQuoteThresholdInLamport.Uint64(),
-		PartnerLpPercentage:       param.PartnerLpPercentage,
-		CreatorLpPercentage:       param.CreatorLpPercentage,
-		PartnerLockedLpPercentage: param.PartnerLockedLpPercentage,
-		CreatorLockedLpPercentage: param.CreatorLockedLpPercentage,
-		SqrtStartPrice:            MustBigIntToUint128(firstCurve.SqrtStartPrice),
-		LockedVesting:             lockedVesting,
-		MigrationFeeOption:        uint8(param.MigrationFeeOption),
-		TokenSupply: &dbc.TokenSupplyParams{
-			PreMigrationTokenSupply:  totalSupply.Uint64(),
-			PostMigrationTokenSupply: totalSupply.Uint64(),
-		},
-		CreatorTradingFeePercentage: param.CreatorTradingFeePercentage,
-		TokenUpdateAuthority:        param.TokenUpdateAuthority,
-		MigrationFee: dbc.MigrationFee{
-			FeePercentage:        uint8(param.MigrationFee.FeePercentage),
-			CreatorFeePercentage: uint8(param.MigrationFee.CreatorFeePercentage),
-		},
-		MigratedPoolFee: GetMigratedPoolFeeParams(
-			param.MigrationOption,
-			param.MigrationFeeOption,
-			param.MigratedPoolFee,
-		),
-		Curve: firstCurve.Curve,
-	}, nil
-
+	return BuildCurveWithMarketCapRat(types.BuildCurveWithMarketCapRatParam{
+		BuildCurveBaseParam: param.BuildCurveBaseParam,
+		InitialMarketCap:    initialMarketCap,
+		MigrationMarketCap:  migrationMarketCap,
+	})
 }
 
-func BuildCurveWithMarketCap(
-	param types.BuildCurveWithMarketCapParam,
+// BuildCurveWithMarketCapRat builds a custom constant product curve by exact market cap.
+func BuildCurveWithMarketCapRat(
+	param types.BuildCurveWithMarketCapRatParam,
 ) (dbc.ConfigParameters, error) {
+	if param.InitialMarketCap == nil || param.MigrationMarketCap == nil {
+		return dbc.ConfigParameters{}, errors.New("initialMarketCap and migrationMarketCap are required")
+	}
 
-	lockedVesting, err := GetLockedVestingParams(
-		param.LockedVestingParam.TotalLockedVestingAmount,
-		param.LockedVestingParam.NumberOfVestingPeriod,
-		param.LockedVestingParam.CliffUnlockAmount,
-		param.LockedVestingParam.TotalVestingDuration,
-		param.LockedVestingParam.CliffDurationFromMigrationTime,
-		param.TokenBaseDecimal,
-	)
+	lockedVesting, err := getLockedVestingFromBaseParam(param.BuildCurveBaseParam)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
 
-	totalLeftover := ConvertToLamports(
-		float64(param.Leftover), param.TokenBaseDecimal,
+	totalLeftover := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.Leftover), param.TokenBaseDecimal,
+	)
+	totalSupply := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.TotalTokenSupply), param.TokenBaseDecimal,
 	)
 
-	totalSupply := ConvertToLamports(float64(param.TotalTokenSupply), param.TokenBaseDecimal)
-	if !totalSupply.IsUint64() {
-		return dbc.ConfigParameters{},
-			fmt.Errorf("cannot fit totalSupply(%s) into uint64", totalSupply)
-	}
-
-	percentageSupplyOnMigration := GetPercentageSupplyOnMigration(
-		big.NewFloat(param.InitialMarketCap),
-		big.NewFloat(param.MigrationMarketCap),
+	percentageSupplyOnMigration, err := GetPercentageSupplyOnMigrationRat(
+		param.InitialMarketCap,
+		param.MigrationMarketCap,
 		lockedVesting,
 		totalLeftover,
 		totalSupply,
 	)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
-	migrationQuoteAmount := GetMigrationQuoteAmount(
-		big.NewFloat(param.MigrationMarketCap),
-		big.NewFloat(percentageSupplyOnMigration),
+	migrationQuoteAmount := GetMigrationQuoteAmountRat(
+		param.MigrationMarketCap,
+		percentageSupplyOnMigration,
 	)
 
-	migrationQuoteThreshold := GetMigrationQuoteThresholdFromMigrationQuoteAmount(
+	migrationFeePercent, err := RatFromFloat64(param.MigrationFee.FeePercentage)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+	migrationQuoteThreshold, err := GetMigrationQuoteThresholdFromMigrationQuoteAmountRat(
 		migrationQuoteAmount,
-		param.MigrationFee.FeePercentage,
+		migrationFeePercent,
 	)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
-	migrationQuoteThresholdFloat64, _ := migrationQuoteThreshold.Float64()
-	return BuildCurve(
-		types.BuildCurveParam{
+	return BuildCurveRat(
+		types.BuildCurveRatParam{
 			BuildCurveBaseParam:         param.BuildCurveBaseParam,
 			PercentageSupplyOnMigration: percentageSupplyOnMigration,
-			MigrationQuoteThreshold:     migrationQuoteThresholdFloat64,
+			MigrationQuoteThreshold:     migrationQuoteThreshold,
 		},
 	)
 }
@@ -268,13 +229,27 @@ func BuildCurveWithMarketCap(
 func BuildCurveWithTwoSegments(
 	param types.BuildCurveWithTwoSegmentsParam,
 ) (dbc.ConfigParameters, error) {
+	return BuildCurveWithTwoSegmentsRat(types.BuildCurveWithTwoSegmentsRatParam{
+		BuildCurveBaseParam:         param.BuildCurveBaseParam,
+		InitialMarketCap:            new(big.Rat).SetUint64(param.InitialMarketCap),
+		MigrationMarketCap:          new(big.Rat).SetUint64(param.MigrationMarketCap),
+		PercentageSupplyOnMigration: param.PercentageSupplyOnMigration,
+	})
+}
 
-	totalSupply := ConvertToLamports(float64(param.TotalTokenSupply), param.TokenBaseDecimal)
-	if !totalSupply.IsUint64() {
+// BuildCurveWithTwoSegmentsRat builds a custom constant product curve by exact market cap.
+func BuildCurveWithTwoSegmentsRat(
+	param types.BuildCurveWithTwoSegmentsRatParam,
+) (dbc.ConfigParameters, error) {
+	if param.InitialMarketCap == nil || param.MigrationMarketCap == nil {
 		return dbc.ConfigParameters{},
-			fmt.Errorf("BuildCurveWithTwoSegments:cannot fit totalSupply(%s) into uint64", totalSupply)
+			errors.New("BuildCurveWithTwoSegments:initialMarketCap and migrationMarketCap are required")
 	}
 
+	totalSupply := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.TotalTokenSupply), param.TokenBaseDecimal,
+	)
+
 	migrationBaseSupply := new(big.Int).Quo(
 		new(big.Int).Mul(
 			new(big.Int).SetUint64(uint64(param.PercentageSupplyOnMigration)),
@@ -282,30 +257,33 @@ func BuildCurveWithTwoSegments(
 		),
 		big.NewInt(100),
 	)
+	if migrationBaseSupply.Sign() <= 0 {
+		return dbc.ConfigParameters{},
+			errors.New("BuildCurveWithTwoSegments:migration base supply must be greater than zero")
+	}
 
-	migrationQuoteAmount := GetMigrationQuoteAmount(
-		new(big.Float).SetUint64(param.MigrationMarketCap),
-		new(big.Float).SetUint64(uint64(param.PercentageSupplyOnMigration)),
+	migrationQuoteAmount := GetMigrationQuoteAmountRat(
+		param.MigrationMarketCap,
+		new(big.Rat).SetUint64(uint64(param.PercentageSupplyOnMigration)),
 	)
 
-	migrationQuoteThreshold := GetMigrationQuoteThresholdFromMigrationQuoteAmount(
+	migrationFeePercent, err := RatFromFloat64(param.MigrationFee.FeePercentage)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+	migrationQuoteThreshold, err := GetMigrationQuoteThresholdFromMigrationQuoteAmountRat(
 		migrationQuoteAmount,
-		param.MigrationFee.FeePercentage,
+		migrationFeePercent,
 	)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
-	migrationPrice := new(big.Float).Quo(
-		migrationQuoteAmount, new(big.Float).SetInt(migrationBaseSupply))
-
-	migrationQuoteThresholdInLamport := new(big.Int)
-	new(big.Float).Mul(migrationQuoteThreshold,
-		big.NewFloat(math.Pow10(int(param.TokenQuoteDecimal)))).Int(migrationQuoteThresholdInLamport)
-
-	migrationQuoteAmountInLamport := new(big.Int)
-	new(big.Float).Mul(migrationQuoteAmount,
-		big.NewFloat(math.Pow10(int(param.TokenQuoteDecimal)))).Int(migrationQuoteAmountInLamport)
+	migrationQuoteThresholdInLamport := ConvertToLamportsRat(migrationQuoteThreshold, param.TokenQuoteDecimal)
+	migrationQuoteAmountInLamport := ConvertToLamportsRat(migrationQuoteAmount, param.TokenQuoteDecimal)
 
-	migrateSqrtPrice := GetSqrtPriceFromPrice(
-		migrationPrice,
+	migrateSqrtPrice := GetSqrtPriceFromPriceRat(
+		new(big.Rat).Quo(migrationQuoteAmount, new(big.Rat).SetInt(migrationBaseSupply)),
 		param.TokenBaseDecimal,
 		param.TokenQuoteDecimal,
 	)
@@ -319,70 +297,43 @@ func BuildCurveWithTwoSegments(
 		return dbc.ConfigParameters{}, err
 	}
 
-	lockedVesting, err := GetLockedVestingParams(
-		param.LockedVestingParam.TotalLockedVestingAmount,
-		param.LockedVestingParam.NumberOfVestingPeriod,
-		param.LockedVestingParam.CliffUnlockAmount,
-		param.LockedVestingParam.TotalVestingDuration,
-		param.LockedVestingParam.CliffDurationFromMigrationTime,
-		param.TokenBaseDecimal,
-	)
+	lockedVesting, err := getLockedVestingFromBaseParam(param.BuildCurveBaseParam)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
 
 	totalVestingAmount := GetTotalVestingAmount(lockedVesting)
-	totalLeftover := ConvertToLamports(float64(param.Leftover), param.TokenBaseDecimal)
+	totalLeftover := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.Leftover), param.TokenBaseDecimal,
+	)
 
-	initialSqrtPrice := GetSqrtPriceFromMarketCap(
+	initialSqrtPrice := GetSqrtPriceFromMarketCapRat(
 		param.InitialMarketCap,
 		param.TotalTokenSupply,
 		param.TokenBaseDecimal,
 		param.TokenQuoteDecimal,
 	)
 
-	// instantiate midSqrtPriceDecimal1
-	midSqrtPriceDecimal1 := new(big.Float).Sqrt(
-		new(big.Float).SetInt(
-			new(big.Int).Mul(migrateSqrtPrice, initialSqrtPrice),
-		),
-	)
-
-	midSqrtPrice1 := new(big.Int)
-	midSqrtPriceDecimal1.Int(midSqrtPrice1)
+	// mid_price1 = sqrt(p1 * p2)
+	midSqrtPrice1 := new(big.Int).Sqrt(new(big.Int).Mul(migrateSqrtPrice, initialSqrtPrice))
 
 	// mid_price2 = (p1 * p2^3)^(1/4)
-	numerator1, numerator2 := new(big.Float).SetInt(initialSqrtPrice),
-		new(big.Float).SetInt(new(big.Int).Exp(migrateSqrtPrice, big.NewInt(3), nil))
-	product1 := numerator1.Mul(numerator1, numerator2) // stills points to numerator1
-
-	// midSqrtPriceDecimal2, err := decimal.NewFromString(product1.String())
-	// if err != nil {
-	// 	return dbc.ConfigParameters{}, err
-	// }
-	// midSqrtPriceDecimal2 = midSqrtPriceDecimal2.Pow(decimal.NewFromFloat32(0.25))
-	// midSqrtPrice2 := midSqrtPriceDecimal2.BigInt()
-	f64, _ := product1.Float64()
-	midSqrtPrice2 := new(big.Int).SetInt64(int64(math.Floor(math.Pow(f64, 0.25))))
+	midSqrtPrice2, err := IntegerNthRoot(
+		new(big.Int).Mul(initialSqrtPrice, new(big.Int).Exp(migrateSqrtPrice, big.NewInt(3), nil)),
+		4,
+	)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
 	// mid_price3 = (p1^3 * p2)^(1/4)
-	// numerator3, err := decimal.NewFromString(initialSqrtPrice.String())
-	// if err != nil {
-	// 	return dbc.ConfigParameters{}, err
-	// }
-	// numerator3 = numerator3.Pow(decimal.NewFromInt(3))
-	numerator3 := new(big.Int).Exp(initialSqrtPrice, big.NewInt(3), nil)
-	// numerator4, err := decimal.NewFromString(migrateSqrtPrice.String())
-	// if err != nil {
-	// 	return dbc.ConfigParameters{}, err
-	// }
-	numerator4 := new(big.Int).Set(migrateSqrtPrice)
-	product2 := numerator3.Mul(numerator3, numerator4) // still points to numerator3
-
-	// midSqrtPriceDecimal3 := product2.Pow(decimal.NewFromFloat32(0.25))
-	// midSqrtPrice3 := midSqrtPriceDecimal3.BigInt()
-	f64, _ = product2.Float64()
-	midSqrtPrice3 := new(big.Int).SetInt64(int64(math.Floor(math.Pow(f64, 0.25))))
+	midSqrtPrice3, err := IntegerNthRoot(
+		new(big.Int).Mul(new(big.Int).Exp(initialSqrtPrice, big.NewInt(3), nil), migrateSqrtPrice),
+		4,
+	)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
 
 	swapAmount := new(big.Int).Sub(
 		new(big.Int).Sub(
@@ -414,6 +365,11 @@ func BuildCurveWithTwoSegments(
 		}
 	}
 
+	if len(curve) == 0 {
+		return dbc.ConfigParameters{},
+			errors.New("BuildCurveWithTwoSegments:cannot find a two segment curve with non-negative liquidity")
+	}
+
 	totalDynamicSupply, err := GetTotalSupplyFromCurve(
 		migrationQuoteThresholdInLamport,
 		sqrtStartPrice,
@@ -437,135 +393,88 @@ func BuildCurveWithTwoSegments(
 		}
 	}
 
-	baseFee, err := GetBaseFeeParams(
-		param.BaseFeeParams,
-		param.TokenQuoteDecimal,
-		param.ActivationType,
+	return buildConfigParameters(
+		param.BuildCurveBaseParam,
+		migrationQuoteThresholdInLamport,
+		sqrtStartPrice,
+		totalSupply,
+		lockedVesting,
+		curve,
 	)
+}
+
+// BuildCurveWithLiquidityWeights builds a custom constant product curve with liquidity weights.
+func BuildCurveWithLiquidityWeights(
+	param types.BuildCurveWithLiquidityWeightsParam,
+) (dbc.ConfigParameters, error) {
+	liquidityWeights, err := ratsFromFloat64(param.LiquidityWeights...)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
 
-	var dynamicField *dbc.DynamicFeeParameters
-	if param.DynamicFeeEnabled {
-		baseFeeBp := param.BaseFeeParams.FeeSchedulerParam.EndingFeeBps
-		if param.BaseFeeParams.BaseFeeMode == types.BaseFeeModeRateLimiter {
-			baseFeeBp = param.BaseFeeParams.RateLimiterParam.BaseFeeBps
-		}
-
-		d, err := GetDynamicFeeParams(baseFeeBp, 0)
-		if err != nil {
-			return dbc.ConfigParameters{}, err
-		}
-
-		dynamicField = &d
-	}
-
-	return dbc.ConfigParameters{
-		PoolFees: dbc.PoolFeeParameters{
-			BaseFee: dbc.BaseFeeParameters{
-				CliffFeeNumerator: baseFee.CliffFeeNumerator,
-				FirstFactor:       baseFee.FirstFactor,
-				SecondFactor:      baseFee.SecondFactor,
-				ThirdFactor:       baseFee.ThirdFactor,
-				BaseFeeMode:       uint8(baseFee.BaseFeeMode),
-			},
-			DynamicFee: dynamicField,
-		},
-		ActivationType:            uint8(param.ActivationType),
-		CollectFeeMode:            uint8(param.CollectFeeMode),
-		MigrationOption:           uint8(param.MigrationFeeOption),
-		TokenType:                 uint8(param.TokenType),
-		TokenDecimal:              uint8(param.TokenBaseDecimal),
-		MigrationQuoteThreshold:   migrationQuoteThresholdInLamport.Uint64(),
-		PartnerLpPercentage:       param.PartnerLpPercentage,
-		CreatorLpPercentage:       param.CreatorLpPercentage,
-		PartnerLockedLpPercentage: param.PartnerLockedLpPercentage,
-		CreatorLockedLpPercentage: param.CreatorLockedLpPercentage,
-		SqrtStartPrice:            MustBigIntToUint128(sqrtStartPrice),
-		LockedVesting:             lockedVesting,
-		MigrationFeeOption:        uint8(param.MigrationFeeOption),
-		TokenSupply: &dbc.TokenSupplyParams{
-			PreMigrationTokenSupply:  totalSupply.Uint64(),
-			PostMigrationTokenSupply: totalSupply.Uint64(),
-		},
-		CreatorTradingFeePercentage: param.CreatorTradingFeePercentage,
-		MigratedPoolFee: GetMigratedPoolFeeParams(
-			param.MigrationOption,
-			param.MigrationFeeOption,
-			param.MigratedPoolFee,
-		),
-		TokenUpdateAuthority: param.TokenUpdateAuthority,
-		MigrationFee: dbc.MigrationFee{
-			FeePercentage:        uint8(param.MigrationFee.FeePercentage),
-			CreatorFeePercentage: uint8(param.MigrationFee.CreatorFeePercentage),
-		},
-		Curve: curve,
-	}, nil
-}
-
-func PowBigFloat(x, y *big.Float) *big.Float {
-	// Uses ln/exp for power calc
-	lx := new(big.Float).SetPrec(256)
-	lx, _ = lx.SetString(fmt.Sprintf("%g", math.Log(float64FromBigFloat(x))))
-	result := math.Exp(float64FromBigFloat(y) * float64FromBigFloat(lx))
-	return new(big.Float).SetPrec(256).SetFloat64(result)
+	return BuildCurveWithLiquidityWeightsRat(types.BuildCurveWithLiquidityWeightsRatParam{
+		BuildCurveBaseParam: param.BuildCurveBaseParam,
+		InitialMarketCap:    new(big.Rat).SetUint64(param.InitialMarketCap),
+		MigrationMarketCap:  new(big.Rat).SetUint64(param.MigrationMarketCap),
+		LiquidityWeights:    liquidityWeights,
+	})
 }
 
-func float64FromBigFloat(b *big.Float) float64 {
-	f, _ := b.Float64()
-	return f
-}
-
-func BuildCurveWithLiquidityWeights(
-	param types.BuildCurveWithLiquidityWeightsParam,
+// BuildCurveWithLiquidityWeightsRat builds a custom constant product curve with exact liquidity weights.
+func BuildCurveWithLiquidityWeightsRat(
+	param types.BuildCurveWithLiquidityWeightsRatParam,
 ) (dbc.ConfigParameters, error) {
+	if param.InitialMarketCap == nil || param.MigrationMarketCap == nil {
+		return dbc.ConfigParameters{}, errors.New("initialMarketCap and migrationMarketCap are required")
+	}
+
+	if l := len(param.LiquidityWeights); l < 16 {
+		return dbc.ConfigParameters{},
+			fmt.Errorf("len of param.LiquidityWeights is expected to be >= 16, len is %d", l)
+	}
 
 	// 1. finding Pmax and Pmin
-	pMin := GetSqrtPriceFromMarketCap(
+	pMin := GetSqrtPriceFromMarketCapRat(
 		param.InitialMarketCap,
 		param.TotalTokenSupply,
 		param.TokenBaseDecimal,
 		param.TokenQuoteDecimal,
 	)
 
-	pMax := GetSqrtPriceFromMarketCap(
+	pMax := GetSqrtPriceFromMarketCapRat(
 		param.MigrationMarketCap,
 		param.TotalTokenSupply,
 		param.TokenBaseDecimal,
 		param.TokenQuoteDecimal,
 	)
 
-	// find q^16 = pMax / pMin
-	priceRatio := new(big.Float).Quo(
-		new(big.Float).SetInt(pMax),
-		new(big.Float).SetInt(pMin),
-	)
-
-	qDecimal := bigfloat.Pow(priceRatio, big.NewFloat(float64(1)/float64(16)))
-	sqrtPrices, currentPrice := make([]*big.Float, 0, 17), new(big.Float).SetInt(pMin)
+	if pMin.Sign() <= 0 || pMin.Cmp(pMax) >= 0 {
+		return dbc.ConfigParameters{},
+			fmt.Errorf("initial sqrt price(%s) must be positive and less than migration sqrt price(%s)", pMin, pMax)
+	}
 
-	// finding all prices
-	for range 17 {
-		sqrtPrices = append(sqrtPrices, currentPrice)
-		currentPrice = new(big.Float).Mul(qDecimal, currentPrice)
+	// q^16 = pMax / pMin, so p(i) = pMin * q^i = (pMin^(16-i) * pMax^i)^(1/16)
+	sqrtPrices := make([]*big.Int, 0, 17)
+	for i := range 17 {
+		product := new(big.Int).Mul(
+			new(big.Int).Exp(pMin, big.NewInt(int64(16-i)), nil),
+			new(big.Int).Exp(pMax, big.NewInt(int64(i)), nil),
+		)
+		sqrtPrice, err := IntegerNthRoot(product, 16)
+		if err != nil {
+			return dbc.ConfigParameters{}, err
+		}
+		sqrtPrices = append(sqrtPrices, sqrtPrice)
 	}
 
-	lockedVesting, err := GetLockedVestingParams(
-		param.LockedVestingParam.TotalLockedVestingAmount,
-		param.LockedVestingParam.NumberOfVestingPeriod,
-		param.LockedVestingParam.CliffUnlockAmount,
-		param.LockedVestingParam.TotalVestingDuration,
-		param.LockedVestingParam.CliffDurationFromMigrationTime,
-		param.TokenBaseDecimal,
-	)
+	lockedVesting, err := getLockedVestingFromBaseParam(param.BuildCurveBaseParam)
 	if err != nil {
 		return dbc.ConfigParameters{}, err
 	}
 
 	totalSupply, totalLeftover, totalVestingAmount :=
-		ConvertToLamports(float64(param.TotalTokenSupply), param.TokenBaseDecimal),
-		ConvertToLamports(float64(param.Leftover), param.TokenBaseDecimal),
+		ConvertToLamportsRat(new(big.Rat).SetUint64(param.TotalTokenSupply), param.TokenBaseDecimal),
+		ConvertToLamportsRat(new(big.Rat).SetUint64(param.Leftover), param.TokenBaseDecimal),
 		GetTotalVestingAmount(lockedVesting)
 
 	if !totalSupply.IsUint64() || !totalLeftover.IsUint64() {
@@ -585,31 +494,31 @@ func BuildCurveWithLiquidityWeights(
 	// => l0 * sum_factor = sum(li * (1/p(i-1) - 1/pi)) + sum(li * (pi-p(i-1))) * (1-migrationFee/100) / Pmax ^ 2
 	// => l0 = (Swap_Amount + Base_Amount ) / sum_factor
 
-	sumFactor, pmaxWeight, migrationFeeFactor := big.NewFloat(0), new(big.Float).SetInt(pMax),
-		new(big.Float).Quo(
-			new(big.Float).SetPrec(20).Sub(big.NewFloat(100), big.NewFloat(param.MigrationFee.FeePercentage)),
-			big.NewFloat(100),
-		)
-
-	if l := len(param.LiquidityWeights); l < 16 {
-		return dbc.ConfigParameters{},
-			fmt.Errorf("len of param.LiquidityWeights is expected to be >= 16, len is %d", l)
+	migrationFeePercent, err := RatFromFloat64(param.MigrationFee.FeePercentage)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
 	}
+	pMaxSquared := new(big.Rat).SetInt(new(big.Int).Mul(pMax, pMax))
+	migrationFeeFactor := new(big.Rat).Quo(
+		new(big.Rat).Sub(big.NewRat(100, 1), migrationFeePercent),
+		big.NewRat(100, 1),
+	)
 
+	sumFactor := new(big.Rat)
 	for i := 1; i < 17; i++ {
-		pi, piMinus, k := sqrtPrices[i], sqrtPrices[i-1],
-			big.NewFloat(param.LiquidityWeights[i-1])
-		w1 := new(big.Float).Quo(
-			new(big.Float).Sub(pi, piMinus),
-			new(big.Float).Mul(pi, piMinus),
-		)
-		w2 := new(big.Float).Quo(
-			new(big.Float).Mul(migrationFeeFactor, new(big.Float).Sub(pi, piMinus)),
-			new(big.Float).Mul(pmaxWeight, pmaxWeight),
+		k := param.LiquidityWeights[i-1]
+		if k == nil {
+			return dbc.ConfigParameters{}, fmt.Errorf("liquidity weight at index %d is nil", i-1)
+		}
+
+		delta := new(big.Int).Sub(sqrtPrices[i], sqrtPrices[i-1])
+		w1 := new(big.Rat).SetFrac(delta, new(big.Int).Mul(sqrtPrices[i], sqrtPrices[i-1]))
+		w2 := new(big.Rat).Quo(
+			new(big.Rat).Mul(migrationFeeFactor, new(big.Rat).SetInt(delta)),
+			pMaxSquared,
 		)
 
-		weight := new(big.Float).Mul(k, new(big.Float).Add(w1, w2))
-		sumFactor = new(big.Float).Add(sumFactor, weight)
+		sumFactor.Add(sumFactor, new(big.Rat).Mul(k, new(big.Rat).Add(w1, w2)))
 	}
 
 	totalSwapAndMigrationAmount := new(big.Int).Sub(
@@ -621,19 +530,18 @@ func BuildCurveWithLiquidityWeights(
 		return dbc.ConfigParameters{}, errors.New("sumFactor cannot be zero")
 	}
 
-	l1 := new(big.Float).Quo(
-		new(big.Float).SetInt(totalSwapAndMigrationAmount),
+	l1 := new(big.Rat).Quo(
+		new(big.Rat).SetInt(totalSwapAndMigrationAmount),
 		sumFactor,
 	)
 
 	// construct curve
 	curve := make([]dbc.LiquidityDistributionParameters, 0, 16)
 	for i := range 16 {
-		k := big.NewFloat(param.LiquidityWeights[i])
-		liquidity, _ := new(big.Float).Mul(l1, k).Int(nil)
-		sqrtPrice := new(big.Int).Set(pMax)
+		liquidity := RatFloor(new(big.Rat).Mul(l1, param.LiquidityWeights[i]))
+		sqrtPrice := pMax
 		if i < 15 {
-			sqrtPrice, _ = sqrtPrices[i+1].Int(nil)
+			sqrtPrice = sqrtPrices[i+1]
 		}
 		curve = append(curve, dbc.LiquidityDistributionParameters{
 			SqrtPrice: MustBigIntToUint128(sqrtPrice),
@@ -662,16 +570,16 @@ func BuildCurveWithLiquidityWeights(
 		128,
 	)
 
-	migrationQuoteThreshold := GetMigrationQuoteThresholdFromMigrationQuoteAmount(
-		new(big.Float).SetInt(migrationQuoteAmount),
-		param.MigrationFee.FeePercentage,
+	migrationQuoteThreshold, err := GetMigrationQuoteThresholdFromMigrationQuoteAmountRat(
+		new(big.Rat).SetInt(migrationQuoteAmount),
+		migrationFeePercent,
 	)
-
-	migrationQuoteThresholdInLamport, _ := migrationQuoteThreshold.Int(nil)
-	if !migrationQuoteThresholdInLamport.IsUint64() {
-		return dbc.ConfigParameters{}, fmt.Errorf("cannot fit migrationQuoteThresholdInLamport(%s) into uint64", migrationQuoteThresholdInLamport)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
 	}
 
+	migrationQuoteThresholdInLamport := RatFloor(migrationQuoteThreshold)
+
 	// sanity check
 	{
 		totalDynamicSupply, err := GetTotalSupplyFromCurve(
@@ -697,6 +605,282 @@ func BuildCurveWithLiquidityWeights(
 		}
 	}
 
+	return buildConfigParameters(
+		param.BuildCurveBaseParam,
+		migrationQuoteThresholdInLamport,
+		pMin,
+		totalSupply,
+		lockedVesting,
+		curve,
+	)
+}
+
+// BuildCurveFromCheckpoints builds a custom constant product curve through market cap or price checkpoints.
+func BuildCurveFromCheckpoints(
+	param types.BuildCurveFromCheckpointsParam,
+) (dbc.ConfigParameters, []types.CheckpointReport, error) {
+	checkpoints := make([]types.CurveCheckpointRat, 0, len(param.Checkpoints))
+	for _, checkpoint := range param.Checkpoints {
+		supplySoldPercentage, err := RatFromFloat64(checkpoint.SupplySoldPercentage)
+		if err != nil {
+			return dbc.ConfigParameters{}, nil, err
+		}
+		checkpointRat := types.CurveCheckpointRat{SupplySoldPercentage: supplySoldPercentage}
+		if checkpoint.MarketCap != 0 {
+			if checkpointRat.MarketCap, err = RatFromFloat64(checkpoint.MarketCap); err != nil {
+				return dbc.ConfigParameters{}, nil, err
+			}
+		}
+		if checkpoint.Price != 0 {
+			if checkpointRat.Price, err = RatFromFloat64(checkpoint.Price); err != nil {
+				return dbc.ConfigParameters{}, nil, err
+			}
+		}
+		checkpoints = append(checkpoints, checkpointRat)
+	}
+
+	initialMarketCap, err := RatFromFloat64(param.InitialMarketCap)
+	if err != nil {
+		return dbc.ConfigParameters{}, nil, err
+	}
+
+	return BuildCurveFromCheckpointsRat(types.BuildCurveFromCheckpointsRatParam{
+		BuildCurveBaseParam: param.BuildCurveBaseParam,
+		InitialMarketCap:    initialMarketCap,
+		Checkpoints:         checkpoints,
+	})
+}
+
+// BuildCurveFromCheckpointsRat builds a custom constant product curve through exact checkpoints.
+// Each checkpoint closes a segment, its liquidity sells the supply between two checkpoints across their prices.
+//
+//	Δbase_i = totalSupply * (supplySold_i - supplySold_i-1) / 100
+//	L_i = Δbase_i * √P_i * √P_i-1 / (√P_i - √P_i-1)
+//
+// The last checkpoint is the migration point, supply left after the swap, migration, vesting and leftover
+// amounts is sold on a final segment up to MaxSqrtPrice when there is room for one more curve point.
+func BuildCurveFromCheckpointsRat(
+	param types.BuildCurveFromCheckpointsRatParam,
+) (dbc.ConfigParameters, []types.CheckpointReport, error) {
+	if param.InitialMarketCap == nil || param.InitialMarketCap.Sign() <= 0 {
+		return dbc.ConfigParameters{}, nil, errors.New("initialMarketCap must be greater than zero")
+	}
+	if l := len(param.Checkpoints); l == 0 || l > constants.MaxCurvePoint {
+		return dbc.ConfigParameters{}, nil,
+			fmt.Errorf("expected between 1 and %d checkpoints, got %d", constants.MaxCurvePoint, l)
+	}
+
+	totalSupply := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.TotalTokenSupply), param.TokenBaseDecimal,
+	)
+	totalLeftover := ConvertToLamportsRat(
+		new(big.Rat).SetUint64(param.Leftover), param.TokenBaseDecimal,
+	)
+
+	lockedVesting, err := getLockedVestingFromBaseParam(param.BuildCurveBaseParam)
+	if err != nil {
+		return dbc.ConfigParameters{}, nil, err
+	}
+
+	sqrtStartPrice := GetSqrtPriceFromMarketCapRat(
+		param.InitialMarketCap,
+		param.TotalTokenSupply,
+		param.TokenBaseDecimal,
+		param.TokenQuoteDecimal,
+	)
+	if sqrtStartPrice.Cmp(constants.MinSqrtPrice) < 0 {
+		return dbc.ConfigParameters{}, nil,
+			fmt.Errorf("initial sqrt price(%s) is less than MinSqrtPrice(%s)", sqrtStartPrice, constants.MinSqrtPrice)
+	}
+
+	var (
+		curve            = make([]dbc.LiquidityDistributionParameters, 0, constants.MaxCurvePoint)
+		targetMarketCaps = make([]*big.Rat, 0, len(param.Checkpoints))
+		prevSqrtPrice    = sqrtStartPrice
+		prevSupplySold   = new(big.Rat)
+		prevBaseSold     = big.NewInt(0)
+		hundred          = big.NewRat(100, 1)
+	)
+
+	for i, checkpoint := range param.Checkpoints {
+		marketCap := checkpoint.MarketCap
+		switch {
+		case (checkpoint.MarketCap == nil) == (checkpoint.Price == nil):
+			return dbc.ConfigParameters{}, nil, fmt.Errorf("checkpoint %d must set exactly one of marketCap or price", i)
+		case checkpoint.Price != nil:
+			marketCap = new(big.Rat).Mul(checkpoint.Price, new(big.Rat).SetUint64(param.TotalTokenSupply))
+		}
+		targetMarketCaps = append(targetMarketCaps, marketCap)
+
+		supplySold := checkpoint.SupplySoldPercentage
+		if supplySold == nil || supplySold.Cmp(prevSupplySold) <= 0 || supplySold.Cmp(hundred) > 0 {
+			return dbc.ConfigParameters{}, nil, fmt.Errorf(
+				"checkpoint %d supply sold percentage must be greater than %s and at most 100",
+				i, prevSupplySold.FloatString(4),
+			)
+		}
+
+		sqrtPrice := GetSqrtPriceFromMarketCapRat(
+			marketCap,
+			param.TotalTokenSupply,
+			param.TokenBaseDecimal,
+			param.TokenQuoteDecimal,
+		)
+		if sqrtPrice.Cmp(prevSqrtPrice) <= 0 || sqrtPrice.Cmp(constants.MaxSqrtPrice) > 0 {
+			return dbc.ConfigParameters{}, nil, fmt.Errorf(
+				"checkpoint %d sqrt price(%s) must be greater than %s and at most MaxSqrtPrice", i, sqrtPrice, prevSqrtPrice,
+			)
+		}
+
+		baseSold := RatFloor(new(big.Rat).Quo(
+			new(big.Rat).Mul(new(big.Rat).SetInt(totalSupply), supplySold),
+			hundred,
+		))
+
+		liquidity, err := GetInitialLiquidityFromDeltaBase(
+			new(big.Int).Sub(baseSold, prevBaseSold),
+			sqrtPrice,
+			prevSqrtPrice,
+		)
+		if err != nil {
+			return dbc.ConfigParameters{}, nil, err
+		}
+		if liquidity.Sign() == 0 {
+			return dbc.ConfigParameters{}, nil, fmt.Errorf("checkpoint %d sells no supply", i)
+		}
+
+		curve = append(curve, dbc.LiquidityDistributionParameters{
+			SqrtPrice: MustBigIntToUint128(sqrtPrice),
+			Liquidity: MustBigIntToUint128(liquidity),
+		})
+		prevSqrtPrice, prevSupplySold, prevBaseSold = sqrtPrice, supplySold, baseSold
+	}
+
+	// migration quote threshold is the quote raised along the whole curve
+	migrationQuoteThresholdInLamport := big.NewInt(0)
+	lowerSqrtPrice := sqrtStartPrice
+	for _, point := range curve {
+		quoteAmount, err := maths.GetDeltaAmountQuoteUnsigned(
+			lowerSqrtPrice,
+			point.SqrtPrice.BigInt(),
+			point.Liquidity.BigInt(),
+			types.RoundingUp,
+		)
+		if err != nil {
+			return dbc.ConfigParameters{}, nil, err
+		}
+		migrationQuoteThresholdInLamport.Add(migrationQuoteThresholdInLamport, quoteAmount)
+		lowerSqrtPrice = point.SqrtPrice.BigInt()
+	}
+
+	totalDynamicSupply, err := GetTotalSupplyFromCurve(
+		migrationQuoteThresholdInLamport,
+		sqrtStartPrice,
+		curve,
+		lockedVesting,
+		param.MigrationOption,
+		totalLeftover,
+		param.MigrationFee.FeePercentage,
+	)
+	if err != nil {
+		return dbc.ConfigParameters{}, nil, err
+	}
+	if totalDynamicSupply.Cmp(totalSupply) > 0 {
+		return dbc.ConfigParameters{}, nil, fmt.Errorf(
+			"checkpoints require a total supply of %s but the total supply is %s", totalDynamicSupply, totalSupply,
+		)
+	}
+
+	if len(curve) < constants.MaxCurvePoint && prevSqrtPrice.Cmp(constants.MaxSqrtPrice) < 0 {
+		lastLiquidity, err := GetInitialLiquidityFromDeltaBase(
+			new(big.Int).Sub(totalSupply, totalDynamicSupply),
+			constants.MaxSqrtPrice,
+			prevSqrtPrice,
+		)
+		if err != nil {
+			return dbc.ConfigParameters{}, nil, err
+		}
+
+		if lastLiquidity.Sign() != 0 {
+			curve = append(curve, dbc.LiquidityDistributionParameters{
+				SqrtPrice: MustBigIntToUint128(constants.MaxSqrtPrice),
+				Liquidity: MustBigIntToUint128(lastLiquidity),
+			})
+		}
+	}
+
+	report := make([]types.CheckpointReport, 0, len(param.Checkpoints))
+	for i, checkpoint := range param.Checkpoints {
+		sqrtPrice := curve[i].SqrtPrice.BigInt()
+		baseSold, err := GetBaseTokenForSwap(sqrtStartPrice, sqrtPrice, curve)
+		if err != nil {
+			return dbc.ConfigParameters{}, nil, err
+		}
+
+		targetMarketCap, _ := targetMarketCaps[i].Float64()
+		actualMarketCap, _ := GetMarketCapFromSqrtPrice(
+			sqrtPrice, totalSupply, param.TokenBaseDecimal, param.TokenQuoteDecimal,
+		).Float64()
+		targetSupplySold, _ := checkpoint.SupplySoldPercentage.Float64()
+		actualSupplySold, _ := new(big.Rat).Quo(
+			new(big.Rat).Mul(new(big.Rat).SetInt(baseSold), hundred),
+			new(big.Rat).SetInt(totalSupply),
+		).Float64()
+
+		report = append(report, types.CheckpointReport{
+			TargetMarketCap:              targetMarketCap,
+			ActualMarketCap:              actualMarketCap,
+			TargetSupplySoldPercentage:   targetSupplySold,
+			ActualSupplySoldPercentage:   actualSupplySold,
+			MarketCapDeviationPercentage: (actualMarketCap - targetMarketCap) / targetMarketCap * 100,
+			SupplySoldDeviation:          actualSupplySold - targetSupplySold,
+		})
+	}
+
+	config, err := buildConfigParameters(
+		param.BuildCurveBaseParam,
+		migrationQuoteThresholdInLamport,
+		sqrtStartPrice,
+		totalSupply,
+		lockedVesting,
+		curve,
+	)
+	if err != nil {
+		return dbc.ConfigParameters{}, nil, err
+	}
+
+	return config, report, nil
+}
+
+// getLockedVestingFromBaseParam gets the locked vesting parameters of a build curve param.
+func getLockedVestingFromBaseParam(param types.BuildCurveBaseParam) (dbc.LockedVestingParams, error) {
+	return GetLockedVestingParams(
+		param.LockedVestingParam.TotalLockedVestingAmount,
+		param.LockedVestingParam.NumberOfVestingPeriod,
+		param.LockedVestingParam.CliffUnlockAmount,
+		param.LockedVestingParam.TotalVestingDuration,
+		param.LockedVestingParam.CliffDurationFromMigrationTime,
+		param.TokenBaseDecimal,
+	)
+}
+
+// buildConfigParameters assembles the config parameters shared by every curve builder.
+func buildConfigParameters(
+	param types.BuildCurveBaseParam,
+	migrationQuoteThresholdInLamport, sqrtStartPrice, totalSupply *big.Int,
+	lockedVesting dbc.LockedVestingParams,
+	curve []dbc.LiquidityDistributionParameters,
+) (dbc.ConfigParameters, error) {
+	if !totalSupply.IsUint64() {
+		return dbc.ConfigParameters{},
+			fmt.Errorf("cannot fit totalSupply(%s) into uint64", totalSupply)
+	}
+
+	if !migrationQuoteThresholdInLamport.IsUint64() {
+		return dbc.ConfigParameters{},
+			fmt.Errorf("cannot fit migrationQuoteThresholdInLamport(%s) into uint64", migrationQuoteThresholdInLamport)
+	}
+
 	baseFee, err := GetBaseFeeParams(
 		param.BaseFeeParams,
 		param.TokenQuoteDecimal,
@@ -734,7 +918,7 @@ func BuildCurveWithLiquidityWeights(
 		},
 		ActivationType:            uint8(param.ActivationType),
 		CollectFeeMode:            uint8(param.CollectFeeMode),
-		MigrationOption:           uint8(param.MigrationFeeOption),
+		MigrationOption:           uint8(param.MigrationOption),
 		TokenType:                 uint8(param.TokenType),
 		TokenDecimal:              uint8(param.TokenBaseDecimal),
 		MigrationQuoteThreshold:   migrationQuoteThresholdInLamport.Uint64(),
@@ -742,7 +926,7 @@ func BuildCurveWithLiquidityWeights(
 		CreatorLpPercentage:       param.CreatorLpPercentage,
 		PartnerLockedLpPercentage: param.PartnerLockedLpPercentage,
 		CreatorLockedLpPercentage: param.CreatorLockedLpPercentage,
-		SqrtStartPrice:            MustBigIntToUint128(pMin),
+		SqrtStartPrice:            MustBigIntToUint128(sqrtStartPrice),
 		LockedVesting:             lockedVesting,
 		MigrationFeeOption:        uint8(param.MigrationFeeOption),
 		TokenSupply: &dbc.TokenSupplyParams{
@@ -762,18 +946,15 @@ func BuildCurveWithLiquidityWeights(
 		},
 		Curve: curve,
 	}, nil
-
 }
 
 // GetSqrtPriceFromMarketCap gets the sqrt price from the market cap.
 func GetSqrtPriceFromMarketCap(
 	marketCap, totalSupply uint64, tokenBaseDecimal, tokenQuoteDecimal types.TokenDecimal,
 ) *big.Int {
-	return GetSqrtPriceFromPrice(
-		new(big.Float).SetPrec(256).Quo(
-			new(big.Float).SetUint64(marketCap),
-			new(big.Float).SetUint64(totalSupply),
-		),
+	return GetSqrtPriceFromMarketCapRat(
+		new(big.Rat).SetUint64(marketCap),
+		totalSupply,
 		tokenBaseDecimal,
 		tokenQuoteDecimal,
 	)
@@ -0,0 +1,90 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCurveFromCheckpoints(t *testing.T) {
+	base := types.BuildCurveBaseParam{
+		TotalTokenSupply:  1_000_000_000,
+		MigrationOption:   types.MigrationOptionMET_DAMM_V2,
+		TokenBaseDecimal:  types.TokenDecimalSIX,
+		TokenQuoteDecimal: types.TokenDecimalNINE,
+		BaseFeeParams: types.BaseFeeParams{
+			BaseFeeMode: types.BaseFeeModeFeeSchedulerLinear,
+			FeeSchedulerParam: &types.FeeSchedulerParams{
+				StartingFeeBps: 100,
+				EndingFeeBps:   100,
+			},
+		},
+		ActivationType:            types.ActivationTypeSlot,
+		CollectFeeMode:            types.CollectFeeModeQuoteToken,
+		MigrationFeeOption:        types.MigrationFeeOptionFixedBps100,
+		TokenType:                 types.TokenTypeSPL,
+		PartnerLockedLpPercentage: 100,
+		Leftover:                  10_000,
+	}
+
+	t.Run("market cap and price checkpoints", func(t *testing.T) {
+		config, report, err := helpers.BuildCurveFromCheckpoints(types.BuildCurveFromCheckpointsParam{
+			BuildCurveBaseParam: base,
+			InitialMarketCap:    30,
+			Checkpoints: []types.CurveCheckpoint{
+				{MarketCap: 100, SupplySoldPercentage: 20},
+				{Price: 0.0000003, SupplySoldPercentage: 40},
+				{MarketCap: 1_000, SupplySoldPercentage: 60},
+			},
+		})
+		if err != nil {
+			t.Fatalf("BuildCurveFromCheckpoints errored: %s", err.Error())
+		}
+
+		assert.Equal(t, 4, len(config.Curve)) // 3 checkpoints + tail to MaxSqrtPrice
+		assert.NoError(t, helpers.ValidateConfigParameters(config))
+
+		assert.Len(t, report, 3)
+		for _, r := range report {
+			assert.InDelta(t, 0, r.MarketCapDeviationPercentage, 0.0001)
+			assert.InDelta(t, 0, r.SupplySoldDeviation, 0.0001)
+		}
+		assert.InDelta(t, 300, report[1].ActualMarketCap, 0.001)
+		assert.InDelta(t, 60, report[2].ActualSupplySoldPercentage, 0.0001)
+	})
+
+	t.Run("invalid checkpoints", func(t *testing.T) {
+		_, _, err := helpers.BuildCurveFromCheckpoints(types.BuildCurveFromCheckpointsParam{
+			BuildCurveBaseParam: base,
+			InitialMarketCap:    30,
+			Checkpoints: []types.CurveCheckpoint{
+				{MarketCap: 100, SupplySoldPercentage: 20},
+				{MarketCap: 90, SupplySoldPercentage: 40},
+			},
+		})
+		assert.Error(t, err)
+
+		_, _, err = helpers.BuildCurveFromCheckpoints(types.BuildCurveFromCheckpointsParam{
+			BuildCurveBaseParam: base,
+			InitialMarketCap:    30,
+			Checkpoints: []types.CurveCheckpoint{
+				{MarketCap: 100, SupplySoldPercentage: 20},
+				{MarketCap: 200, SupplySoldPercentage: 10},
+			},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("checkpoints exceeding supply", func(t *testing.T) {
+		_, _, err := helpers.BuildCurveFromCheckpoints(types.BuildCurveFromCheckpointsParam{
+			BuildCurveBaseParam: base,
+			InitialMarketCap:    30,
+			Checkpoints: []types.CurveCheckpoint{
+				{MarketCap: 1_000, SupplySoldPercentage: 95},
+			},
+		})
+		assert.ErrorContains(t, err, "require a total supply")
+	})
+}
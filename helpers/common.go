@@ -197,7 +197,10 @@ func GetRateLimiterParams(
 	if maxLimiterDuration > uint64(maxDuration) {
 		return types.BaseFee{}, fmt.Errorf("max duration exceeds maximum allowed value of %d", maxDuration)
 	}
-	referenceAmountInLamports := ConvertToLamports(referenceAmount, tokenQuoteDecimal)
+	referenceAmountInLamports, err := ConvertToLamportsChecked(referenceAmount, tokenQuoteDecimal)
+	if err != nil {
+		return types.BaseFee{}, err
+	}
 
 	if !cliffFeeNumerator.IsUint64() || !referenceAmountInLamports.IsInt64() {
 		return types.BaseFee{},
@@ -227,13 +230,16 @@ func GetDynamicFeeParams(
 	if maxPriceChangeBps == 0 {
 		maxPriceChangeBps = constants.MaxPriceChangeBpsDefault
 	}
-	priceRatio := float64(maxPriceChangeBps)/constants.BasisPointMax + 1
-
-	hold := new(big.Float).SetFloat64(priceRatio)
-	hold.Sqrt(hold).Mul(hold, big.NewFloat(math.Pow(2, 64)))
-
-	sqrtPriceRatioQ64 := new(big.Int)
-	hold.Int(sqrtPriceRatioQ64)
+	// sqrtPriceRatioQ64 = floor(sqrt((maxPriceChangeBps + BasisPointMax) / BasisPointMax) * 2^64)
+	sqrtPriceRatioQ64 := new(big.Int).Sqrt(
+		new(big.Int).Quo(
+			new(big.Int).Lsh(
+				new(big.Int).SetUint64(maxPriceChangeBps+constants.BasisPointMax),
+				2*constants.RESOLUTION,
+			),
+			big.NewInt(constants.BasisPointMax),
+		),
+	)
 
 	deltaBinId := new(big.Int).Mul(
 		new(big.Int).Quo(
@@ -335,17 +341,24 @@ func GetFeeSchedulerParams(
 		}, nil
 	}
 
-	minBaseFeeNumeratorFloat64, _ := minBaseFeeNumerator.Float64()
-	maxBaseFeeNumeratorFloat64, _ := maxBaseFeeNumerator.Float64()
-	ratio := minBaseFeeNumeratorFloat64 / maxBaseFeeNumeratorFloat64
-	decayBase := math.Pow(ratio, 1/float64(numberOfPeriod))
-
-	reductionFactor := new(big.Float).Mul(
-		big.NewFloat(constants.BasisPointMax),
-		new(big.Float).Sub(big.NewFloat(1), big.NewFloat(decayBase)),
+	// decayBase = (minBaseFeeNumerator / maxBaseFeeNumerator)^(1/numberOfPeriod)
+	decayBase, err := RatNthRoot(
+		new(big.Rat).SetFrac(minBaseFeeNumerator, maxBaseFeeNumerator),
+		uint(numberOfPeriod),
+		128,
 	)
+	if err != nil {
+		return types.BaseFee{}, err
+	}
 
-	reductionFactorU64, _ := reductionFactor.Uint64()
+	reductionFactor := RatFloor(new(big.Rat).Mul(
+		big.NewRat(constants.BasisPointMax, 1),
+		new(big.Rat).Sub(big.NewRat(1, 1), decayBase),
+	))
+	if !reductionFactor.IsUint64() {
+		return types.BaseFee{}, fmt.Errorf("cannot fit reductionFactor(%s) into uint64", reductionFactor)
+	}
+	reductionFactorU64 := reductionFactor.Uint64()
 
 	return types.BaseFee{
 		CliffFeeNumerator: maxBaseFeeNumerator.Uint64(),
@@ -357,25 +370,32 @@ func GetFeeSchedulerParams(
 }
 
 // GetSqrtPriceFromPrice gets the sqrt price from the price.
+// It panics when price is nil, infinite or negative, use GetSqrtPriceFromPriceChecked to get an error instead.
 //
 //	sqrtPriceQ64 = sqrt(price / 10^(tokenADecimal - tokenBDecimal)) * 2^64
 func GetSqrtPriceFromPrice(
 	price *big.Float,
 	tokenADecimal, tokenBDecimal types.TokenDecimal,
 ) *big.Int {
-	adjustedPrice := new(big.Float).Quo(
-		price,
-		new(big.Float).SetFloat64(math.Pow10(int(tokenADecimal)-int(tokenBDecimal))),
-	)
+	sqrtPrice, err := GetSqrtPriceFromPriceChecked(price, tokenADecimal, tokenBDecimal)
+	if err != nil {
+		panic(err)
+	}
+	return sqrtPrice
+}
 
-	sqrtQ64 := new(big.Float).Mul(
-		adjustedPrice.Sqrt(adjustedPrice),
-		new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)),
-	)
+// GetSqrtPriceFromPriceChecked gets the sqrt price from the price, returning an error when price is nil,
+// infinite or negative.
+func GetSqrtPriceFromPriceChecked(
+	price *big.Float,
+	tokenADecimal, tokenBDecimal types.TokenDecimal,
+) (*big.Int, error) {
+	if price == nil || price.IsInf() || price.Sign() < 0 {
+		return nil, fmt.Errorf("invalid price: %v", price)
+	}
 
-	result := new(big.Int)
-	sqrtQ64.Int(result)
-	return result
+	priceRat, _ := price.Rat(nil)
+	return GetSqrtPriceFromPriceRat(priceRat, tokenADecimal, tokenBDecimal), nil
 }
 
 // GetMigratedPoolFeeParams gets migrated pool fee parameters based on migration options.
@@ -413,8 +433,8 @@ func GetLockedVestingParams(
 		return dbc.LockedVestingParams{}, nil
 	}
 
-	holdAmountPerPeriod, holdCliffUnlockAmount := ConvertToLamports(1, tokenBaseDecimal),
-		ConvertToLamports(float64(totalLockedVestingAmount-1), tokenBaseDecimal)
+	holdAmountPerPeriod, holdCliffUnlockAmount := ConvertToLamportsRat(big.NewRat(1, 1), tokenBaseDecimal),
+		ConvertToLamportsRat(new(big.Rat).SetUint64(totalLockedVestingAmount-1), tokenBaseDecimal)
 
 	if !holdAmountPerPeriod.IsInt64() || !holdCliffUnlockAmount.IsUint64() {
 		return dbc.LockedVestingParams{},
@@ -454,8 +474,8 @@ func GetLockedVestingParams(
 	// add the remainder to cliffUnlockAmount to maintain total amount
 	adjustedCliffUnlockAmount := cliffUnlockAmount + remainder
 
-	holdAmountPerPeriod, holdCliffUnlockAmount = ConvertToLamports(float64(amountPerPeriod), tokenBaseDecimal),
-		ConvertToLamports(float64(adjustedCliffUnlockAmount), tokenBaseDecimal)
+	holdAmountPerPeriod, holdCliffUnlockAmount = ConvertToLamportsRat(new(big.Rat).SetUint64(amountPerPeriod), tokenBaseDecimal),
+		ConvertToLamportsRat(new(big.Rat).SetUint64(adjustedCliffUnlockAmount), tokenBaseDecimal)
 
 	if !holdAmountPerPeriod.IsInt64() || !holdCliffUnlockAmount.IsUint64() {
 		return dbc.LockedVestingParams{},
@@ -646,12 +666,14 @@ func GetTotalSupplyFromCurve(
 		return nil, err
 	}
 
-	migrationQuoteAmount := GetMigrationQuoteAmountFromMigrationQuoteThreshold(
-		new(big.Float).SetInt(migrationQuoteThreshold),
-		migrationFeePercent,
-	)
-
-	migrationQuoteAmountInt, _ := migrationQuoteAmount.Int(nil)
+	migrationFeePercentRat, err := RatFromFloat64(migrationFeePercent)
+	if err != nil {
+		return nil, err
+	}
+	migrationQuoteAmountInt := RatFloor(GetMigrationQuoteAmountFromMigrationQuoteThresholdRat(
+		new(big.Rat).SetInt(migrationQuoteThreshold),
+		migrationFeePercentRat,
+	))
 
 	migrationBaseAmount, err := GetMigrationBaseToken(
 		migrationQuoteAmountInt,
@@ -729,7 +751,7 @@ func GetMigrationThresholdPrice(
 
 	amountLeft := new(big.Int).Sub(migrationThreshold, totalAmount)
 	nextSqrtPrice = curve[0].SqrtPrice.BigInt()
-	for i := 1; i < len(curve); i++ {
+	for i := 1; i < len(curve) && amountLeft.Sign() != 0; i++ {
 		maxAmount, err := maths.GetDeltaAmountQuoteUnsigned(
 			nextSqrtPrice,
 			curve[i].SqrtPrice.BigInt(),
@@ -781,22 +803,27 @@ func GetFirstCurve(
 	migrationSqrtPrice, migrationBaseAmount, swapAmount, migrationQuoteThreshold *big.Int,
 	migrationFeePercent float64,
 ) (types.GetFirstCurveResult, error) {
+	migrationFeePercentRat, err := RatFromFloat64(migrationFeePercent)
+	if err != nil {
+		return types.GetFirstCurveResult{}, err
+	}
 
-	denominator := new(big.Float).Quo(
-		new(big.Float).Mul(
-			new(big.Float).SetInt(swapAmount),
-			new(big.Float).Sub(constants.HundredInBigFloat, big.NewFloat(migrationFeePercent)),
+	denominator := new(big.Rat).Quo(
+		new(big.Rat).Mul(
+			new(big.Rat).SetInt(swapAmount),
+			new(big.Rat).Sub(big.NewRat(100, 1), migrationFeePercentRat),
 		),
-		constants.HundredInBigFloat,
+		big.NewRat(100, 1),
 	)
+	if denominator.Sign() <= 0 {
+		return types.GetFirstCurveResult{},
+			fmt.Errorf("swapAmount(%s) and migration fee percentage leave no room for the first curve", swapAmount)
+	}
 
-	sqrtStartPriceFloat := new(big.Float).Quo(
-		new(big.Float).Mul(new(big.Float).SetInt(migrationSqrtPrice), new(big.Float).SetInt(migrationBaseAmount)),
+	sqrtStartPrice := RatFloor(new(big.Rat).Quo(
+		new(big.Rat).SetInt(new(big.Int).Mul(migrationSqrtPrice, migrationBaseAmount)),
 		denominator,
-	)
-
-	sqrtStartPrice := new(big.Int)
-	sqrtStartPriceFloat.Int(sqrtStartPrice)
+	))
 
 	liquidity, err := Liquidity(
 		swapAmount,
@@ -826,50 +853,32 @@ func GetTwoCurve(
 	IsoK     bool
 	TwoCurve types.GetFirstCurveResult
 } {
-	p0, p1, p2 := new(big.Float).SetInt(initialSqrtPrice),
-		new(big.Float).SetInt(midSqrtPrice), new(big.Float).SetInt(migrationSqrtPrice)
+	p0, p1, p2 := new(big.Rat).SetInt(initialSqrtPrice),
+		new(big.Rat).SetInt(midSqrtPrice), new(big.Rat).SetInt(migrationSqrtPrice)
 
-	a1 := new(big.Float).Sub(
-		new(big.Float).Quo(big.NewFloat(1), p0),
-		new(big.Float).Quo(big.NewFloat(1), p1),
-	)
+	a1 := new(big.Rat).Sub(new(big.Rat).Inv(p0), new(big.Rat).Inv(p1))
+	b1 := new(big.Rat).Sub(new(big.Rat).Inv(p1), new(big.Rat).Inv(p2))
+	c1 := new(big.Rat).SetInt(swapAmount)
 
-	b1 := new(big.Float).Sub(
-		new(big.Float).Quo(big.NewFloat(1), p1),
-		new(big.Float).Quo(big.NewFloat(1), p2),
-	)
-
-	c1 := new(big.Float).SetInt(swapAmount)
-
-	a2 := new(big.Float).Sub(p1, p0)
-	b2 := new(big.Float).Sub(p2, p1)
-	c2 := new(big.Float).SetInt(migrationQuoteThreshold)
-	c2.Mul(c2, new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 128)))
-	// c2.Mul(c2, big.NewFloat(math.Pow(2, 128)))
+	a2 := new(big.Rat).Sub(p1, p0)
+	b2 := new(big.Rat).Sub(p2, p1)
+	c2 := new(big.Rat).SetInt(new(big.Int).Lsh(migrationQuoteThreshold, 128))
 
 	// solve equation to find l0 and l1
-	l0 := new(big.Float).Quo(
-		new(big.Float).Sub(
-			new(big.Float).Mul(c1, b2),
-			new(big.Float).Mul(c2, b1),
-		),
-		new(big.Float).Sub(
-			new(big.Float).Mul(a1, b2),
-			new(big.Float).Mul(a2, b1),
-		),
-	)
-	l1 := new(big.Float).Quo(
-		new(big.Float).Sub(
-			new(big.Float).Mul(c1, a2),
-			new(big.Float).Mul(c2, a1),
-		),
-		new(big.Float).Sub(
-			new(big.Float).Mul(b1, a2),
-			new(big.Float).Mul(b2, a1),
-		),
-	)
+	determinant := new(big.Rat).Sub(new(big.Rat).Mul(a1, b2), new(big.Rat).Mul(a2, b1))
+	l0, l1 := new(big.Rat), new(big.Rat)
+	if determinant.Sign() != 0 {
+		l0.Quo(
+			new(big.Rat).Sub(new(big.Rat).Mul(c1, b2), new(big.Rat).Mul(c2, b1)),
+			determinant,
+		)
+		l1.Quo(
+			new(big.Rat).Sub(new(big.Rat).Mul(c1, a2), new(big.Rat).Mul(c2, a1)),
+			new(big.Rat).Neg(determinant),
+		)
+	}
 
-	if l0.Sign() < 0 || l1.Sign() < 0 {
+	if determinant.Sign() == 0 || l0.Sign() < 0 || l1.Sign() < 0 {
 		return struct {
 			IsoK     bool
 			TwoCurve types.GetFirstCurveResult
@@ -881,9 +890,7 @@ func GetTwoCurve(
 		}
 	}
 
-	l0BigInt, l1BigInt := new(big.Int), new(big.Int)
-	l0.Int(l0BigInt)
-	l1.Int(l1BigInt)
+	l0BigInt, l1BigInt := RatFloor(l0), RatFloor(l1)
 
 	return struct {
 		IsoK     bool
@@ -1064,5 +1071,5 @@ func PrepareSwapAmountParam(
 		return nil, err
 	}
 
-	return ConvertToLamports(amount, types.TokenDecimal(mintTokenDecimals)), nil
+	return ConvertToLamportsChecked(amount, types.TokenDecimal(mintTokenDecimals))
 }
@@ -344,3 +344,20 @@ func TestRateLimiter(t *testing.T) {
 		assert.Equal(t, float64(referenceAmount)*math.Pow10(tokenQuoteDecimal), float64(params.ThirdFactor))
 	})
 }
+
+func TestGetSqrtPriceFromPrice(t *testing.T) {
+	oneQ64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	assert.Equal(t, oneQ64, helpers.GetSqrtPriceFromPrice(big.NewFloat(1), types.TokenDecimalNINE, types.TokenDecimalNINE))
+
+	sqrtPrice, err := helpers.GetSqrtPriceFromPriceChecked(big.NewFloat(1), types.TokenDecimalNINE, types.TokenDecimalNINE)
+	assert.NoError(t, err)
+	assert.Equal(t, oneQ64, sqrtPrice)
+
+	for _, price := range []*big.Float{big.NewFloat(math.Inf(1)), big.NewFloat(-1), nil} {
+		_, err = helpers.GetSqrtPriceFromPriceChecked(price, types.TokenDecimalNINE, types.TokenDecimalNINE)
+		assert.Error(t, err, "price=%v", price)
+		assert.Panics(t, func() {
+			helpers.GetSqrtPriceFromPrice(price, types.TokenDecimalNINE, types.TokenDecimalNINE)
+		}, "price=%v", price)
+	}
+}
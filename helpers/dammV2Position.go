@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/maths"
+	"dbcGoSDK/types"
+	"errors"
+	"math/big"
+)
+
+// dammV2LiquidityScale is the fixed point offset of the DAMM V2 fee per liquidity accumulators.
+const dammV2LiquidityScale = 128
+
+// GetDammV2PositionLiquidity gets the total liquidity of a DAMM V2 position, locked and permanently locked included.
+func GetDammV2PositionLiquidity(position *dammv2.PositionAccount) *big.Int {
+	liquidity := new(big.Int).Add(
+		position.UnlockedLiquidity.BigInt(),
+		position.VestedLiquidity.BigInt(),
+	)
+	return liquidity.Add(liquidity, position.PermanentLockedLiquidity.BigInt())
+}
+
+// GetDammV2UnclaimedFee gets the token A and token B fees a DAMM V2 position can claim.
+//
+//	fee = pending + liquidity * (feePerLiquidity - checkpoint) >> 128
+func GetDammV2UnclaimedFee(
+	pool *dammv2.PoolAccount,
+	position *dammv2.PositionAccount,
+) (feeA, feeB *big.Int) {
+	liquidity := GetDammV2PositionLiquidity(position)
+
+	unclaimed := func(feePerLiquidity, checkpoint [32]uint8, pending uint64) *big.Int {
+		delta := new(big.Int).Sub(leBytesToBigInt(feePerLiquidity[:]), leBytesToBigInt(checkpoint[:]))
+		fee := new(big.Int).Rsh(delta.Mul(delta, liquidity), dammV2LiquidityScale)
+		return fee.Add(fee, new(big.Int).SetUint64(pending))
+	}
+
+	return unclaimed(pool.FeeAPerLiquidity, position.FeeAPerTokenCheckpoint, position.FeeAPending),
+		unclaimed(pool.FeeBPerLiquidity, position.FeeBPerTokenCheckpoint, position.FeeBPending)
+}
+
+// GetDammV2LiquidityDelta gets the largest liquidity that can be added to a DAMM V2 pool
+// with at most maxAmountA of token A and maxAmountB of token B.
+func GetDammV2LiquidityDelta(
+	pool *dammv2.PoolAccount,
+	maxAmountA, maxAmountB uint64,
+) (*big.Int, error) {
+	sqrtPrice := pool.SqrtPrice.BigInt()
+
+	liquidityFromA, err := GetInitialLiquidityFromDeltaBase(
+		new(big.Int).SetUint64(maxAmountA),
+		pool.SqrtMaxPrice.BigInt(),
+		sqrtPrice,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidityFromB, err := GetInitialLiquidityFromDeltaQuote(
+		new(big.Int).SetUint64(maxAmountB),
+		pool.SqrtMinPrice.BigInt(),
+		sqrtPrice,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if liquidityFromA.Cmp(liquidityFromB) < 0 {
+		return liquidityFromA, nil
+	}
+	return liquidityFromB, nil
+}
+
+// GetDammV2AmountsFromLiquidity gets the token amounts backing a liquidity delta at the pool price.
+// Round up for deposits and down for withdrawals.
+func GetDammV2AmountsFromLiquidity(
+	pool *dammv2.PoolAccount,
+	liquidity *big.Int,
+	round types.Rounding,
+) (amountA, amountB *big.Int, err error) {
+	if liquidity == nil || liquidity.Sign() < 0 {
+		return nil, nil, errors.New("liquidity must be a non negative value")
+	}
+
+	sqrtPrice := pool.SqrtPrice.BigInt()
+
+	if amountA, err = maths.GetDeltaAmountBaseUnsigned(
+		sqrtPrice,
+		pool.SqrtMaxPrice.BigInt(),
+		liquidity,
+		round,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	if amountB, err = maths.GetDeltaAmountQuoteUnsigned(
+		pool.SqrtMinPrice.BigInt(),
+		sqrtPrice,
+		liquidity,
+		round,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return amountA, amountB, nil
+}
+
+func leBytesToBigInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
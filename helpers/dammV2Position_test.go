@@ -0,0 +1,65 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toLeBytes32(v *big.Int) [32]uint8 {
+	var out [32]uint8
+	be := v.FillBytes(make([]byte, 32))
+	for i := range be {
+		out[31-i] = be[i]
+	}
+	return out
+}
+
+func TestGetDammV2UnclaimedFee(t *testing.T) {
+	pool := &dammv2.PoolAccount{
+		// 3 token A and 5 token B per unit of liquidity since the checkpoint
+		FeeAPerLiquidity: toLeBytes32(new(big.Int).Lsh(big.NewInt(4), 128)),
+		FeeBPerLiquidity: toLeBytes32(new(big.Int).Lsh(big.NewInt(5), 128)),
+	}
+	position := &dammv2.PositionAccount{
+		FeeAPerTokenCheckpoint:   toLeBytes32(new(big.Int).Lsh(big.NewInt(1), 128)),
+		FeeAPending:              7,
+		FeeBPending:              0,
+		UnlockedLiquidity:        helpers.MustBigIntToUint128(big.NewInt(10)),
+		VestedLiquidity:          helpers.MustBigIntToUint128(big.NewInt(20)),
+		PermanentLockedLiquidity: helpers.MustBigIntToUint128(big.NewInt(70)),
+	}
+
+	assert.Equal(t, big.NewInt(100), helpers.GetDammV2PositionLiquidity(position))
+
+	feeA, feeB := helpers.GetDammV2UnclaimedFee(pool, position)
+	assert.Equal(t, big.NewInt(307), feeA)
+	assert.Equal(t, big.NewInt(500), feeB)
+}
+
+func TestGetDammV2LiquidityDelta(t *testing.T) {
+	pool := &dammv2.PoolAccount{
+		SqrtMinPrice: helpers.MustBigIntToUint128(constants.MinSqrtPrice),
+		SqrtMaxPrice: helpers.MustBigIntToUint128(constants.MaxSqrtPrice),
+		SqrtPrice:    helpers.MustBigIntToUint128(constants.OneQ64),
+	}
+
+	liquidity, err := helpers.GetDammV2LiquidityDelta(pool, 1_000_000_000, 2_000_000_000)
+	assert.NoError(t, err)
+
+	amountA, amountB, err := helpers.GetDammV2AmountsFromLiquidity(pool, liquidity, types.RoundingUp)
+	assert.NoError(t, err)
+
+	// the token A side is binding at price 1 with the full price range
+	assert.LessOrEqual(t, amountA.Uint64(), uint64(1_000_000_000))
+	assert.InDelta(t, 1_000_000_000, amountA.Uint64(), 1)
+	assert.LessOrEqual(t, amountB.Uint64(), uint64(2_000_000_000))
+
+	_, _, err = helpers.GetDammV2AmountsFromLiquidity(pool, big.NewInt(-1), types.RoundingUp)
+	assert.Error(t, err)
+}
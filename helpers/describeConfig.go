@@ -0,0 +1,305 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	mathsPoolfees "dbcGoSDK/maths/poolFees"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DescribeConfig decodes a pool config into human units with a readable summary.
+// The quote token decimal is not stored in the config so it has to be provided.
+func DescribeConfig(
+	config *dbc.PoolConfigAccount,
+	tokenQuoteDecimal types.TokenDecimal,
+) (types.ConfigDescription, error) {
+	if config == nil {
+		return types.ConfigDescription{}, errors.New("config cannot be nil")
+	}
+
+	tokenBaseDecimal := types.TokenDecimal(config.TokenDecimal)
+	toBase := func(amount *big.Int) float64 {
+		f, _ := ConvertFromLamportsRat(amount, tokenBaseDecimal).Float64()
+		return f
+	}
+	toQuote := func(amount *big.Int) float64 {
+		f, _ := ConvertFromLamportsRat(amount, tokenQuoteDecimal).Float64()
+		return f
+	}
+	toFloat := func(r *big.Rat) float64 {
+		f, _ := r.Float64()
+		return f
+	}
+
+	swapAmount := new(big.Int).SetUint64(config.SwapBaseAmount)
+	migrationAmount := new(big.Int).SetUint64(config.MigrationBaseThreshold)
+	vestingAmount := getTotalVestingAmountFromConfig(config.LockedVestingConfig)
+
+	// dynamic supply configs mint exactly the curve, migration and vesting amounts
+	totalSupply := new(big.Int).Add(swapAmount, migrationAmount)
+	totalSupply.Add(totalSupply, vestingAmount)
+	// fixed supply configs burn the leftover down to the post migration supply when migrating
+	migrationSupply := totalSupply
+	if config.FixedTokenSupplyFlag == 1 {
+		totalSupply.SetUint64(config.PreMigrationTokenSupply)
+		migrationSupply = new(big.Int).SetUint64(config.PostMigrationTokenSupply)
+	}
+	leftoverAmount := new(big.Int).Sub(totalSupply, swapAmount)
+	leftoverAmount.Sub(leftoverAmount, migrationAmount)
+	leftoverAmount.Sub(leftoverAmount, vestingAmount)
+	if leftoverAmount.Sign() < 0 {
+		leftoverAmount.SetInt64(0)
+	}
+
+	percentOfSupply := func(amount *big.Int) float64 {
+		if totalSupply.Sign() == 0 {
+			return 0
+		}
+		return toFloat(new(big.Rat).SetFrac(new(big.Int).Mul(amount, big.NewInt(100)), totalSupply))
+	}
+
+	sqrtStartPrice, migrationSqrtPrice := config.SqrtStartPrice.BigInt(), config.MigrationSqrtPrice.BigInt()
+
+	baseFee, err := describeBaseFee(config, tokenQuoteDecimal)
+	if err != nil {
+		return types.ConfigDescription{}, err
+	}
+
+	description := types.ConfigDescription{
+		QuoteMint:         config.QuoteMint,
+		FeeClaimer:        config.FeeClaimer,
+		LeftoverReceiver:  config.LeftoverReceiver,
+		TokenType:         tokenTypeName(config.TokenType),
+		TokenBaseDecimal:  config.TokenDecimal,
+		TokenQuoteDecimal: uint8(tokenQuoteDecimal),
+		ActivationType:    activationTypeName(config.ActivationType),
+
+		InitialPrice:            toFloat(GetPriceFromSqrtPrice(sqrtStartPrice, tokenBaseDecimal, tokenQuoteDecimal)),
+		InitialMarketCap:        toFloat(GetMarketCapFromSqrtPrice(sqrtStartPrice, totalSupply, tokenBaseDecimal, tokenQuoteDecimal)),
+		MigrationPrice:          toFloat(GetPriceFromSqrtPrice(migrationSqrtPrice, tokenBaseDecimal, tokenQuoteDecimal)),
+		MigrationMarketCap:      toFloat(GetMarketCapFromSqrtPrice(migrationSqrtPrice, migrationSupply, tokenBaseDecimal, tokenQuoteDecimal)),
+		MigrationQuoteThreshold: toQuote(new(big.Int).SetUint64(config.MigrationQuoteThreshold)),
+
+		FixedTokenSupply:            config.FixedTokenSupplyFlag == 1,
+		TotalSupply:                 toBase(totalSupply),
+		CurveSupplyPercentage:       percentOfSupply(swapAmount),
+		MigrationSupplyPercentage:   percentOfSupply(migrationAmount),
+		VestingSupplyPercentage:     percentOfSupply(vestingAmount),
+		LeftoverSupplyPercentage:    percentOfSupply(leftoverAmount),
+		CollectFeeMode:              collectFeeModeName(config.CollectFeeMode),
+		CreatorTradingFeePercentage: config.CreatorTradingFeePercentage,
+
+		BaseFee: baseFee,
+
+		PartnerLpPercentage:       config.PartnerLpPercentage,
+		PartnerLockedLpPercentage: config.PartnerLockedLpPercentage,
+		CreatorLpPercentage:       config.CreatorLpPercentage,
+		CreatorLockedLpPercentage: config.CreatorLockedLpPercentage,
+
+		MigrationTarget:               migrationOptionName(config.MigrationOption),
+		MigrationFeeOption:            migrationFeeOptionName(config.MigrationFeeOption),
+		MigrationFeePercentage:        config.MigrationFeePercentage,
+		CreatorMigrationFeePercentage: config.CreatorMigrationFeePercentage,
+
+		TokenUpdateAuthority: tokenUpdateAuthorityName(config.TokenUpdateAuthority),
+	}
+
+	if types.MigrationFeeOption(config.MigrationFeeOption) == types.MigrationFeeOptionCustomizable {
+		description.MigratedPoolFeeBps = config.MigratedPoolFeeBps
+		description.MigratedCollectFeeMode = "both tokens"
+		if config.MigratedCollectFeeMode == 1 {
+			description.MigratedCollectFeeMode = "quote token"
+		}
+		description.MigratedDynamicFee = config.MigratedDynamicFee == 1
+	}
+
+	if dynamicFee := config.PoolFees.DynamicFee; mathsPoolfees.IsDynamicFeeEnabled(dynamicFee) {
+		description.DynamicFee = &types.DynamicFeeDescription{
+			BinStep:                  dynamicFee.BinStep,
+			FilterPeriod:             dynamicFee.FilterPeriod,
+			DecayPeriod:              dynamicFee.DecayPeriod,
+			ReductionFactor:          dynamicFee.ReductionFactor,
+			MaxVolatilityAccumulator: dynamicFee.MaxVolatilityAccumulator,
+			VariableFeeControl:       dynamicFee.VariableFeeControl,
+		}
+	}
+
+	if vestingAmount.Sign() > 0 {
+		lockedVesting := config.LockedVestingConfig
+		description.LockedVesting = &types.LockedVestingDescription{
+			TotalAmount:                    toBase(vestingAmount),
+			CliffAmount:                    toBase(new(big.Int).SetUint64(lockedVesting.CliffUnlockAmount)),
+			AmountPerPeriod:                toBase(new(big.Int).SetUint64(lockedVesting.AmountPerPeriod)),
+			NumberOfPeriod:                 lockedVesting.NumberOfPeriod,
+			Frequency:                      lockedVesting.Frequency,
+			CliffDurationFromMigrationTime: lockedVesting.CliffDurationFromMigrationTime,
+		}
+	}
+
+	description.Summary = describeConfigSummary(description)
+	return description, nil
+}
+
+// describeBaseFee converts the base fee numerators into bps.
+func describeBaseFee(
+	config *dbc.PoolConfigAccount,
+	tokenQuoteDecimal types.TokenDecimal,
+) (types.BaseFeeDescription, error) {
+	baseFee := config.PoolFees.BaseFee
+	toBps := func(numerator *big.Int) float64 {
+		f, _ := new(big.Rat).SetFrac(
+			new(big.Int).Mul(numerator, big.NewInt(constants.BasisPointMax)),
+			constants.FeeDenominatorBigInt,
+		).Float64()
+		return f
+	}
+
+	cliffFeeNumerator := new(big.Int).SetUint64(baseFee.CliffFeeNumerator)
+	description := types.BaseFeeDescription{
+		StartingFeeBps: toBps(cliffFeeNumerator),
+		EndingFeeBps:   toBps(cliffFeeNumerator),
+	}
+
+	switch types.BaseFeeMode(baseFee.BaseFeeMode) {
+	case types.BaseFeeModeFeeSchedulerLinear, types.BaseFeeModeFeeSchedulerExponential:
+		description.Mode = "fee scheduler linear"
+		if types.BaseFeeMode(baseFee.BaseFeeMode) == types.BaseFeeModeFeeSchedulerExponential {
+			description.Mode = "fee scheduler exponential"
+		}
+		description.NumberOfPeriod = baseFee.FirstFactor
+		description.PeriodFrequency = baseFee.SecondFactor
+		description.ReductionFactor = baseFee.ThirdFactor
+
+		minFeeNumerator, err := mathsPoolfees.GetMinBaseFeeNumerator(
+			cliffFeeNumerator,
+			baseFee.FirstFactor,
+			new(big.Int).SetUint64(baseFee.SecondFactor),
+			new(big.Int).SetUint64(baseFee.ThirdFactor),
+			types.BaseFeeMode(baseFee.BaseFeeMode),
+		)
+		if err != nil {
+			return types.BaseFeeDescription{}, err
+		}
+		description.EndingFeeBps = toBps(minFeeNumerator)
+
+	case types.BaseFeeModeRateLimiter:
+		description.Mode = "rate limiter"
+		description.FeeIncrementBps = baseFee.FirstFactor
+		description.MaxLimiterDuration = baseFee.SecondFactor
+		description.ReferenceAmount, _ = ConvertFromLamportsRat(
+			new(big.Int).SetUint64(baseFee.ThirdFactor), tokenQuoteDecimal,
+		).Float64()
+
+	default:
+		return types.BaseFeeDescription{}, fmt.Errorf("unknown base fee mode: %d", baseFee.BaseFeeMode)
+	}
+
+	return description, nil
+}
+
+func describeConfigSummary(d types.ConfigDescription) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s token with %d decimals, total supply %g (fixed supply: %t)\n",
+		d.TokenType, d.TokenBaseDecimal, d.TotalSupply, d.FixedTokenSupply)
+	fmt.Fprintf(&b, "price %g -> %g, market cap %g -> %g, migrates at %g quote raised\n",
+		d.InitialPrice, d.MigrationPrice, d.InitialMarketCap, d.MigrationMarketCap, d.MigrationQuoteThreshold)
+	fmt.Fprintf(&b, "supply: %.2f%% curve, %.2f%% migration, %.2f%% vesting, %.2f%% leftover\n",
+		d.CurveSupplyPercentage, d.MigrationSupplyPercentage, d.VestingSupplyPercentage, d.LeftoverSupplyPercentage)
+
+	switch d.BaseFee.Mode {
+	case "rate limiter":
+		fmt.Fprintf(&b, "base fee: %s, %g bps +%d bps per %g quote for %d %s\n",
+			d.BaseFee.Mode, d.BaseFee.StartingFeeBps, d.BaseFee.FeeIncrementBps,
+			d.BaseFee.ReferenceAmount, d.BaseFee.MaxLimiterDuration, d.ActivationType)
+	default:
+		fmt.Fprintf(&b, "base fee: %s, %g bps -> %g bps over %d periods of %d %s\n",
+			d.BaseFee.Mode, d.BaseFee.StartingFeeBps, d.BaseFee.EndingFeeBps,
+			d.BaseFee.NumberOfPeriod, d.BaseFee.PeriodFrequency, d.ActivationType)
+	}
+	fmt.Fprintf(&b, "dynamic fee: %t, fees collected in %s, creator gets %d%% of trading fees\n",
+		d.DynamicFee != nil, d.CollectFeeMode, d.CreatorTradingFeePercentage)
+	fmt.Fprintf(&b, "LP: partner %d%% (+%d%% locked), creator %d%% (+%d%% locked)\n",
+		d.PartnerLpPercentage, d.PartnerLockedLpPercentage, d.CreatorLpPercentage, d.CreatorLockedLpPercentage)
+	fmt.Fprintf(&b, "migrates to %s with %s fee, migration fee %d%% (%d%% to creator)\n",
+		d.MigrationTarget, d.MigrationFeeOption, d.MigrationFeePercentage, d.CreatorMigrationFeePercentage)
+	if d.LockedVesting != nil {
+		fmt.Fprintf(&b, "vesting: %g locked, %g at cliff then %g every %ds for %d periods\n",
+			d.LockedVesting.TotalAmount, d.LockedVesting.CliffAmount, d.LockedVesting.AmountPerPeriod,
+			d.LockedVesting.Frequency, d.LockedVesting.NumberOfPeriod)
+	}
+	fmt.Fprintf(&b, "token authority: %s", d.TokenUpdateAuthority)
+
+	return b.String()
+}
+
+func tokenTypeName(tokenType uint8) string {
+	if types.TokenType(tokenType) == types.TokenTypeToken2022 {
+		return "Token2022"
+	}
+	return "SPL"
+}
+
+func activationTypeName(activationType uint8) string {
+	if types.ActivationType(activationType) == types.ActivationTypeTimestamp {
+		return "seconds"
+	}
+	return "slots"
+}
+
+func collectFeeModeName(collectFeeMode uint8) string {
+	if types.CollectFeeMode(collectFeeMode) == types.CollectFeeModeOutputToken {
+		return "output token"
+	}
+	return "quote token"
+}
+
+func migrationOptionName(migrationOption uint8) string {
+	switch types.MigrationOption(migrationOption) {
+	case types.MigrationOptionMET_DAMM:
+		return "DAMM v1"
+	case types.MigrationOptionMET_DAMM_V2:
+		return "DAMM v2"
+	}
+	return fmt.Sprintf("unknown(%d)", migrationOption)
+}
+
+func migrationFeeOptionName(migrationFeeOption uint8) string {
+	switch types.MigrationFeeOption(migrationFeeOption) {
+	case types.MigrationFeeOptionFixedBps25:
+		return "fixed 25 bps"
+	case types.MigrationFeeOptionFixedBps30:
+		return "fixed 30 bps"
+	case types.MigrationFeeOptionFixedBps100:
+		return "fixed 100 bps"
+	case types.MigrationFeeOptionFixedBps200:
+		return "fixed 200 bps"
+	case types.MigrationFeeOptionFixedBps400:
+		return "fixed 400 bps"
+	case types.MigrationFeeOptionFixedBps600:
+		return "fixed 600 bps"
+	case types.MigrationFeeOptionCustomizable:
+		return "customizable"
+	}
+	return fmt.Sprintf("unknown(%d)", migrationFeeOption)
+}
+
+func tokenUpdateAuthorityName(tokenUpdateAuthority uint8) string {
+	switch types.TokenUpdateAuthorityOption(tokenUpdateAuthority) {
+	case types.TokenUpdateAuthorityOptionCreatorUpdateAuthority:
+		return "creator update authority"
+	case types.TokenUpdateAuthorityOptionImmutable:
+		return "immutable"
+	case types.TokenUpdateAuthorityOptionPartnerUpdateAuthority:
+		return "partner update authority"
+	case types.TokenUpdateAuthorityOptionCreatorUpdateAndMintAuthority:
+		return "creator update and mint authority"
+	case types.TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority:
+		return "partner update and mint authority"
+	}
+	return fmt.Sprintf("unknown(%d)", tokenUpdateAuthority)
+}
@@ -0,0 +1,80 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeConfig(t *testing.T) {
+	config := &dbc.PoolConfigAccount{
+		PoolFees: dbc.PoolFeesConfig{
+			BaseFee: dbc.BaseFeeConfig{
+				CliffFeeNumerator: 50_000_000, // 500 bps
+				FirstFactor:       10,
+				SecondFactor:      60,
+				ThirdFactor:       4_000_000, // 40 bps per period
+			},
+		},
+		MigrationOption:           uint8(types.MigrationOptionMET_DAMM_V2),
+		TokenDecimal:              uint8(types.TokenDecimalSIX),
+		PartnerLockedLpPercentage: 50,
+		CreatorLockedLpPercentage: 50,
+		MigrationFeeOption:        uint8(types.MigrationFeeOptionCustomizable),
+		FixedTokenSupplyFlag:      1,
+		MigrationFeePercentage:    10,
+		SwapBaseAmount:            600_000_000_000,
+		MigrationQuoteThreshold:   85_000_000_000,
+		MigrationBaseThreshold:    200_000_000_000,
+		LockedVestingConfig: dbc.LockedVestingConfig{
+			AmountPerPeriod:   10_000_000_000,
+			Frequency:         3_600,
+			NumberOfPeriod:    5,
+			CliffUnlockAmount: 50_000_000_000,
+		},
+		PreMigrationTokenSupply:  1_000_000_000_000,
+		PostMigrationTokenSupply: 900_000_000_000,
+		MigratedPoolFeeBps:       100,
+		SqrtStartPrice:           helpers.MustBigIntToUint128(helpers.GetSqrtPriceFromPriceRat(helpers.MustParseRat("0.0000001"), types.TokenDecimalSIX, types.TokenDecimalNINE)),
+		MigrationSqrtPrice:       helpers.MustBigIntToUint128(helpers.GetSqrtPriceFromPriceRat(helpers.MustParseRat("0.000001"), types.TokenDecimalSIX, types.TokenDecimalNINE)),
+	}
+
+	d, err := helpers.DescribeConfig(config, types.TokenDecimalNINE)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1_000_000), d.TotalSupply)
+	assert.InDelta(t, 60, d.CurveSupplyPercentage, 1e-9)
+	assert.InDelta(t, 20, d.MigrationSupplyPercentage, 1e-9)
+	assert.InDelta(t, 10, d.VestingSupplyPercentage, 1e-9)
+	assert.InDelta(t, 10, d.LeftoverSupplyPercentage, 1e-9)
+
+	assert.InDelta(t, 0.0000001, d.InitialPrice, 1e-12)
+	assert.InDelta(t, 0.1, d.InitialMarketCap, 1e-6)
+	assert.InDelta(t, 0.9, d.MigrationMarketCap, 1e-6)
+	assert.Equal(t, float64(85), d.MigrationQuoteThreshold)
+
+	assert.Equal(t, "fee scheduler linear", d.BaseFee.Mode)
+	assert.Equal(t, float64(500), d.BaseFee.StartingFeeBps)
+	assert.Equal(t, float64(100), d.BaseFee.EndingFeeBps)
+	assert.Nil(t, d.DynamicFee)
+
+	assert.Equal(t, "DAMM v2", d.MigrationTarget)
+	assert.Equal(t, "customizable", d.MigrationFeeOption)
+	assert.Equal(t, uint16(100), d.MigratedPoolFeeBps)
+	assert.Equal(t, float64(100_000), d.LockedVesting.TotalAmount)
+	assert.Equal(t, "creator update authority", d.TokenUpdateAuthority)
+	assert.Contains(t, d.Summary, "500 bps -> 100 bps over 10 periods of 60 slots")
+
+	raw, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"migrationTarget":"DAMM v2"`)
+
+	config.MigrationOption = 7
+	d, err = helpers.DescribeConfig(config, types.TokenDecimalNINE)
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown(7)", d.MigrationTarget)
+}
@@ -0,0 +1,212 @@
+package helpers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"dbcGoSDK/types"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// a base58 public key has at most 44 characters
+	maxVanityLength = 44
+
+	// MinKeypairSeedLength is the shortest seed accepted to derive launch keypairs.
+	MinKeypairSeedLength = 16
+
+	keypairPurposeConfig   = "config"
+	keypairPurposeBaseMint = "base_mint"
+)
+
+// GrindVanityKeypair generates keypairs on param.Workers goroutines until a public key starts with
+// param.Prefix and ends with param.Suffix, or ctx is done. Every extra character multiplies the expected
+// attempts by about 58, less for letters matched case insensitively.
+func GrindVanityKeypair(ctx context.Context, param types.VanityKeypairParam) (solana.PrivateKey, error) {
+	if err := ValidateVanityParam(param); err != nil {
+		return nil, err
+	}
+
+	prefix, suffix := param.Prefix, param.Suffix
+	if !param.CaseSensitive {
+		prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	}
+
+	workers := param.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan solana.PrivateKey, 1)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				key, err := solana.NewRandomPrivateKey()
+				if err != nil {
+					continue
+				}
+
+				address := key.PublicKey().String()
+				if !param.CaseSensitive {
+					address = strings.ToLower(address)
+				}
+				if strings.HasPrefix(address, prefix) && strings.HasSuffix(address, suffix) {
+					select {
+					case found <- key:
+						cancel()
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case key := <-found:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("vanity keypair not found: %w", ctx.Err())
+	}
+}
+
+// ValidateVanityParam checks the prefix and suffix only use base58 characters and fit in a public key,
+// a character matched case insensitively only needs one of its cases in base58 (e.g. l for L).
+func ValidateVanityParam(param types.VanityKeypairParam) error {
+	if param.Prefix == "" && param.Suffix == "" {
+		return errors.New("prefix or suffix is required")
+	}
+	if len(param.Prefix)+len(param.Suffix) > maxVanityLength {
+		return fmt.Errorf("prefix and suffix cannot be longer than %d characters", maxVanityLength)
+	}
+
+	for _, s := range []string{param.Prefix, param.Suffix} {
+		for _, c := range s {
+			if !isBase58Rune(c, param.CaseSensitive) {
+				return fmt.Errorf("character(%q) is not base58", c)
+			}
+		}
+	}
+	return nil
+}
+
+func isBase58Rune(c rune, caseSensitive bool) bool {
+	if strings.ContainsRune(base58Alphabet, c) {
+		return true
+	}
+	if caseSensitive {
+		return false
+	}
+	return strings.ContainsRune(base58Alphabet, unicode.ToLower(c)) ||
+		strings.ContainsRune(base58Alphabet, unicode.ToUpper(c))
+}
+
+// DeriveKeypairFromSeed derives the keypair at index for a purpose from seed.
+//
+//	keypair = ed25519(sha256(seed || purpose || index))
+func DeriveKeypairFromSeed(seed []byte, purpose string, index uint64) (solana.PrivateKey, error) {
+	if len(seed) < MinKeypairSeedLength {
+		return nil, fmt.Errorf("seed must be at least %d bytes", MinKeypairSeedLength)
+	}
+
+	h := sha256.New()
+	h.Write(seed)
+	h.Write([]byte(purpose))
+	h.Write(binary.BigEndian.AppendUint64(nil, index))
+
+	return solana.PrivateKey(ed25519.NewKeyFromSeed(h.Sum(nil))), nil
+}
+
+// DeriveLaunchKeypairs derives the config and base mint keypairs of the launch at index from seed,
+// the same seed and index always give the same launch.
+func DeriveLaunchKeypairs(seed []byte, index uint64) (types.LaunchKeypairs, error) {
+	config, err := DeriveKeypairFromSeed(seed, keypairPurposeConfig, index)
+	if err != nil {
+		return types.LaunchKeypairs{}, err
+	}
+
+	baseMint, err := DeriveKeypairFromSeed(seed, keypairPurposeBaseMint, index)
+	if err != nil {
+		return types.LaunchKeypairs{}, err
+	}
+
+	return types.LaunchKeypairs{Config: config, BaseMint: baseMint}, nil
+}
+
+// SeedKeypairProvider is a LaunchKeypairProvider deriving the launches of a seed one index after the other.
+type SeedKeypairProvider struct {
+	mu    sync.Mutex
+	seed  []byte
+	index uint64
+}
+
+// NewSeedKeypairProvider starts at index, resume a provider from the index of its next launch.
+func NewSeedKeypairProvider(seed []byte, index uint64) (*SeedKeypairProvider, error) {
+	if len(seed) < MinKeypairSeedLength {
+		return nil, fmt.Errorf("seed must be at least %d bytes", MinKeypairSeedLength)
+	}
+	return &SeedKeypairProvider{seed: seed, index: index}, nil
+}
+
+func (s *SeedKeypairProvider) NextLaunchKeypairs(_ context.Context) (types.LaunchKeypairs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keypairs, err := DeriveLaunchKeypairs(s.seed, s.index)
+	if err != nil {
+		return types.LaunchKeypairs{}, err
+	}
+	s.index++
+	return keypairs, nil
+}
+
+// Index gets the index of the next launch.
+func (s *SeedKeypairProvider) Index() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.index
+}
+
+// VanityKeypairProvider is a LaunchKeypairProvider grinding a vanity base mint for every launch,
+// the config keypair is random.
+type VanityKeypairProvider struct {
+	param types.VanityKeypairParam
+}
+
+func NewVanityKeypairProvider(param types.VanityKeypairParam) (*VanityKeypairProvider, error) {
+	if err := ValidateVanityParam(param); err != nil {
+		return nil, err
+	}
+	return &VanityKeypairProvider{param: param}, nil
+}
+
+func (v *VanityKeypairProvider) NextLaunchKeypairs(ctx context.Context) (types.LaunchKeypairs, error) {
+	baseMint, err := GrindVanityKeypair(ctx, v.param)
+	if err != nil {
+		return types.LaunchKeypairs{}, err
+	}
+
+	config, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		return types.LaunchKeypairs{}, err
+	}
+
+	return types.LaunchKeypairs{Config: config, BaseMint: baseMint}, nil
+}
@@ -0,0 +1,84 @@
+package helpers_test
+
+import (
+	"context"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveLaunchKeypairs(t *testing.T) {
+	seed := []byte("a reproducible launch seed")
+
+	first, err := helpers.DeriveLaunchKeypairs(seed, 0)
+	assert.NoError(t, err)
+	again, err := helpers.DeriveLaunchKeypairs(seed, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first.Config.PublicKey(), first.BaseMint.PublicKey())
+
+	second, err := helpers.DeriveLaunchKeypairs(seed, 1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.BaseMint.PublicKey(), second.BaseMint.PublicKey())
+
+	_, err = helpers.DeriveLaunchKeypairs([]byte("short"), 0)
+	assert.Error(t, err)
+
+	provider, err := helpers.NewSeedKeypairProvider(seed, 1)
+	assert.NoError(t, err)
+	next, err := provider.NextLaunchKeypairs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, second, next)
+	assert.Equal(t, uint64(2), provider.Index())
+}
+
+func TestGrindVanityKeypair(t *testing.T) {
+	t.Run("suffix", func(t *testing.T) {
+		key, err := helpers.GrindVanityKeypair(context.Background(), types.VanityKeypairParam{Suffix: "a"})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(strings.ToLower(key.PublicKey().String()), "a"))
+	})
+
+	t.Run("case sensitive prefix", func(t *testing.T) {
+		provider, err := helpers.NewVanityKeypairProvider(types.VanityKeypairParam{
+			Prefix:        "Z",
+			CaseSensitive: true,
+			Workers:       2,
+		})
+		assert.NoError(t, err)
+
+		keypairs, err := provider.NextLaunchKeypairs(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(keypairs.BaseMint.PublicKey().String(), "Z"))
+		assert.NotNil(t, keypairs.Config)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := helpers.GrindVanityKeypair(context.Background(), types.VanityKeypairParam{})
+		assert.Error(t, err)
+		_, err = helpers.GrindVanityKeypair(context.Background(), types.VanityKeypairParam{Suffix: "pump0"})
+		assert.Error(t, err)
+		assert.Error(t, helpers.ValidateVanityParam(types.VanityKeypairParam{Prefix: "l", CaseSensitive: true}))
+	})
+
+	t.Run("case insensitive alphabet", func(t *testing.T) {
+		assert.NoError(t, helpers.ValidateVanityParam(types.VanityKeypairParam{Prefix: "l"}))
+		assert.NoError(t, helpers.ValidateVanityParam(types.VanityKeypairParam{Suffix: "IO"}))
+
+		key, err := helpers.GrindVanityKeypair(context.Background(), types.VanityKeypairParam{Prefix: "l"})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(strings.ToLower(key.PublicKey().String()), "l"))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := helpers.GrindVanityKeypair(ctx, types.VanityKeypairParam{Prefix: "zzzzzzzzzz", CaseSensitive: true})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
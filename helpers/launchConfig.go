@@ -0,0 +1,468 @@
+package helpers
+
+import (
+	"bytes"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const secondsPerDay = 86_400
+
+var (
+	launchTokenTypes = map[string]types.TokenType{
+		"spl":       types.TokenTypeSPL,
+		"token2022": types.TokenTypeToken2022,
+	}
+	launchActivationTypes = map[string]types.ActivationType{
+		"slot":      types.ActivationTypeSlot,
+		"timestamp": types.ActivationTypeTimestamp,
+	}
+	launchBaseFeeModes = map[string]types.BaseFeeMode{
+		"linear":      types.BaseFeeModeFeeSchedulerLinear,
+		"exponential": types.BaseFeeModeFeeSchedulerExponential,
+		"rateLimiter": types.BaseFeeModeRateLimiter,
+	}
+	launchCollectFeeModes = map[string]types.CollectFeeMode{
+		"quote":  types.CollectFeeModeQuoteToken,
+		"output": types.CollectFeeModeOutputToken,
+	}
+	launchMigrationOptions = map[string]types.MigrationOption{
+		"dammV1": types.MigrationOptionMET_DAMM,
+		"dammV2": types.MigrationOptionMET_DAMM_V2,
+	}
+	launchMigratedCollectFeeModes = map[string]uint8{
+		"both":  0,
+		"quote": 1,
+	}
+	launchMigrationFeeOptions = map[string]types.MigrationFeeOption{
+		"25bps":        types.MigrationFeeOptionFixedBps25,
+		"30bps":        types.MigrationFeeOptionFixedBps30,
+		"100bps":       types.MigrationFeeOptionFixedBps100,
+		"200bps":       types.MigrationFeeOptionFixedBps200,
+		"400bps":       types.MigrationFeeOptionFixedBps400,
+		"600bps":       types.MigrationFeeOptionFixedBps600,
+		"customizable": types.MigrationFeeOptionCustomizable,
+	}
+	launchTokenUpdateAuthorities = map[string]types.TokenUpdateAuthorityOption{
+		"creator":        types.TokenUpdateAuthorityOptionCreatorUpdateAuthority,
+		"immutable":      types.TokenUpdateAuthorityOptionImmutable,
+		"partner":        types.TokenUpdateAuthorityOptionPartnerUpdateAuthority,
+		"creatorAndMint": types.TokenUpdateAuthorityOptionCreatorUpdateAndMintAuthority,
+		"partnerAndMint": types.TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority,
+	}
+)
+
+// lookupLaunchName gets the value of a launch config name. Every named field defines the launch,
+// so an empty name is an error rather than the zero value.
+func lookupLaunchName[T comparable](field, name string, names map[string]T) (T, error) {
+	var zero T
+	if name == "" {
+		return zero, fmt.Errorf("missing %s", field)
+	}
+	if v, ok := names[name]; ok {
+		return v, nil
+	}
+	return zero, fmt.Errorf("invalid %s: %q", field, name)
+}
+
+// launchName gets the launch config name of a value.
+func launchName[T comparable](value T, names map[string]T) string {
+	for name, v := range names {
+		if v == value {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetLaunchConfigFormat gets the launch config format from a file extension.
+func GetLaunchConfigFormat(path string) (types.LaunchConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return types.LaunchConfigFormatJSON, nil
+	case ".yaml", ".yml":
+		return types.LaunchConfigFormatYAML, nil
+	}
+	return 0, fmt.Errorf("unsupported launch config file extension: %s", path)
+}
+
+// LoadLaunchConfig reads a JSON or YAML launch config file.
+func LoadLaunchConfig(path string) (types.LaunchConfig, error) {
+	format, err := GetLaunchConfigFormat(path)
+	if err != nil {
+		return types.LaunchConfig{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.LaunchConfig{}, fmt.Errorf("cannot read launch config: %w", err)
+	}
+
+	return UnmarshalLaunchConfig(data, format)
+}
+
+// SaveLaunchConfig writes a launch config file, the format follows the file extension.
+func SaveLaunchConfig(path string, launchConfig types.LaunchConfig) error {
+	format, err := GetLaunchConfigFormat(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := MarshalLaunchConfig(launchConfig, format)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// UnmarshalLaunchConfig decodes a launch config, unknown fields are rejected.
+func UnmarshalLaunchConfig(data []byte, format types.LaunchConfigFormat) (types.LaunchConfig, error) {
+	var launchConfig types.LaunchConfig
+
+	switch format {
+	case types.LaunchConfigFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&launchConfig); err != nil {
+			return types.LaunchConfig{}, fmt.Errorf("cannot decode launch config: %w", err)
+		}
+	case types.LaunchConfigFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&launchConfig); err != nil {
+			return types.LaunchConfig{}, fmt.Errorf("cannot decode launch config: %w", err)
+		}
+	default:
+		return types.LaunchConfig{}, fmt.Errorf("unsupported launch config format: %d", format)
+	}
+
+	return launchConfig, nil
+}
+
+// MarshalLaunchConfig encodes a launch config.
+func MarshalLaunchConfig(launchConfig types.LaunchConfig, format types.LaunchConfigFormat) ([]byte, error) {
+	switch format {
+	case types.LaunchConfigFormatJSON:
+		return json.MarshalIndent(launchConfig, "", "  ")
+	case types.LaunchConfigFormatYAML:
+		return yaml.Marshal(launchConfig)
+	}
+	return nil, fmt.Errorf("unsupported launch config format: %d", format)
+}
+
+// GetBuildCurveBaseParam converts the launch config fields shared by every curve builder.
+func GetBuildCurveBaseParam(launchConfig types.LaunchConfig) (types.BuildCurveBaseParam, error) {
+	tokenType, err := lookupLaunchName("tokenType", launchConfig.TokenType, launchTokenTypes)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	activationType, err := lookupLaunchName("activationType", launchConfig.ActivationType, launchActivationTypes)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	tokenUpdateAuthority, err := lookupLaunchName(
+		"tokenUpdateAuthority", launchConfig.TokenUpdateAuthority, launchTokenUpdateAuthorities,
+	)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	baseFeeMode, err := lookupLaunchName("fee.mode", launchConfig.Fee.Mode, launchBaseFeeModes)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	collectFeeMode, err := lookupLaunchName("fee.collectFeeMode", launchConfig.Fee.CollectFeeMode, launchCollectFeeModes)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	migrationOption, err := lookupLaunchName("migration.option", launchConfig.Migration.Option, launchMigrationOptions)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+	migrationFeeOption, err := lookupLaunchName(
+		"migration.feeOption", launchConfig.Migration.FeeOption, launchMigrationFeeOptions,
+	)
+	if err != nil {
+		return types.BuildCurveBaseParam{}, err
+	}
+
+	// the migrated pool fee only applies to the customizable fee option, where its collect fee mode is required
+	var migratedCollectFeeMode uint8
+	if migrationFeeOption == types.MigrationFeeOptionCustomizable || launchConfig.Migration.CollectFeeMode != "" {
+		migratedCollectFeeMode, err = lookupLaunchName(
+			"migration.collectFeeMode", launchConfig.Migration.CollectFeeMode, launchMigratedCollectFeeModes,
+		)
+		if err != nil {
+			return types.BuildCurveBaseParam{}, err
+		}
+	}
+
+	baseFeeParams := types.BaseFeeParams{BaseFeeMode: baseFeeMode}
+	if baseFeeMode == types.BaseFeeModeRateLimiter {
+		baseFeeParams.RateLimiterParam = &types.RateLimiterParams{
+			BaseFeeBps:         launchConfig.Fee.BaseFeeBps,
+			FeeIncrementBps:    launchConfig.Fee.FeeIncrementBps,
+			ReferenceAmount:    launchConfig.Fee.ReferenceAmount,
+			MaxLimiterDuration: launchConfig.Fee.MaxLimiterDuration,
+		}
+	} else {
+		baseFeeParams.FeeSchedulerParam = &types.FeeSchedulerParams{
+			StartingFeeBps: launchConfig.Fee.StartingFeeBps,
+			EndingFeeBps:   launchConfig.Fee.EndingFeeBps,
+			NumberOfPeriod: launchConfig.Fee.NumberOfPeriod,
+			TotalDuration:  launchConfig.Fee.TotalDuration,
+		}
+	}
+
+	var migratedDynamicFee uint8
+	if launchConfig.Migration.DynamicFee {
+		migratedDynamicFee = 1
+	}
+
+	return types.BuildCurveBaseParam{
+		TotalTokenSupply:  launchConfig.TotalTokenSupply,
+		MigrationOption:   migrationOption,
+		TokenBaseDecimal:  types.TokenDecimal(launchConfig.TokenBaseDecimal),
+		TokenQuoteDecimal: types.TokenDecimal(launchConfig.TokenQuoteDecimal),
+		LockedVestingParam: types.LockedVestingParams{
+			TotalLockedVestingAmount:       launchConfig.Vesting.TotalLockedAmount,
+			NumberOfVestingPeriod:          launchConfig.Vesting.NumberOfPeriod,
+			CliffUnlockAmount:              launchConfig.Vesting.CliffUnlockAmount,
+			TotalVestingDuration:           uint64(math.Round(launchConfig.Vesting.TotalDurationDays * secondsPerDay)),
+			CliffDurationFromMigrationTime: uint64(math.Round(launchConfig.Vesting.CliffDurationDays * secondsPerDay)),
+		},
+		BaseFeeParams:               baseFeeParams,
+		DynamicFeeEnabled:           launchConfig.Fee.DynamicFee,
+		ActivationType:              activationType,
+		CollectFeeMode:              collectFeeMode,
+		MigrationFeeOption:          migrationFeeOption,
+		TokenType:                   tokenType,
+		PartnerLpPercentage:         launchConfig.Lp.PartnerPercentage,
+		CreatorLpPercentage:         launchConfig.Lp.CreatorPercentage,
+		PartnerLockedLpPercentage:   launchConfig.Lp.PartnerLockedPercentage,
+		CreatorLockedLpPercentage:   launchConfig.Lp.CreatorLockedPercentage,
+		CreatorTradingFeePercentage: launchConfig.Fee.CreatorTradingFeePercentage,
+		Leftover:                    launchConfig.Leftover,
+		TokenUpdateAuthority:        uint8(tokenUpdateAuthority),
+		MigrationFee: types.MigrationFee{
+			FeePercentage:        launchConfig.Migration.FeePercentage,
+			CreatorFeePercentage: launchConfig.Migration.CreatorFeePercentage,
+		},
+		MigratedPoolFee: dbc.MigratedPoolFee{
+			CollectFeeMode: migratedCollectFeeMode,
+			DynamicFee:     migratedDynamicFee,
+			PoolFeeBps:     launchConfig.Migration.PoolFeeBps,
+		},
+	}, nil
+}
+
+// BuildConfigFromLaunchConfig runs the curve builder selected by the launch config and validates the result.
+func BuildConfigFromLaunchConfig(launchConfig types.LaunchConfig) (dbc.ConfigParameters, error) {
+	baseParam, err := GetBuildCurveBaseParam(launchConfig)
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+
+	var config dbc.ConfigParameters
+	switch launchConfig.Builder {
+	case "", "marketCap":
+		config, err = BuildCurveWithMarketCap(types.BuildCurveWithMarketCapParam{
+			BuildCurveBaseParam: baseParam,
+			InitialMarketCap:    launchConfig.InitialMarketCap,
+			MigrationMarketCap:  launchConfig.MigrationMarketCap,
+		})
+	case "percentage":
+		config, err = BuildCurve(types.BuildCurveParam{
+			BuildCurveBaseParam:         baseParam,
+			PercentageSupplyOnMigration: launchConfig.PercentageSupplyOnMigration,
+			MigrationQuoteThreshold:     launchConfig.MigrationQuoteThreshold,
+		})
+	case "twoSegments":
+		percentage := launchConfig.PercentageSupplyOnMigration
+		if percentage != math.Trunc(percentage) || percentage < 0 || percentage > 100 {
+			return dbc.ConfigParameters{}, fmt.Errorf(
+				"percentageSupplyOnMigration(%v) must be a whole number from 0 to 100 for the twoSegments builder", percentage,
+			)
+		}
+		marketCaps, err := ratsFromFloat64(launchConfig.InitialMarketCap, launchConfig.MigrationMarketCap)
+		if err != nil {
+			return dbc.ConfigParameters{}, err
+		}
+		config, err = BuildCurveWithTwoSegmentsRat(types.BuildCurveWithTwoSegmentsRatParam{
+			BuildCurveBaseParam:         baseParam,
+			InitialMarketCap:            marketCaps[0],
+			MigrationMarketCap:          marketCaps[1],
+			PercentageSupplyOnMigration: uint8(launchConfig.PercentageSupplyOnMigration),
+		})
+	case "liquidityWeights":
+		marketCaps, err := ratsFromFloat64(launchConfig.InitialMarketCap, launchConfig.MigrationMarketCap)
+		if err != nil {
+			return dbc.ConfigParameters{}, err
+		}
+		liquidityWeights, err := ratsFromFloat64(launchConfig.LiquidityWeights...)
+		if err != nil {
+			return dbc.ConfigParameters{}, err
+		}
+		config, err = BuildCurveWithLiquidityWeightsRat(types.BuildCurveWithLiquidityWeightsRatParam{
+			BuildCurveBaseParam: baseParam,
+			InitialMarketCap:    marketCaps[0],
+			MigrationMarketCap:  marketCaps[1],
+			LiquidityWeights:    liquidityWeights,
+		})
+	case "checkpoints":
+		checkpoints := make([]types.CurveCheckpoint, 0, len(launchConfig.Checkpoints))
+		for _, checkpoint := range launchConfig.Checkpoints {
+			checkpoints = append(checkpoints, types.CurveCheckpoint(checkpoint))
+		}
+		config, _, err = BuildCurveFromCheckpoints(types.BuildCurveFromCheckpointsParam{
+			BuildCurveBaseParam: baseParam,
+			InitialMarketCap:    launchConfig.InitialMarketCap,
+			Checkpoints:         checkpoints,
+		})
+	default:
+		return dbc.ConfigParameters{}, fmt.Errorf("invalid builder: %q", launchConfig.Builder)
+	}
+	if err != nil {
+		return dbc.ConfigParameters{}, err
+	}
+
+	if err := ValidateConfigParameters(config); err != nil {
+		return dbc.ConfigParameters{}, fmt.Errorf("invalid config parameters: %w", err)
+	}
+
+	return config, nil
+}
+
+// NewLaunchConfigFromMarketCapParam converts a market cap curve param into a launch config.
+func NewLaunchConfigFromMarketCapParam(param types.BuildCurveWithMarketCapParam) types.LaunchConfig {
+	launchConfig := newLaunchConfigFromBaseParam(param.BuildCurveBaseParam)
+	launchConfig.Builder = "marketCap"
+	launchConfig.InitialMarketCap = param.InitialMarketCap
+	launchConfig.MigrationMarketCap = param.MigrationMarketCap
+	return launchConfig
+}
+
+// NewLaunchConfigFromParam converts a percentage curve param into a launch config.
+func NewLaunchConfigFromParam(param types.BuildCurveParam) types.LaunchConfig {
+	launchConfig := newLaunchConfigFromBaseParam(param.BuildCurveBaseParam)
+	launchConfig.Builder = "percentage"
+	launchConfig.PercentageSupplyOnMigration = param.PercentageSupplyOnMigration
+	launchConfig.MigrationQuoteThreshold = param.MigrationQuoteThreshold
+	return launchConfig
+}
+
+// NewLaunchConfigFromTwoSegmentsParam converts a two segments curve param into a launch config.
+// Market caps are rounded to the nearest float64.
+func NewLaunchConfigFromTwoSegmentsParam(param types.BuildCurveWithTwoSegmentsRatParam) types.LaunchConfig {
+	launchConfig := newLaunchConfigFromBaseParam(param.BuildCurveBaseParam)
+	launchConfig.Builder = "twoSegments"
+	launchConfig.InitialMarketCap = ratToFloat64(param.InitialMarketCap)
+	launchConfig.MigrationMarketCap = ratToFloat64(param.MigrationMarketCap)
+	launchConfig.PercentageSupplyOnMigration = float64(param.PercentageSupplyOnMigration)
+	return launchConfig
+}
+
+// NewLaunchConfigFromLiquidityWeightsParam converts a liquidity weights curve param into a launch config.
+// Market caps and weights are rounded to the nearest float64.
+func NewLaunchConfigFromLiquidityWeightsParam(param types.BuildCurveWithLiquidityWeightsRatParam) types.LaunchConfig {
+	launchConfig := newLaunchConfigFromBaseParam(param.BuildCurveBaseParam)
+	launchConfig.Builder = "liquidityWeights"
+	launchConfig.InitialMarketCap = ratToFloat64(param.InitialMarketCap)
+	launchConfig.MigrationMarketCap = ratToFloat64(param.MigrationMarketCap)
+	launchConfig.LiquidityWeights = make([]float64, 0, len(param.LiquidityWeights))
+	for _, weight := range param.LiquidityWeights {
+		launchConfig.LiquidityWeights = append(launchConfig.LiquidityWeights, ratToFloat64(weight))
+	}
+	return launchConfig
+}
+
+// NewLaunchConfigFromCheckpointsParam converts a checkpoints curve param into a launch config.
+func NewLaunchConfigFromCheckpointsParam(param types.BuildCurveFromCheckpointsParam) types.LaunchConfig {
+	launchConfig := newLaunchConfigFromBaseParam(param.BuildCurveBaseParam)
+	launchConfig.Builder = "checkpoints"
+	launchConfig.InitialMarketCap = param.InitialMarketCap
+	launchConfig.Checkpoints = make([]types.LaunchConfigCheckpoint, 0, len(param.Checkpoints))
+	for _, checkpoint := range param.Checkpoints {
+		launchConfig.Checkpoints = append(launchConfig.Checkpoints, types.LaunchConfigCheckpoint(checkpoint))
+	}
+	return launchConfig
+}
+
+func ratToFloat64(r *big.Rat) float64 {
+	if r == nil {
+		return 0
+	}
+	f, _ := r.Float64()
+	return f
+}
+
+// newLaunchConfigFromBaseParam is the inverse of GetBuildCurveBaseParam.
+func newLaunchConfigFromBaseParam(param types.BuildCurveBaseParam) types.LaunchConfig {
+	fee := types.LaunchConfigFee{
+		Mode:                        launchName(param.BaseFeeParams.BaseFeeMode, launchBaseFeeModes),
+		DynamicFee:                  param.DynamicFeeEnabled,
+		CollectFeeMode:              launchName(param.CollectFeeMode, launchCollectFeeModes),
+		CreatorTradingFeePercentage: param.CreatorTradingFeePercentage,
+	}
+	if feeScheduler := param.BaseFeeParams.FeeSchedulerParam; feeScheduler != nil {
+		fee.StartingFeeBps = feeScheduler.StartingFeeBps
+		fee.EndingFeeBps = feeScheduler.EndingFeeBps
+		fee.NumberOfPeriod = feeScheduler.NumberOfPeriod
+		fee.TotalDuration = feeScheduler.TotalDuration
+	}
+	if rateLimiter := param.BaseFeeParams.RateLimiterParam; rateLimiter != nil {
+		fee.BaseFeeBps = rateLimiter.BaseFeeBps
+		fee.FeeIncrementBps = rateLimiter.FeeIncrementBps
+		fee.ReferenceAmount = rateLimiter.ReferenceAmount
+		fee.MaxLimiterDuration = rateLimiter.MaxLimiterDuration
+	}
+
+	var migratedCollectFeeMode string
+	if param.MigrationFeeOption == types.MigrationFeeOptionCustomizable || param.MigratedPoolFee.CollectFeeMode != 0 {
+		migratedCollectFeeMode = launchName(param.MigratedPoolFee.CollectFeeMode, launchMigratedCollectFeeModes)
+	}
+
+	return types.LaunchConfig{
+		TotalTokenSupply:  param.TotalTokenSupply,
+		TokenBaseDecimal:  uint8(param.TokenBaseDecimal),
+		TokenQuoteDecimal: uint8(param.TokenQuoteDecimal),
+		TokenType:         launchName(param.TokenType, launchTokenTypes),
+		ActivationType:    launchName(param.ActivationType, launchActivationTypes),
+		Leftover:          param.Leftover,
+		TokenUpdateAuthority: launchName(
+			types.TokenUpdateAuthorityOption(param.TokenUpdateAuthority), launchTokenUpdateAuthorities,
+		),
+		Fee: fee,
+		Vesting: types.LaunchConfigVesting{
+			TotalLockedAmount: param.LockedVestingParam.TotalLockedVestingAmount,
+			CliffUnlockAmount: param.LockedVestingParam.CliffUnlockAmount,
+			NumberOfPeriod:    param.LockedVestingParam.NumberOfVestingPeriod,
+			TotalDurationDays: float64(param.LockedVestingParam.TotalVestingDuration) / secondsPerDay,
+			CliffDurationDays: float64(param.LockedVestingParam.CliffDurationFromMigrationTime) / secondsPerDay,
+		},
+		Lp: types.LaunchConfigLp{
+			PartnerPercentage:       param.PartnerLpPercentage,
+			PartnerLockedPercentage: param.PartnerLockedLpPercentage,
+			CreatorPercentage:       param.CreatorLpPercentage,
+			CreatorLockedPercentage: param.CreatorLockedLpPercentage,
+		},
+		Migration: types.LaunchConfigMigration{
+			Option:               launchName(param.MigrationOption, launchMigrationOptions),
+			FeeOption:            launchName(param.MigrationFeeOption, launchMigrationFeeOptions),
+			FeePercentage:        param.MigrationFee.FeePercentage,
+			CreatorFeePercentage: param.MigrationFee.CreatorFeePercentage,
+			PoolFeeBps:           param.MigratedPoolFee.PoolFeeBps,
+			CollectFeeMode:       migratedCollectFeeMode,
+			DynamicFee:           param.MigratedPoolFee.DynamicFee == 1,
+		},
+	}
+}
@@ -0,0 +1,179 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLaunchConfigYAML = `
+builder: marketCap
+totalTokenSupply: 1000000000
+tokenBaseDecimal: 6
+tokenQuoteDecimal: 9
+tokenType: spl
+activationType: timestamp
+leftover: 10000
+tokenUpdateAuthority: immutable
+initialMarketCap: 20
+migrationMarketCap: 600
+fee:
+  mode: linear
+  startingFeeBps: 5000
+  endingFeeBps: 100
+  numberOfPeriod: 120
+  totalDuration: 120
+  dynamicFee: true
+  collectFeeMode: quote
+  creatorTradingFeePercentage: 50
+vesting:
+  totalLockedAmount: 10000000
+  numberOfPeriod: 30
+  totalDurationDays: 30
+  cliffDurationDays: 1
+lp:
+  partnerLockedPercentage: 50
+  creatorLockedPercentage: 50
+migration:
+  option: dammV2
+  feeOption: customizable
+  feePercentage: 5
+  creatorFeePercentage: 50
+  poolFeeBps: 100
+  collectFeeMode: quote
+`
+
+func TestLaunchConfig(t *testing.T) {
+	launchConfig, err := helpers.UnmarshalLaunchConfig([]byte(testLaunchConfigYAML), types.LaunchConfigFormatYAML)
+	if err != nil {
+		t.Fatalf("UnmarshalLaunchConfig errored: %s", err.Error())
+	}
+
+	t.Run("build config", func(t *testing.T) {
+		config, err := helpers.BuildConfigFromLaunchConfig(launchConfig)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint8(types.MigrationOptionMET_DAMM_V2), config.MigrationOption)
+		assert.Equal(t, uint8(types.TokenUpdateAuthorityOptionImmutable), config.TokenUpdateAuthority)
+		assert.Equal(t, uint8(types.BaseFeeModeFeeSchedulerLinear), config.PoolFees.BaseFee.BaseFeeMode)
+		assert.Equal(t, uint64(500_000_000), config.PoolFees.BaseFee.CliffFeeNumerator)
+		assert.NotNil(t, config.PoolFees.DynamicFee)
+		assert.Equal(t, uint16(100), config.MigratedPoolFee.PoolFeeBps)
+		assert.Equal(t, uint8(1), config.MigratedPoolFee.CollectFeeMode)
+		assert.Equal(t, uint64(24*60*60), config.LockedVesting.CliffDurationFromMigrationTime)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		baseParam, err := helpers.GetBuildCurveBaseParam(launchConfig)
+		assert.NoError(t, err)
+
+		reversed := helpers.NewLaunchConfigFromMarketCapParam(types.BuildCurveWithMarketCapParam{
+			BuildCurveBaseParam: baseParam,
+			InitialMarketCap:    launchConfig.InitialMarketCap,
+			MigrationMarketCap:  launchConfig.MigrationMarketCap,
+		})
+		assert.Equal(t, launchConfig, reversed)
+
+		dir := t.TempDir()
+		for _, name := range []string{"launch.json", "launch.yaml"} {
+			path := filepath.Join(dir, name)
+			assert.NoError(t, helpers.SaveLaunchConfig(path, reversed))
+
+			loaded, err := helpers.LoadLaunchConfig(path)
+			assert.NoError(t, err)
+			assert.Equal(t, reversed, loaded)
+		}
+	})
+
+	t.Run("round trip every builder", func(t *testing.T) {
+		baseParam, err := helpers.GetBuildCurveBaseParam(launchConfig)
+		assert.NoError(t, err)
+
+		for _, reversed := range []types.LaunchConfig{
+			helpers.NewLaunchConfigFromParam(types.BuildCurveParam{
+				BuildCurveBaseParam:         baseParam,
+				PercentageSupplyOnMigration: 20,
+				MigrationQuoteThreshold:     300,
+			}),
+			helpers.NewLaunchConfigFromTwoSegmentsParam(types.BuildCurveWithTwoSegmentsRatParam{
+				BuildCurveBaseParam:         baseParam,
+				InitialMarketCap:            big.NewRat(20, 1),
+				MigrationMarketCap:          big.NewRat(600, 1),
+				PercentageSupplyOnMigration: 20,
+			}),
+			helpers.NewLaunchConfigFromLiquidityWeightsParam(types.BuildCurveWithLiquidityWeightsRatParam{
+				BuildCurveBaseParam: baseParam,
+				InitialMarketCap:    big.NewRat(20, 1),
+				MigrationMarketCap:  big.NewRat(600, 1),
+				LiquidityWeights:    []*big.Rat{big.NewRat(1, 1), big.NewRat(3, 2)},
+			}),
+			helpers.NewLaunchConfigFromCheckpointsParam(types.BuildCurveFromCheckpointsParam{
+				BuildCurveBaseParam: baseParam,
+				InitialMarketCap:    20,
+				Checkpoints: []types.CurveCheckpoint{
+					{MarketCap: 100, SupplySoldPercentage: 10},
+					{MarketCap: 600, SupplySoldPercentage: 30},
+				},
+			}),
+		} {
+			data, err := helpers.MarshalLaunchConfig(reversed, types.LaunchConfigFormatYAML)
+			assert.NoError(t, err)
+
+			loaded, err := helpers.UnmarshalLaunchConfig(data, types.LaunchConfigFormatYAML)
+			assert.NoError(t, err)
+			assert.Equal(t, reversed, loaded, reversed.Builder)
+
+			loadedBaseParam, err := helpers.GetBuildCurveBaseParam(loaded)
+			assert.NoError(t, err)
+			assert.Equal(t, baseParam, loadedBaseParam, reversed.Builder)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		_, err := helpers.UnmarshalLaunchConfig([]byte(`{"totalSupply": 1}`), types.LaunchConfigFormatJSON)
+		assert.Error(t, err)
+
+		_, err = helpers.LoadLaunchConfig("launch.toml")
+		assert.Error(t, err)
+
+		invalid := launchConfig
+		invalid.Migration.Option = "dammV3"
+		_, err = helpers.BuildConfigFromLaunchConfig(invalid)
+		assert.ErrorContains(t, err, "migration.option")
+
+		for _, unset := range []func(*types.LaunchConfig){
+			func(c *types.LaunchConfig) { c.Migration.Option = "" },
+			func(c *types.LaunchConfig) { c.Fee.Mode = "" },
+			func(c *types.LaunchConfig) { c.TokenUpdateAuthority = "" },
+			func(c *types.LaunchConfig) { c.Migration.CollectFeeMode = "" },
+		} {
+			invalid = launchConfig
+			unset(&invalid)
+			_, err = helpers.BuildConfigFromLaunchConfig(invalid)
+			assert.ErrorContains(t, err, "missing")
+		}
+
+		invalid = launchConfig
+		invalid.Migration.CollectFeeMode = "output"
+		_, err = helpers.BuildConfigFromLaunchConfig(invalid)
+		assert.ErrorContains(t, err, "migration.collectFeeMode")
+
+		// a fixed fee option does not need the migrated collect fee mode
+		fixed := launchConfig
+		fixed.Migration.FeeOption = "25bps"
+		fixed.Migration.PoolFeeBps = 0
+		fixed.Migration.CollectFeeMode = ""
+		_, err = helpers.BuildConfigFromLaunchConfig(fixed)
+		assert.NoError(t, err)
+
+		invalid = launchConfig
+		invalid.Builder = "twoSegments"
+		invalid.PercentageSupplyOnMigration = 2.98
+		_, err = helpers.BuildConfigFromLaunchConfig(invalid)
+		assert.ErrorContains(t, err, "percentageSupplyOnMigration")
+	})
+}
@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ValidateMigrationPercentages checks the LP percentages sum to 100
+// and the migration fee percentages are within bounds.
+func ValidateMigrationPercentages(config *dbc.PoolConfigAccount) error {
+	if config == nil {
+		return errors.New("config cannot be nil")
+	}
+
+	total := uint64(config.PartnerLpPercentage) +
+		uint64(config.CreatorLpPercentage) +
+		uint64(config.PartnerLockedLpPercentage) +
+		uint64(config.CreatorLockedLpPercentage)
+	if total != 100 {
+		return fmt.Errorf("sum of LP percentages must equal 100, got %d", total)
+	}
+	if config.MigrationFeePercentage > constants.MaxMigrationFeePercentage {
+		return fmt.Errorf(
+			"migration fee percentage must be at most %d, got %d",
+			constants.MaxMigrationFeePercentage, config.MigrationFeePercentage,
+		)
+	}
+	if config.CreatorMigrationFeePercentage > constants.MaxCreatorMigrationFeePercentage {
+		return fmt.Errorf(
+			"creator migration fee percentage must be at most %d, got %d",
+			constants.MaxCreatorMigrationFeePercentage, config.CreatorMigrationFeePercentage,
+		)
+	}
+
+	return nil
+}
+
+// GetMigrationLpDistribution predicts how a pool is split at migration given the quote amount it migrates with.
+//
+//	migrationFee = migrationQuoteThreshold * migrationFeePercentage / 100
+//	creatorMigrationFee = migrationFee * creatorMigrationFeePercentage / 100
+//	partnerMigrationFee = migrationFee - creatorMigrationFee
+//	quoteAmount = migrationQuoteThreshold - migrationFee
+//	totalLp = √(baseAmount * quoteAmount)          (DAMM v1)
+//	totalLp = min(L_base, L_quote) at migrationSqrtPrice (DAMM v2)
+//
+// The creator liquid share takes the rounding remainder, matching the program.
+func GetMigrationLpDistribution(
+	config *dbc.PoolConfigAccount,
+	migrationQuoteThreshold uint64,
+) (types.MigrationLpDistribution, error) {
+	if err := ValidateMigrationPercentages(config); err != nil {
+		return types.MigrationLpDistribution{}, err
+	}
+
+	result := types.MigrationLpDistribution{
+		MigrationOption: types.MigrationOption(config.MigrationOption),
+		BaseAmount:      config.MigrationBaseThreshold,
+	}
+
+	result.MigrationFee = mulDivDown(migrationQuoteThreshold, uint64(config.MigrationFeePercentage), 100)
+	result.CreatorMigrationFee = mulDivDown(result.MigrationFee, uint64(config.CreatorMigrationFeePercentage), 100)
+	result.PartnerMigrationFee = result.MigrationFee - result.CreatorMigrationFee
+	result.QuoteAmount = migrationQuoteThreshold - result.MigrationFee
+
+	baseAmount := new(big.Int).SetUint64(result.BaseAmount)
+	quoteAmount := new(big.Int).SetUint64(result.QuoteAmount)
+
+	switch result.MigrationOption {
+	case types.MigrationOptionMET_DAMM:
+		result.TotalLp = new(big.Int).Sqrt(new(big.Int).Mul(baseAmount, quoteAmount))
+	case types.MigrationOptionMET_DAMM_V2:
+		sqrtPrice := config.MigrationSqrtPrice.BigInt()
+		liquidityFromBase, err := GetInitialLiquidityFromDeltaBase(baseAmount, constants.MaxSqrtPrice, sqrtPrice)
+		if err != nil {
+			return types.MigrationLpDistribution{}, fmt.Errorf("liquidity from base: %w", err)
+		}
+		liquidityFromQuote, err := GetInitialLiquidityFromDeltaQuote(quoteAmount, constants.MinSqrtPrice, sqrtPrice)
+		if err != nil {
+			return types.MigrationLpDistribution{}, fmt.Errorf("liquidity from quote: %w", err)
+		}
+		result.TotalLp = liquidityFromBase
+		if liquidityFromQuote.Cmp(liquidityFromBase) < 0 {
+			result.TotalLp = liquidityFromQuote
+		}
+	default:
+		return types.MigrationLpDistribution{}, fmt.Errorf("unsupported migration option: %d", config.MigrationOption)
+	}
+
+	percentOf := func(percentage uint8) *big.Int {
+		return new(big.Int).Quo(
+			new(big.Int).Mul(result.TotalLp, big.NewInt(int64(percentage))),
+			big.NewInt(100),
+		)
+	}
+
+	result.Partner = types.MigrationLpShare{
+		Liquid: percentOf(config.PartnerLpPercentage),
+		Locked: percentOf(config.PartnerLockedLpPercentage),
+	}
+	result.Creator = types.MigrationLpShare{
+		Locked: percentOf(config.CreatorLockedLpPercentage),
+	}
+	result.Creator.Liquid = new(big.Int).Sub(result.TotalLp, result.Partner.Liquid)
+	result.Creator.Liquid.Sub(result.Creator.Liquid, result.Partner.Locked)
+	result.Creator.Liquid.Sub(result.Creator.Liquid, result.Creator.Locked)
+
+	return result, nil
+}
+
+func mulDivDown(x, y, denominator uint64) uint64 {
+	return new(big.Int).Quo(
+		new(big.Int).Mul(new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)),
+		new(big.Int).SetUint64(denominator),
+	).Uint64()
+}
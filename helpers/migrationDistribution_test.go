@@ -0,0 +1,65 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMigrationLpDistribution(t *testing.T) {
+	config := &dbc.PoolConfigAccount{
+		MigrationOption:               uint8(types.MigrationOptionMET_DAMM),
+		PartnerLpPercentage:           10,
+		CreatorLpPercentage:           20,
+		PartnerLockedLpPercentage:     30,
+		CreatorLockedLpPercentage:     40,
+		MigrationFeePercentage:        10,
+		CreatorMigrationFeePercentage: 50,
+		MigrationBaseThreshold:        1_600_000,
+	}
+
+	t.Run("damm v1", func(t *testing.T) {
+		got, err := helpers.GetMigrationLpDistribution(config, 1_000_000)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100_000), got.MigrationFee)
+		assert.Equal(t, uint64(50_000), got.PartnerMigrationFee)
+		assert.Equal(t, uint64(50_000), got.CreatorMigrationFee)
+		assert.Equal(t, uint64(1_600_000), got.BaseAmount)
+		assert.Equal(t, uint64(900_000), got.QuoteAmount)
+		assert.Equal(t, "1200000", got.TotalLp.String())
+		assert.Equal(t, "120000", got.Partner.Liquid.String())
+		assert.Equal(t, "360000", got.Partner.Locked.String())
+		assert.Equal(t, "240000", got.Creator.Liquid.String())
+		assert.Equal(t, "480000", got.Creator.Locked.String())
+	})
+
+	t.Run("damm v2", func(t *testing.T) {
+		v2 := *config
+		v2.MigrationOption = uint8(types.MigrationOptionMET_DAMM_V2)
+		v2.MigrationSqrtPrice = helpers.MustBigIntToUint128(new(big.Int).Lsh(big.NewInt(1), 64))
+
+		got, err := helpers.GetMigrationLpDistribution(&v2, 1_000_003)
+		assert.NoError(t, err)
+		assert.Positive(t, got.TotalLp.Sign())
+
+		sum := new(big.Int).Add(got.Partner.Liquid, got.Partner.Locked)
+		sum.Add(sum, got.Creator.Liquid)
+		sum.Add(sum, got.Creator.Locked)
+		assert.Equal(t, got.TotalLp.String(), sum.String())
+	})
+
+	t.Run("invalid percentages", func(t *testing.T) {
+		invalid := *config
+		invalid.CreatorLockedLpPercentage = 41
+		_, err := helpers.GetMigrationLpDistribution(&invalid, 1_000_000)
+		assert.Error(t, err)
+
+		invalid = *config
+		invalid.MigrationFeePercentage = 51
+		assert.Error(t, helpers.ValidateMigrationPercentages(&invalid))
+	})
+}
@@ -0,0 +1,130 @@
+package helpers
+
+import (
+	"context"
+	"dbcGoSDK/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// KeypairSigner is a TransactionSigner over in memory keypairs, the first keypair pays the fees.
+type KeypairSigner struct {
+	keys  map[solana.PublicKey]*solana.PrivateKey
+	payer solana.PublicKey
+}
+
+func NewKeypairSigner(payer solana.PrivateKey, others ...solana.PrivateKey) *KeypairSigner {
+	signer := &KeypairSigner{
+		keys:  make(map[solana.PublicKey]*solana.PrivateKey, 1+len(others)),
+		payer: payer.PublicKey(),
+	}
+	for _, key := range append([]solana.PrivateKey{payer}, others...) {
+		k := key
+		signer.keys[k.PublicKey()] = &k
+	}
+	return signer
+}
+
+func (s *KeypairSigner) Payer() solana.PublicKey {
+	return s.payer
+}
+
+func (s *KeypairSigner) CanSign(key solana.PublicKey) bool {
+	_, ok := s.keys[key]
+	return ok
+}
+
+// Sign adds the signatures of the held keys that the transaction requires.
+func (s *KeypairSigner) Sign(_ context.Context, tx *solana.Transaction) error {
+	_, err := tx.PartialSign(func(key solana.PublicKey) *solana.PrivateKey {
+		return s.keys[key]
+	})
+	return err
+}
+
+// FileCheckpointStore is a MigrationKeeperCheckpointStore writing the checkpoint as JSON to a file.
+// The file is replaced atomically so a crash never leaves a partial checkpoint.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load reads the checkpoint, a missing file is an empty checkpoint.
+func (f *FileCheckpointStore) Load(_ context.Context) (types.MigrationKeeperCheckpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	checkpoint := types.MigrationKeeperCheckpoint{
+		Pools: make(map[solana.PublicKey]types.MigrationKeeperPoolState),
+	}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return types.MigrationKeeperCheckpoint{}, fmt.Errorf("cannot read checkpoint(%s): %w", f.path, err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return types.MigrationKeeperCheckpoint{}, fmt.Errorf("cannot decode checkpoint(%s): %w", f.path, err)
+	}
+	if checkpoint.Pools == nil {
+		checkpoint.Pools = make(map[solana.PublicKey]types.MigrationKeeperPoolState)
+	}
+	return checkpoint, nil
+}
+
+func (f *FileCheckpointStore) Save(_ context.Context, checkpoint types.MigrationKeeperCheckpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint(%s): %w", f.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write checkpoint(%s): %w", f.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write checkpoint(%s): %w", f.path, err)
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// GetKeeperBackoff gets the delay before the next attempt after attempts consecutive failures.
+//
+//	backoff = min(baseBackoff * 2^(attempts - 1), maxBackoff)
+func GetKeeperBackoff(attempts int, baseBackoff, maxBackoff time.Duration) time.Duration {
+	if attempts <= 0 {
+		return 0
+	}
+
+	backoff := baseBackoff
+	for i := 1; i < attempts; i++ {
+		if backoff >= maxBackoff/2 {
+			return maxBackoff
+		}
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
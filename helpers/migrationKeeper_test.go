@@ -0,0 +1,68 @@
+package helpers_test
+
+import (
+	"context"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeeperBackoff(t *testing.T) {
+	base, max := 5*time.Second, time.Minute
+
+	assert.Equal(t, time.Duration(0), helpers.GetKeeperBackoff(0, base, max))
+	assert.Equal(t, 5*time.Second, helpers.GetKeeperBackoff(1, base, max))
+	assert.Equal(t, 10*time.Second, helpers.GetKeeperBackoff(2, base, max))
+	assert.Equal(t, 40*time.Second, helpers.GetKeeperBackoff(4, base, max))
+	assert.Equal(t, time.Minute, helpers.GetKeeperBackoff(5, base, max))
+	assert.Equal(t, time.Minute, helpers.GetKeeperBackoff(100, base, max))
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store := helpers.NewFileCheckpointStore(filepath.Join(t.TempDir(), "keeper.json"))
+
+	checkpoint, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, checkpoint.Pools)
+
+	pool := solana.NewWallet().PublicKey()
+	checkpoint.LastSignature = solana.Signature{1, 2, 3}
+	checkpoint.Pools[pool] = types.MigrationKeeperPoolState{
+		Attempts:    2,
+		NextAttempt: time.Unix(1_700_000_000, 0).UTC(),
+		LastError:   "blockhash not found",
+	}
+	assert.NoError(t, store.Save(ctx, checkpoint))
+
+	got, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, got)
+}
+
+func TestKeypairSigner(t *testing.T) {
+	payer, creator := solana.NewWallet().PrivateKey, solana.NewWallet().PrivateKey
+	signer := helpers.NewKeypairSigner(payer, creator)
+
+	assert.Equal(t, payer.PublicKey(), signer.Payer())
+	assert.True(t, signer.CanSign(creator.PublicKey()))
+	assert.False(t, signer.CanSign(solana.NewWallet().PublicKey()))
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			system.NewTransferInstruction(1, creator.PublicKey(), payer.PublicKey()).Build(),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(payer.PublicKey()),
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signer.Sign(context.Background(), tx))
+	assert.NoError(t, tx.VerifySignatures())
+}
@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+)
+
+// GetRemainingMigrationSteps gets the steps left to fully migrate a pool, in execution order.
+// Each step is derived from the on chain state alone, so the steps can be recomputed after a partial failure.
+// Nothing is left to do before the curve is complete.
+func GetRemainingMigrationSteps(param types.MigrationStepsParam) ([]types.MigrationStep, error) {
+	pool, config := param.Pool, param.Config
+	if pool == nil || config == nil {
+		return nil, errors.New("pool and config cannot be nil")
+	}
+
+	progress := types.MigrationProgress(pool.MigrationProgress)
+	if progress > types.MigrationProgressCreatedPool {
+		return nil, fmt.Errorf("unknown migration progress(%d)", pool.MigrationProgress)
+	}
+
+	migrationOption := types.MigrationOption(config.MigrationOption)
+	if migrationOption != types.MigrationOptionMET_DAMM && migrationOption != types.MigrationOptionMET_DAMM_V2 {
+		return nil, fmt.Errorf("unknown migration option(%d)", config.MigrationOption)
+	}
+
+	var steps []types.MigrationStep
+	if progress == types.MigrationProgressPreBondingCurve {
+		return steps, nil
+	}
+
+	if progress == types.MigrationProgressPostBondingCurve {
+		steps = append(steps, types.MigrationStepCreateLocker)
+	}
+
+	if progress < types.MigrationProgressCreatedPool {
+		if !param.MigrationMetadataExists {
+			steps = append(steps, types.MigrationStepCreateMigrationMetadata)
+		}
+		steps = append(steps, types.MigrationStepMigrate)
+	}
+
+	if migrationOption == types.MigrationOptionMET_DAMM {
+		steps = append(steps, getDammV1LpSteps(param, progress)...)
+	}
+
+	if config.FixedTokenSupplyFlag == 1 && !param.Surplus.IsLeftoverWithdrawn &&
+		(progress < types.MigrationProgressCreatedPool || param.Surplus.LeftoverBaseAmount > 0) {
+		steps = append(steps, types.MigrationStepWithdrawLeftover)
+	}
+
+	if param.Surplus.PartnerSurplus > 0 && !param.Surplus.IsPartnerSurplusWithdrawn {
+		steps = append(steps, types.MigrationStepPartnerWithdrawSurplus)
+	}
+	if param.Surplus.CreatorSurplus > 0 && !param.Surplus.IsCreatorSurplusWithdrawn {
+		steps = append(steps, types.MigrationStepCreatorWithdrawSurplus)
+	}
+
+	// migration fee withdraw status, first bit is for partner, second bit is for creator
+	if config.MigrationFeePercentage > 0 {
+		if config.CreatorMigrationFeePercentage < 100 && pool.MigrationFeeWithdrawStatus&1 == 0 {
+			steps = append(steps, types.MigrationStepPartnerWithdrawMigrationFee)
+		}
+		if config.CreatorMigrationFeePercentage > 0 && pool.MigrationFeeWithdrawStatus&2 == 0 {
+			steps = append(steps, types.MigrationStepCreatorWithdrawMigrationFee)
+		}
+	}
+
+	return steps, nil
+}
+
+// getDammV1LpSteps gets the LP lock and claim steps of a DAMM V1 migration. Before the migration the
+// LP percentages of the config decide, after it the LP amounts recorded in the migration metadata.
+func getDammV1LpSteps(param types.MigrationStepsParam, progress types.MigrationProgress) []types.MigrationStep {
+	config, metadata := param.Config, param.DammV1MigrationMetadata
+
+	var steps []types.MigrationStep
+	if progress < types.MigrationProgressCreatedPool || metadata == nil {
+		if config.PartnerLockedLpPercentage > 0 {
+			steps = append(steps, types.MigrationStepLockPartnerLp)
+		}
+		if config.CreatorLockedLpPercentage > 0 {
+			steps = append(steps, types.MigrationStepLockCreatorLp)
+		}
+		if config.PartnerLpPercentage > 0 {
+			steps = append(steps, types.MigrationStepClaimPartnerLp)
+		}
+		if config.CreatorLpPercentage > 0 {
+			steps = append(steps, types.MigrationStepClaimCreatorLp)
+		}
+		return steps
+	}
+
+	if metadata.PartnerLockedLp > 0 && metadata.PartnerLockedStatus == 0 {
+		steps = append(steps, types.MigrationStepLockPartnerLp)
+	}
+	if metadata.CreatorLockedLp > 0 && metadata.CreatorLockedStatus == 0 {
+		steps = append(steps, types.MigrationStepLockCreatorLp)
+	}
+	if metadata.PartnerLp > 0 && metadata.PartnerClaimStatus == 0 {
+		steps = append(steps, types.MigrationStepClaimPartnerLp)
+	}
+	if metadata.CreatorLp > 0 && metadata.CreatorClaimStatus == 0 {
+		steps = append(steps, types.MigrationStepClaimCreatorLp)
+	}
+	return steps
+}
@@ -0,0 +1,96 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRemainingMigrationSteps(t *testing.T) {
+	config := &dbc.PoolConfigAccount{
+		MigrationOption:               uint8(types.MigrationOptionMET_DAMM),
+		PartnerLockedLpPercentage:     50,
+		CreatorLpPercentage:           50,
+		MigrationFeePercentage:        5,
+		CreatorMigrationFeePercentage: 50,
+	}
+
+	t.Run("curve not complete", func(t *testing.T) {
+		steps, err := helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{
+			Pool:   &dbc.VirtualPoolAccount{},
+			Config: config,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, steps)
+	})
+
+	t.Run("damm v1 with locked vesting", func(t *testing.T) {
+		steps, err := helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{
+			Pool:    &dbc.VirtualPoolAccount{MigrationProgress: uint8(types.MigrationProgressPostBondingCurve)},
+			Config:  config,
+			Surplus: types.PoolSurplusResult{PartnerSurplus: 10, CreatorSurplus: 0},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []types.MigrationStep{
+			types.MigrationStepCreateLocker,
+			types.MigrationStepCreateMigrationMetadata,
+			types.MigrationStepMigrate,
+			types.MigrationStepLockPartnerLp,
+			types.MigrationStepClaimCreatorLp,
+			types.MigrationStepPartnerWithdrawSurplus,
+			types.MigrationStepPartnerWithdrawMigrationFee,
+			types.MigrationStepCreatorWithdrawMigrationFee,
+		}, steps)
+	})
+
+	t.Run("partially migrated damm v1", func(t *testing.T) {
+		steps, err := helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{
+			Pool: &dbc.VirtualPoolAccount{
+				MigrationProgress:          uint8(types.MigrationProgressCreatedPool),
+				MigrationFeeWithdrawStatus: 1,
+			},
+			Config:                  config,
+			MigrationMetadataExists: true,
+			DammV1MigrationMetadata: &dbc.MeteoraDammMigrationMetadataAccount{
+				PartnerLockedLp:     100,
+				PartnerLockedStatus: 1,
+				CreatorLp:           100,
+			},
+			Surplus: types.PoolSurplusResult{PartnerSurplus: 10, IsPartnerSurplusWithdrawn: true},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []types.MigrationStep{
+			types.MigrationStepClaimCreatorLp,
+			types.MigrationStepCreatorWithdrawMigrationFee,
+		}, steps)
+	})
+
+	t.Run("damm v2 fixed supply", func(t *testing.T) {
+		steps, err := helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{
+			Pool: &dbc.VirtualPoolAccount{MigrationProgress: uint8(types.MigrationProgressCreatedPool)},
+			Config: &dbc.PoolConfigAccount{
+				MigrationOption:           uint8(types.MigrationOptionMET_DAMM_V2),
+				PartnerLockedLpPercentage: 100,
+				FixedTokenSupplyFlag:      1,
+			},
+			MigrationMetadataExists: true,
+			Surplus:                 types.PoolSurplusResult{LeftoverBaseAmount: 1_000},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []types.MigrationStep{types.MigrationStepWithdrawLeftover}, steps)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		_, err := helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{
+			Pool:   &dbc.VirtualPoolAccount{MigrationProgress: 4},
+			Config: config,
+		})
+		assert.Error(t, err)
+
+		_, err = helpers.GetRemainingMigrationSteps(types.MigrationStepsParam{Config: config})
+		assert.Error(t, err)
+	})
+}
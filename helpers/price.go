@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"math/big"
+)
+
+// GetPriceFromSqrtPrice gets the price from the sqrt price, it is the inverse of GetSqrtPriceFromPrice.
+//
+//	price = (sqrtPriceQ64 / 2^64)^2 * 10^(tokenADecimal - tokenBDecimal)
+func GetPriceFromSqrtPrice(
+	sqrtPrice *big.Int,
+	tokenADecimal, tokenBDecimal types.TokenDecimal,
+) *big.Rat {
+	price := new(big.Rat).SetFrac(
+		new(big.Int).Mul(sqrtPrice, sqrtPrice),
+		new(big.Int).Lsh(big.NewInt(1), 2*constants.RESOLUTION),
+	)
+	return price.Mul(price, Pow10Rat(int(tokenADecimal)-int(tokenBDecimal)))
+}
+
+// GetMarketCapFromSqrtPrice gets the market cap from the sqrt price and a base token supply in lamports.
+func GetMarketCapFromSqrtPrice(
+	sqrtPrice, supply *big.Int,
+	tokenBaseDecimal, tokenQuoteDecimal types.TokenDecimal,
+) *big.Rat {
+	return new(big.Rat).Mul(
+		GetPriceFromSqrtPrice(sqrtPrice, tokenBaseDecimal, tokenQuoteDecimal),
+		new(big.Rat).Mul(new(big.Rat).SetInt(supply), Pow10Rat(-int(tokenBaseDecimal))),
+	)
+}
+
+// GetBaseTokenTotalSupply gets the base token total supply of a pool in lamports.
+// Fixed supply configs store it directly, dynamic supply configs mint the swap, migration and vesting amounts.
+func GetBaseTokenTotalSupply(
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+) *big.Int {
+	if config.FixedTokenSupplyFlag == 1 {
+		if pool.IsMigrated == 1 {
+			return new(big.Int).SetUint64(config.PostMigrationTokenSupply)
+		}
+		return new(big.Int).SetUint64(config.PreMigrationTokenSupply)
+	}
+
+	totalSupply := new(big.Int).Add(
+		new(big.Int).SetUint64(config.SwapBaseAmount),
+		new(big.Int).SetUint64(config.MigrationBaseThreshold),
+	)
+	return totalSupply.Add(totalSupply, getTotalVestingAmountFromConfig(config.LockedVestingConfig))
+}
+
+// GetPoolPriceMetrics gets the current price, market cap, fully diluted value and migration market cap of a pool
+// in quote token units. Circulating supply is the total supply minus the pool base reserve and, once migrated,
+// minus the locked vesting. Before migration the base reserve still holds the vesting.
+func GetPoolPriceMetrics(
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	tokenBaseDecimal, tokenQuoteDecimal types.TokenDecimal,
+) (types.PoolPriceMetrics, error) {
+	if pool == nil || config == nil {
+		return types.PoolPriceMetrics{}, errors.New("pool and config are required")
+	}
+
+	totalSupply := GetBaseTokenTotalSupply(pool, config)
+
+	circulatingSupply := new(big.Int).Sub(totalSupply, new(big.Int).SetUint64(pool.BaseReserve))
+	if pool.IsMigrated == 1 {
+		// the vesting left the base reserve for the locker
+		circulatingSupply.Sub(circulatingSupply, getTotalVestingAmountFromConfig(config.LockedVestingConfig))
+	}
+	if circulatingSupply.Sign() < 0 {
+		circulatingSupply.SetInt64(0)
+	}
+
+	sqrtPrice := pool.SqrtPrice.BigInt()
+	price := GetPriceFromSqrtPrice(sqrtPrice, tokenBaseDecimal, tokenQuoteDecimal)
+
+	return types.PoolPriceMetrics{
+		Price:             price,
+		MarketCap:         GetMarketCapFromSqrtPrice(sqrtPrice, circulatingSupply, tokenBaseDecimal, tokenQuoteDecimal),
+		FullyDilutedValue: GetMarketCapFromSqrtPrice(sqrtPrice, totalSupply, tokenBaseDecimal, tokenQuoteDecimal),
+		MigrationMarketCap: GetMarketCapFromSqrtPrice(
+			config.MigrationSqrtPrice.BigInt(), totalSupply, tokenBaseDecimal, tokenQuoteDecimal,
+		),
+		CirculatingSupply: circulatingSupply,
+		TotalSupply:       totalSupply,
+	}, nil
+}
+
+// ConvertPoolPriceMetrics converts the quote denominated metrics into a reference currency
+// given the price of one quote token in that currency.
+func ConvertPoolPriceMetrics(
+	metrics types.PoolPriceMetrics,
+	quoteTokenPrice *big.Rat,
+) types.PoolPriceMetrics {
+	mul := func(r *big.Rat) *big.Rat {
+		if r == nil {
+			return nil
+		}
+		return new(big.Rat).Mul(r, quoteTokenPrice)
+	}
+
+	return types.PoolPriceMetrics{
+		Price:              mul(metrics.Price),
+		MarketCap:          mul(metrics.MarketCap),
+		FullyDilutedValue:  mul(metrics.FullyDilutedValue),
+		MigrationMarketCap: mul(metrics.MigrationMarketCap),
+		CirculatingSupply:  metrics.CirculatingSupply,
+		TotalSupply:        metrics.TotalSupply,
+	}
+}
+
+func getTotalVestingAmountFromConfig(lockedVesting dbc.LockedVestingConfig) *big.Int {
+	return GetTotalVestingAmount(dbc.LockedVestingParams{
+		AmountPerPeriod:                lockedVesting.AmountPerPeriod,
+		CliffDurationFromMigrationTime: lockedVesting.CliffDurationFromMigrationTime,
+		Frequency:                      lockedVesting.Frequency,
+		NumberOfPeriod:                 lockedVesting.NumberOfPeriod,
+		CliffUnlockAmount:              lockedVesting.CliffUnlockAmount,
+	})
+}
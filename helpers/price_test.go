@@ -0,0 +1,71 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPriceFromSqrtPrice(t *testing.T) {
+	for _, price := range []string{"1", "0.000000028", "123.456", "1/3"} {
+		p := helpers.MustParseRat(price)
+		sqrtPrice := helpers.GetSqrtPriceFromPriceRat(p, types.TokenDecimalSIX, types.TokenDecimalNINE)
+
+		// round trip is within one ulp of the sqrt price
+		back := helpers.GetPriceFromSqrtPrice(sqrtPrice, types.TokenDecimalSIX, types.TokenDecimalNINE)
+		next := helpers.GetPriceFromSqrtPrice(
+			new(big.Int).Add(sqrtPrice, big.NewInt(1)), types.TokenDecimalSIX, types.TokenDecimalNINE,
+		)
+		assert.True(t, back.Cmp(p) <= 0, price)
+		assert.True(t, next.Cmp(p) > 0, price)
+	}
+}
+
+func TestGetPoolPriceMetrics(t *testing.T) {
+	// price of 1 quote per base with equal decimals
+	sqrtPrice := helpers.GetSqrtPriceFromPriceRat(big.NewRat(1, 1), types.TokenDecimalNINE, types.TokenDecimalNINE)
+	migrationSqrtPrice := helpers.GetSqrtPriceFromPriceRat(big.NewRat(4, 1), types.TokenDecimalNINE, types.TokenDecimalNINE)
+
+	config := &dbc.PoolConfigAccount{
+		FixedTokenSupplyFlag:     1,
+		PreMigrationTokenSupply:  1_000_000_000,
+		PostMigrationTokenSupply: 900_000_000,
+		MigrationSqrtPrice:       helpers.MustBigIntToUint128(migrationSqrtPrice),
+		LockedVestingConfig: dbc.LockedVestingConfig{
+			AmountPerPeriod:   10_000_000,
+			NumberOfPeriod:    5,
+			CliffUnlockAmount: 50_000_000,
+		},
+	}
+	pool := &dbc.VirtualPoolAccount{
+		SqrtPrice:   helpers.MustBigIntToUint128(sqrtPrice),
+		BaseReserve: 600_000_000,
+	}
+
+	metrics, err := helpers.GetPoolPriceMetrics(pool, config, types.TokenDecimalNINE, types.TokenDecimalNINE)
+	assert.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(1_000_000_000), metrics.TotalSupply)
+	// the base reserve still holds the vesting before migration
+	assert.Equal(t, big.NewInt(400_000_000), metrics.CirculatingSupply)
+	assert.Equal(t, 0, metrics.Price.Cmp(big.NewRat(1, 1)))
+	assert.Equal(t, 0, metrics.MarketCap.Cmp(big.NewRat(4, 10)))
+	assert.Equal(t, 0, metrics.FullyDilutedValue.Cmp(big.NewRat(1, 1)))
+	assert.Equal(t, 0, metrics.MigrationMarketCap.Cmp(big.NewRat(4, 1)))
+
+	converted := helpers.ConvertPoolPriceMetrics(metrics, big.NewRat(150, 1))
+	assert.Equal(t, 0, converted.MarketCap.Cmp(big.NewRat(60, 1)))
+
+	pool.IsMigrated = 1
+	assert.Equal(t, big.NewInt(900_000_000), helpers.GetBaseTokenTotalSupply(pool, config))
+
+	// after migration the vesting sits in the locker
+	pool.BaseReserve = 0
+	metrics, err = helpers.GetPoolPriceMetrics(pool, config, types.TokenDecimalNINE, types.TokenDecimalNINE)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(800_000_000), metrics.CirculatingSupply)
+}
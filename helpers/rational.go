@@ -0,0 +1,252 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// ParseRat parses a decimal string ("0.5", "1e-3", "12345") or a fraction ("1/3") into an exact rational.
+func ParseRat(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("ParseRat:cannot parse %q as a decimal or rational", s)
+	}
+	return r, nil
+}
+
+// MustParseRat is like ParseRat but panics on error.
+func MustParseRat(s string) *big.Rat {
+	r, err := ParseRat(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RatFromFloat64 converts a float64 into a rational through its shortest decimal representation,
+// the same way `new Decimal(number)` does in the JS/TS SDK, so 0.1 becomes exactly 1/10.
+// NaN and infinite values have no rational representation and are rejected.
+func RatFromFloat64(f float64) (*big.Rat, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("cannot convert %v to a rational", f)
+	}
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return r, nil
+}
+
+// ratsFromFloat64 converts every float64 through RatFromFloat64, stopping at the first NaN or infinite value.
+func ratsFromFloat64(fs ...float64) ([]*big.Rat, error) {
+	rats := make([]*big.Rat, 0, len(fs))
+	for _, f := range fs {
+		r, err := RatFromFloat64(f)
+		if err != nil {
+			return nil, err
+		}
+		rats = append(rats, r)
+	}
+	return rats, nil
+}
+
+// RatFloor returns floor(r) for a non-negative rational.
+func RatFloor(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// RatCeil returns ceil(r) for a non-negative rational.
+func RatCeil(r *big.Rat) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+// Pow10Rat returns 10^n as a rational, n may be negative.
+func Pow10Rat(n int) *big.Rat {
+	if n >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+	}
+	return new(big.Rat).SetFrac(
+		big.NewInt(1),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-n)), nil),
+	)
+}
+
+// IntegerNthRoot returns floor(x^(1/n)) for a non-negative x using Newton's method on integers.
+func IntegerNthRoot(x *big.Int, n uint) (*big.Int, error) {
+	if x.Sign() < 0 {
+		return nil, errors.New("IntegerNthRoot:x must be non-negative")
+	}
+	if n == 0 {
+		return nil, errors.New("IntegerNthRoot:n must be greater than zero")
+	}
+	if n == 1 || x.Sign() == 0 {
+		return new(big.Int).Set(x), nil
+	}
+	if n == 2 {
+		return new(big.Int).Sqrt(x), nil
+	}
+
+	// initial guess 2^ceil(bitlen/n) is always >= the root
+	bn := big.NewInt(int64(n))
+	nMinusOne := big.NewInt(int64(n - 1))
+	y := new(big.Int).Lsh(big.NewInt(1), uint((x.BitLen()+int(n)-1)/int(n)))
+	for {
+		// y' = ((n-1)*y + x / y^(n-1)) / n
+		next := new(big.Int).Quo(x, new(big.Int).Exp(y, nMinusOne, nil))
+		next.Add(next, new(big.Int).Mul(nMinusOne, y))
+		next.Quo(next, bn)
+		if next.Cmp(y) >= 0 {
+			return y, nil
+		}
+		y = next
+	}
+}
+
+// RatSqrt returns floor(sqrt(r) * 2^precisionBits) / 2^precisionBits for a non-negative rational.
+func RatSqrt(r *big.Rat, precisionBits uint) *big.Rat {
+	scaled := new(big.Int).Quo(
+		new(big.Int).Lsh(r.Num(), 2*precisionBits),
+		r.Denom(),
+	)
+	return new(big.Rat).SetFrac(
+		new(big.Int).Sqrt(scaled),
+		new(big.Int).Lsh(big.NewInt(1), precisionBits),
+	)
+}
+
+// RatNthRoot returns floor(r^(1/n) * 2^precisionBits) / 2^precisionBits for a non-negative rational.
+func RatNthRoot(r *big.Rat, n, precisionBits uint) (*big.Rat, error) {
+	scaled := new(big.Int).Quo(
+		new(big.Int).Lsh(r.Num(), n*precisionBits),
+		r.Denom(),
+	)
+	root, err := IntegerNthRoot(scaled, n)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).SetFrac(root, new(big.Int).Lsh(big.NewInt(1), precisionBits)), nil
+}
+
+// GetSqrtPriceFromPriceRat gets the sqrt price from an exact price.
+//
+//	sqrtPriceQ64 = floor(sqrt(price * 10^tokenBDecimal / 10^tokenADecimal) * 2^64)
+func GetSqrtPriceFromPriceRat(
+	price *big.Rat,
+	tokenADecimal, tokenBDecimal types.TokenDecimal,
+) *big.Int {
+	adjustedPrice := new(big.Rat).Mul(
+		price, Pow10Rat(int(tokenBDecimal)-int(tokenADecimal)),
+	)
+
+	// floor(sqrt(floor(x))) == floor(sqrt(x)) for x >= 0
+	return new(big.Int).Sqrt(
+		RatFloor(new(big.Rat).Mul(
+			adjustedPrice,
+			new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 2*constants.RESOLUTION)),
+		)),
+	)
+}
+
+// GetSqrtPriceFromMarketCapRat gets the sqrt price from an exact market cap.
+func GetSqrtPriceFromMarketCapRat(
+	marketCap *big.Rat, totalSupply uint64,
+	tokenBaseDecimal, tokenQuoteDecimal types.TokenDecimal,
+) *big.Int {
+	return GetSqrtPriceFromPriceRat(
+		new(big.Rat).Quo(marketCap, new(big.Rat).SetUint64(totalSupply)),
+		tokenBaseDecimal,
+		tokenQuoteDecimal,
+	)
+}
+
+// ConvertToLamportsRat converts an exact human amount into lamports, rounding down.
+func ConvertToLamportsRat(amount *big.Rat, tokenDecimal types.TokenDecimal) *big.Int {
+	return RatFloor(new(big.Rat).Mul(amount, Pow10Rat(int(tokenDecimal))))
+}
+
+// ConvertFromLamportsRat converts lamports into an exact human amount.
+func ConvertFromLamportsRat(amount *big.Int, tokenDecimal types.TokenDecimal) *big.Rat {
+	return new(big.Rat).Mul(new(big.Rat).SetInt(amount), Pow10Rat(-int(tokenDecimal)))
+}
+
+// GetMigrationQuoteAmountFromMigrationQuoteThresholdRat is the exact counterpart of
+// GetMigrationQuoteAmountFromMigrationQuoteThreshold.
+func GetMigrationQuoteAmountFromMigrationQuoteThresholdRat(
+	migrationQuoteThreshold, migrationFeePercent *big.Rat,
+) *big.Rat {
+	// (migrationQuoteThreshold * (100 - feePercent)) / 100
+	return new(big.Rat).Quo(
+		new(big.Rat).Mul(
+			migrationQuoteThreshold,
+			new(big.Rat).Sub(big.NewRat(100, 1), migrationFeePercent),
+		),
+		big.NewRat(100, 1),
+	)
+}
+
+// GetMigrationQuoteThresholdFromMigrationQuoteAmountRat is the exact counterpart of
+// GetMigrationQuoteThresholdFromMigrationQuoteAmount.
+func GetMigrationQuoteThresholdFromMigrationQuoteAmountRat(
+	migrationQuoteAmount, migrationFeePercent *big.Rat,
+) (*big.Rat, error) {
+	denominator := new(big.Rat).Sub(big.NewRat(100, 1), migrationFeePercent)
+	if denominator.Sign() <= 0 {
+		return nil, fmt.Errorf("migration fee percentage (%s) must be less than 100", migrationFeePercent.FloatString(2))
+	}
+	return new(big.Rat).Quo(
+		new(big.Rat).Mul(migrationQuoteAmount, big.NewRat(100, 1)),
+		denominator,
+	), nil
+}
+
+// GetMigrationQuoteAmountRat is the exact counterpart of GetMigrationQuoteAmount.
+func GetMigrationQuoteAmountRat(
+	migrationMarketCap, percentageSupplyOnMigration *big.Rat,
+) *big.Rat {
+	// migrationMC * x / 100
+	return new(big.Rat).Quo(
+		new(big.Rat).Mul(migrationMarketCap, percentageSupplyOnMigration),
+		big.NewRat(100, 1),
+	)
+}
+
+// GetPercentageSupplyOnMigrationRat is the exact counterpart of GetPercentageSupplyOnMigration.
+// The square root is evaluated in 256-bit fixed point, so the result is reproducible across platforms.
+func GetPercentageSupplyOnMigrationRat(
+	initialMarketCap, migrationMarketCap *big.Rat,
+	lockedVesting dbc.LockedVestingParams,
+	totalLeftover, totalTokenSupply *big.Int,
+) (*big.Rat, error) {
+	// formula: x = sqrt(initialMC / migrationMC) * (100 - lockedVesting - leftover) / (1 + sqrt(initialMC / migrationMC))
+	if migrationMarketCap.Sign() <= 0 || totalTokenSupply.Sign() <= 0 {
+		return nil, errors.New("migrationMarketCap and totalTokenSupply must be greater than zero")
+	}
+
+	sqrtRatio := RatSqrt(new(big.Rat).Quo(initialMarketCap, migrationMarketCap), 256)
+
+	totalVestingAmount := GetTotalVestingAmount(lockedVesting)
+	vestingPercentage := new(big.Rat).SetFrac(
+		new(big.Int).Mul(totalVestingAmount, constants.HundredInBigInt),
+		totalTokenSupply,
+	)
+	leftoverPercentage := new(big.Rat).SetFrac(
+		new(big.Int).Mul(totalLeftover, constants.HundredInBigInt),
+		totalTokenSupply,
+	)
+
+	numerator := new(big.Rat).Mul(
+		sqrtRatio,
+		new(big.Rat).Sub(
+			big.NewRat(100, 1),
+			new(big.Rat).Add(vestingPercentage, leftoverPercentage),
+		),
+	)
+	return new(big.Rat).Quo(numerator, new(big.Rat).Add(big.NewRat(1, 1), sqrtRatio)), nil
+}
@@ -0,0 +1,134 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegerNthRoot(t *testing.T) {
+	for _, n := range []uint{2, 3, 4, 16} {
+		for _, x := range []string{
+			"0", "1", "15", "16", "17", "65535", "65536",
+			"340282366920938463463374607431768211455",
+			"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		} {
+			v, _ := new(big.Int).SetString(x, 10)
+			root, err := helpers.IntegerNthRoot(v, n)
+			assert.NoError(t, err)
+
+			// root^n <= x < (root+1)^n
+			bn := big.NewInt(int64(n))
+			assert.True(t, new(big.Int).Exp(root, bn, nil).Cmp(v) <= 0, "x=%s n=%d", x, n)
+			next := new(big.Int).Add(root, big.NewInt(1))
+			assert.True(t, new(big.Int).Exp(next, bn, nil).Cmp(v) > 0, "x=%s n=%d", x, n)
+		}
+	}
+
+	_, err := helpers.IntegerNthRoot(big.NewInt(-1), 2)
+	assert.Error(t, err)
+}
+
+func TestRatFromFloat64(t *testing.T) {
+	r, err := helpers.RatFromFloat64(0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 10), r)
+
+	r, err = helpers.RatFromFloat64(2.983257229832572)
+	assert.NoError(t, err)
+	assert.Equal(t, helpers.MustParseRat("2.983257229832572"), r)
+
+	assert.Equal(t, big.NewInt(100_000_000), helpers.ConvertToLamports(0.1, types.TokenDecimalNINE))
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = helpers.RatFromFloat64(f)
+		assert.Error(t, err, "f=%v", f)
+
+		_, err = helpers.ConvertToLamportsChecked(f, types.TokenDecimalNINE)
+		assert.Error(t, err, "f=%v", f)
+		assert.Panics(t, func() { helpers.ConvertToLamports(f, types.TokenDecimalNINE) }, "f=%v", f)
+	}
+
+	_, err = helpers.BuildCurveWithMarketCap(types.BuildCurveWithMarketCapParam{
+		InitialMarketCap:   math.NaN(),
+		MigrationMarketCap: 1000,
+	})
+	assert.Error(t, err)
+}
+
+func TestGetSqrtPriceFromPriceRat(t *testing.T) {
+	// sqrt(1) * 2^64
+	assert.Equal(t,
+		new(big.Int).Lsh(big.NewInt(1), 64),
+		helpers.GetSqrtPriceFromPriceRat(big.NewRat(1, 1), types.TokenDecimalNINE, types.TokenDecimalNINE),
+	)
+
+	// sqrt(4 * 10^3 / 10^6) * 2^64 = 2^64 * 2 / sqrt(1000)
+	sqrtPrice := helpers.GetSqrtPriceFromPriceRat(big.NewRat(4, 1), types.TokenDecimalNINE, types.TokenDecimalSIX)
+	expected := new(big.Int).Sqrt(new(big.Int).Quo(new(big.Int).Lsh(big.NewInt(4), 128), big.NewInt(1000)))
+	assert.Equal(t, expected, sqrtPrice)
+}
+
+func TestBuildCurveRat(t *testing.T) {
+	param := types.BuildCurveBaseParam{
+		TotalTokenSupply:  1_000_000_000,
+		MigrationOption:   types.MigrationOptionMET_DAMM_V2,
+		TokenBaseDecimal:  types.TokenDecimalSIX,
+		TokenQuoteDecimal: types.TokenDecimalNINE,
+		BaseFeeParams: types.BaseFeeParams{
+			BaseFeeMode: types.BaseFeeModeFeeSchedulerLinear,
+			FeeSchedulerParam: &types.FeeSchedulerParams{
+				StartingFeeBps: 100,
+				EndingFeeBps:   100,
+			},
+		},
+		DynamicFeeEnabled:         true,
+		ActivationType:            types.ActivationTypeSlot,
+		CollectFeeMode:            types.CollectFeeModeQuoteToken,
+		MigrationFeeOption:        types.MigrationFeeOptionFixedBps100,
+		TokenType:                 types.TokenTypeSPL,
+		PartnerLockedLpPercentage: 100,
+		Leftover:                  10_000,
+	}
+
+	t.Run("float and decimal string inputs build the same config", func(t *testing.T) {
+		fromFloat, err := helpers.BuildCurve(types.BuildCurveParam{
+			BuildCurveBaseParam:         param,
+			PercentageSupplyOnMigration: 2.983257229832572,
+			MigrationQuoteThreshold:     95.07640791476408,
+		})
+		assert.NoError(t, err)
+
+		fromRat, err := helpers.BuildCurveRat(types.BuildCurveRatParam{
+			BuildCurveBaseParam:         param,
+			PercentageSupplyOnMigration: helpers.MustParseRat("2.983257229832572"),
+			MigrationQuoteThreshold:     helpers.MustParseRat("95.07640791476408"),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, fromFloat, fromRat)
+		assert.Equal(t, uint8(types.MigrationOptionMET_DAMM_V2), fromRat.MigrationOption)
+	})
+
+	t.Run("market cap builder is reproducible", func(t *testing.T) {
+		build := func() any {
+			config, err := helpers.BuildCurveWithMarketCapRat(types.BuildCurveWithMarketCapRatParam{
+				BuildCurveBaseParam: param,
+				InitialMarketCap:    helpers.MustParseRat("23.5"),
+				MigrationMarketCap:  helpers.MustParseRat("405.882352941"),
+			})
+			assert.NoError(t, err)
+			return config
+		}
+		assert.Equal(t, build(), build())
+	})
+
+	t.Run("nil inputs are rejected", func(t *testing.T) {
+		_, err := helpers.BuildCurveRat(types.BuildCurveRatParam{BuildCurveBaseParam: param})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// GetRouterVenue gets where a virtual pool trades now: the DBC curve until it migrates,
+// then the DAMM pool of its config migration option.
+func GetRouterVenue(
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+) (types.RouterVenue, error) {
+	if virtualPool == nil || config == nil {
+		return 0, errors.New("pool and config cannot be nil")
+	}
+
+	if virtualPool.IsMigrated == 0 {
+		return types.RouterVenueDBC, nil
+	}
+
+	switch types.MigrationOption(config.MigrationOption) {
+	case types.MigrationOptionMET_DAMM:
+		return types.RouterVenueDammV1, nil
+	case types.MigrationOptionMET_DAMM_V2:
+		return types.RouterVenueDammV2, nil
+	}
+	return 0, fmt.Errorf("invalid migrationOption(%d)", config.MigrationOption)
+}
+
+// GetRouterSwapMints gets the input and output mints of a swap side, a buy spends the quote mint for the base mint.
+func GetRouterSwapMints(
+	baseMint, quoteMint solana.PublicKey,
+	side types.SwapSide,
+) (inputMint, outputMint solana.PublicKey) {
+	if side == types.SwapSideSell {
+		return baseMint, quoteMint
+	}
+	return quoteMint, baseMint
+}
+
+// IsDammV2SwapAToB tells whether a swap side trades token A for token B on a DAMM V2 pool,
+// the base mint can be either token of the pool.
+func IsDammV2SwapAToB(tokenAMint, baseMint solana.PublicKey, side types.SwapSide) bool {
+	sellsBase := side == types.SwapSideSell
+	if tokenAMint.Equals(baseMint) {
+		return sellsBase
+	}
+	return !sellsBase
+}
+
+// GetDammV1ProtocolTokenFee gets the protocol fee account of the input token of a DAMM V1 swap.
+func GetDammV1ProtocolTokenFee(pool *dammv1.PoolAccount, inputMint solana.PublicKey) solana.PublicKey {
+	if inputMint.Equals(pool.TokenAMint) {
+		return pool.ProtocolTokenAFee
+	}
+	return pool.ProtocolTokenBFee
+}
@@ -0,0 +1,81 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRouterVenue(t *testing.T) {
+	tests := []struct {
+		name            string
+		isMigrated      uint8
+		migrationOption types.MigrationOption
+		want            types.RouterVenue
+	}{
+		{"curve before migration to damm v1", 0, types.MigrationOptionMET_DAMM, types.RouterVenueDBC},
+		{"curve before migration to damm v2", 0, types.MigrationOptionMET_DAMM_V2, types.RouterVenueDBC},
+		{"migrated to damm v1", 1, types.MigrationOptionMET_DAMM, types.RouterVenueDammV1},
+		{"migrated to damm v2", 1, types.MigrationOptionMET_DAMM_V2, types.RouterVenueDammV2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := helpers.GetRouterVenue(
+				&dbc.VirtualPoolAccount{IsMigrated: tt.isMigrated},
+				&dbc.PoolConfigAccount{MigrationOption: uint8(tt.migrationOption)},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := helpers.GetRouterVenue(&dbc.VirtualPoolAccount{IsMigrated: 1}, &dbc.PoolConfigAccount{MigrationOption: 2})
+	assert.Error(t, err)
+	_, err = helpers.GetRouterVenue(nil, &dbc.PoolConfigAccount{})
+	assert.Error(t, err)
+}
+
+func TestGetRouterSwapMints(t *testing.T) {
+	baseMint, quoteMint := solana.NewWallet().PublicKey(), solana.WrappedSol
+
+	inputMint, outputMint := helpers.GetRouterSwapMints(baseMint, quoteMint, types.SwapSideBuy)
+	assert.Equal(t, quoteMint, inputMint)
+	assert.Equal(t, baseMint, outputMint)
+
+	inputMint, outputMint = helpers.GetRouterSwapMints(baseMint, quoteMint, types.SwapSideSell)
+	assert.Equal(t, baseMint, inputMint)
+	assert.Equal(t, quoteMint, outputMint)
+}
+
+func TestIsDammV2SwapAToB(t *testing.T) {
+	baseMint, quoteMint := solana.NewWallet().PublicKey(), solana.WrappedSol
+
+	// base mint is token A
+	assert.True(t, helpers.IsDammV2SwapAToB(baseMint, baseMint, types.SwapSideSell))
+	assert.False(t, helpers.IsDammV2SwapAToB(baseMint, baseMint, types.SwapSideBuy))
+
+	// base mint is token B
+	assert.False(t, helpers.IsDammV2SwapAToB(quoteMint, baseMint, types.SwapSideSell))
+	assert.True(t, helpers.IsDammV2SwapAToB(quoteMint, baseMint, types.SwapSideBuy))
+}
+
+func TestGetDammV1ProtocolTokenFee(t *testing.T) {
+	pool := &dammv1.PoolAccount{
+		TokenAMint:        solana.NewWallet().PublicKey(),
+		TokenBMint:        solana.WrappedSol,
+		ProtocolTokenAFee: solana.NewWallet().PublicKey(),
+		ProtocolTokenBFee: solana.NewWallet().PublicKey(),
+	}
+
+	// selling the base token A pays the protocol fee in token A
+	inputMint, _ := helpers.GetRouterSwapMints(pool.TokenAMint, pool.TokenBMint, types.SwapSideSell)
+	assert.Equal(t, pool.ProtocolTokenAFee, helpers.GetDammV1ProtocolTokenFee(pool, inputMint))
+
+	inputMint, _ = helpers.GetRouterSwapMints(pool.TokenAMint, pool.TokenBMint, types.SwapSideBuy)
+	assert.Equal(t, pool.ProtocolTokenBFee, helpers.GetDammV1ProtocolTokenFee(pool, inputMint))
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"math/big"
+)
+
+// GetPoolSurplus gets the surplus each party can withdraw once the curve is complete,
+// and the base token leftover the leftover receiver can withdraw after migration of a fixed supply pool.
+//
+//	totalSurplus = quoteReserve - migrationQuoteThreshold
+//	partnerAndCreatorSurplus = totalSurplus * PartnerSurplusShare / 100
+//	creatorSurplus = partnerAndCreatorSurplus * creatorTradingFeePercentage / 100
+//	partnerSurplus = partnerAndCreatorSurplus - creatorSurplus
+//	protocolSurplus = totalSurplus - partnerAndCreatorSurplus
+//	leftover = baseVaultAmount - protocolBaseFee - partnerBaseFee - creatorBaseFee
+func GetPoolSurplus(
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	baseVaultAmount uint64,
+) (types.PoolSurplusResult, error) {
+	if pool == nil || config == nil {
+		return types.PoolSurplusResult{}, errors.New("pool and config cannot be nil")
+	}
+
+	result := types.PoolSurplusResult{
+		IsPartnerSurplusWithdrawn:  pool.IsPartnerWithdrawSurplus == 1,
+		IsCreatorSurplusWithdrawn:  pool.IsCreatorWithdrawSurplus == 1,
+		IsProtocolSurplusWithdrawn: pool.IsProtocolWithdrawSurplus == 1,
+		IsLeftoverWithdrawn:        pool.IsWithdrawLeftover == 1,
+	}
+
+	if pool.QuoteReserve > config.MigrationQuoteThreshold {
+		result.TotalSurplus = pool.QuoteReserve - config.MigrationQuoteThreshold
+
+		partnerAndCreatorSurplus := new(big.Int).Quo(
+			new(big.Int).Mul(new(big.Int).SetUint64(result.TotalSurplus), big.NewInt(constants.PartnerSurplusShare)),
+			big.NewInt(100),
+		).Uint64()
+
+		result.CreatorSurplus = new(big.Int).Quo(
+			new(big.Int).Mul(
+				new(big.Int).SetUint64(partnerAndCreatorSurplus),
+				new(big.Int).SetUint64(uint64(config.CreatorTradingFeePercentage)),
+			),
+			big.NewInt(100),
+		).Uint64()
+		result.PartnerSurplus = partnerAndCreatorSurplus - result.CreatorSurplus
+		result.ProtocolSurplus = result.TotalSurplus - partnerAndCreatorSurplus
+	}
+
+	if config.FixedTokenSupplyFlag == 1 && pool.IsMigrated == 1 {
+		baseFee := new(big.Int).SetUint64(pool.ProtocolBaseFee)
+		baseFee.Add(baseFee, new(big.Int).SetUint64(pool.PartnerBaseFee))
+		baseFee.Add(baseFee, new(big.Int).SetUint64(pool.CreatorBaseFee))
+
+		if leftover := new(big.Int).Sub(new(big.Int).SetUint64(baseVaultAmount), baseFee); leftover.Sign() > 0 {
+			result.LeftoverBaseAmount = leftover.Uint64()
+		}
+	}
+
+	return result, nil
+}
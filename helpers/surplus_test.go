@@ -0,0 +1,54 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPoolSurplus(t *testing.T) {
+	config := &dbc.PoolConfigAccount{
+		MigrationQuoteThreshold:     1_000_000,
+		CreatorTradingFeePercentage: 25,
+		FixedTokenSupplyFlag:        1,
+	}
+	pool := &dbc.VirtualPoolAccount{
+		QuoteReserve:             1_100_001,
+		IsMigrated:               1,
+		IsPartnerWithdrawSurplus: 1,
+		ProtocolBaseFee:          10,
+		PartnerBaseFee:           20,
+		CreatorBaseFee:           30,
+	}
+
+	got, err := helpers.GetPoolSurplus(pool, config, 5_060)
+	assert.NoError(t, err)
+	assert.Equal(t, types.PoolSurplusResult{
+		TotalSurplus:              100_001,
+		PartnerSurplus:            60_000,
+		CreatorSurplus:            20_000,
+		ProtocolSurplus:           20_001,
+		LeftoverBaseAmount:        5_000,
+		IsPartnerSurplusWithdrawn: true,
+	}, got)
+
+	t.Run("no surplus before the threshold", func(t *testing.T) {
+		got, err := helpers.GetPoolSurplus(&dbc.VirtualPoolAccount{QuoteReserve: 999_999}, config, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, types.PoolSurplusResult{}, got)
+	})
+
+	t.Run("no leftover for dynamic supply", func(t *testing.T) {
+		dynamicSupply := *config
+		dynamicSupply.FixedTokenSupplyFlag = 0
+		got, err := helpers.GetPoolSurplus(pool, &dynamicSupply, 5_060)
+		assert.NoError(t, err)
+		assert.Zero(t, got.LeftoverBaseAmount)
+	})
+
+	_, err = helpers.GetPoolSurplus(nil, config, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ValidateSwapPool checks the pool can still be swapped on at currentPoint.
+func ValidateSwapPool(
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	currentPoint *big.Int,
+) error {
+	if pool == nil || config == nil || currentPoint == nil {
+		return errors.New("pool, config and currentPoint cannot be nil")
+	}
+
+	if pool.IsMigrated == 1 {
+		return types.ErrPoolMigrated
+	}
+	if pool.QuoteReserve >= config.MigrationQuoteThreshold {
+		return fmt.Errorf("%w: quote reserve(%d) reached migration quote threshold(%d)",
+			types.ErrPoolCurveComplete, pool.QuoteReserve, config.MigrationQuoteThreshold)
+	}
+	if currentPoint.Cmp(new(big.Int).SetUint64(pool.ActivationPoint)) < 0 {
+		return fmt.Errorf("%w: current point(%s) is before activation point(%d)",
+			types.ErrPoolNotActivated, currentPoint, pool.ActivationPoint)
+	}
+
+	return nil
+}
+
+// ValidateMinimumAmountOut checks minimumAmountOut is attainable with the quoted output amount.
+func ValidateMinimumAmountOut(quotedAmountOut, minimumAmountOut uint64) error {
+	if minimumAmountOut > quotedAmountOut {
+		return fmt.Errorf("%w: minimum amount out(%d) is greater than quoted amount out(%d)",
+			types.ErrSlippageExceeded, minimumAmountOut, quotedAmountOut)
+	}
+	return nil
+}
+
+// ValidateMaximumAmountIn checks maximumAmountIn covers the quoted input amount.
+func ValidateMaximumAmountIn(quotedAmountIn, maximumAmountIn uint64) error {
+	if quotedAmountIn > maximumAmountIn {
+		return fmt.Errorf("%w: quoted amount in(%d) is greater than maximum amount in(%d)",
+			types.ErrSlippageExceeded, quotedAmountIn, maximumAmountIn)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSwapPool(t *testing.T) {
+	config := &dbc.PoolConfigAccount{MigrationQuoteThreshold: 1_000}
+	pool := &dbc.VirtualPoolAccount{QuoteReserve: 500, ActivationPoint: 100}
+
+	assert.NoError(t, helpers.ValidateSwapPool(pool, config, big.NewInt(100)))
+	assert.ErrorIs(t, helpers.ValidateSwapPool(pool, config, big.NewInt(99)), types.ErrPoolNotActivated)
+
+	complete := *pool
+	complete.QuoteReserve = 1_000
+	assert.ErrorIs(t, helpers.ValidateSwapPool(&complete, config, big.NewInt(100)), types.ErrPoolCurveComplete)
+
+	migrated := *pool
+	migrated.IsMigrated = 1
+	assert.ErrorIs(t, helpers.ValidateSwapPool(&migrated, config, big.NewInt(100)), types.ErrPoolMigrated)
+}
+
+func TestValidateSwapSlippage(t *testing.T) {
+	assert.NoError(t, helpers.ValidateMinimumAmountOut(100, 100))
+	assert.ErrorIs(t, helpers.ValidateMinimumAmountOut(99, 100), types.ErrSlippageExceeded)
+
+	assert.NoError(t, helpers.ValidateMaximumAmountIn(100, 100))
+	assert.ErrorIs(t, helpers.ValidateMaximumAmountIn(101, 100), types.ErrSlippageExceeded)
+}
+
+func TestInsufficientBalanceError(t *testing.T) {
+	var err error = &types.InsufficientBalanceError{Mint: solana.WrappedSol, Required: 10, Available: 5}
+
+	assert.ErrorIs(t, err, types.ErrInsufficientBalance)
+
+	var balanceErr *types.InsufficientBalanceError
+	assert.True(t, errors.As(err, &balanceErr))
+	assert.Equal(t, uint64(5), balanceErr.Available)
+}
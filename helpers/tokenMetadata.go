@@ -0,0 +1,266 @@
+package helpers
+
+import (
+	"context"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/types"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	metaplexKeyMetadataV1 = 4
+
+	extensionTypeMetadataPointer = 18
+	extensionTypeTokenMetadata   = 19
+	metadataPointerLength        = 64
+)
+
+// DecodeMetaplexMetadata decodes a Metaplex metadata account. Name, symbol and URI are stored padded
+// with zero bytes, the padding is trimmed.
+func DecodeMetaplexMetadata(data []byte) (*types.TokenMetadata, error) {
+	r := borshReader{dec: bin.NewBorshDecoder(data)}
+
+	if key := r.u8(); r.err == nil && key != metaplexKeyMetadataV1 {
+		return nil, fmt.Errorf("account key(%d) is not a metadata account", key)
+	}
+
+	metadata := &types.TokenMetadata{
+		Source:          types.TokenMetadataSourceMetaplex,
+		UpdateAuthority: r.publicKey(),
+		Mint:            r.publicKey(),
+		Name:            trimPadding(r.string()),
+		Symbol:          trimPadding(r.string()),
+		URI:             trimPadding(r.string()),
+	}
+	metadata.SellerFeeBasisPoints = r.u16()
+
+	if r.option() {
+		creators := r.u32()
+		if r.err == nil && int(creators) > r.dec.Remaining()/34 {
+			return nil, fmt.Errorf("invalid creator count(%d)", creators)
+		}
+		metadata.Creators = make([]types.TokenMetadataCreator, 0, creators)
+		for range creators {
+			metadata.Creators = append(metadata.Creators, types.TokenMetadataCreator{
+				Address:  r.publicKey(),
+				Verified: r.bool(),
+				Share:    r.u8(),
+			})
+		}
+	}
+
+	_ = r.bool() // primary sale happened
+	metadata.IsMutable = r.bool()
+
+	if r.err != nil {
+		return nil, fmt.Errorf("cannot decode metaplex metadata: %w", r.err)
+	}
+	return metadata, nil
+}
+
+// GetTokenMetadataFromMintData reads the Token-2022 metadata pointer and token metadata extensions from
+// mint account data. The metadata is nil when the mint does not hold it, the pointer is zero when unset.
+func GetTokenMetadataFromMintData(data []byte) (*types.TokenMetadata, solana.PublicKey, error) {
+	var pointer solana.PublicKey
+
+	extension, err := getMintExtension(data, extensionTypeMetadataPointer)
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	if extension != nil {
+		if len(extension) != metadataPointerLength {
+			return nil, solana.PublicKey{}, fmt.Errorf("unexpected MetadataPointer length(%d)", len(extension))
+		}
+		// authority(32) then metadata address(32)
+		pointer = solana.PublicKeyFromBytes(extension[32:64])
+	}
+
+	extension, err = getMintExtension(data, extensionTypeTokenMetadata)
+	if err != nil || extension == nil {
+		return nil, pointer, err
+	}
+
+	r := borshReader{dec: bin.NewBorshDecoder(extension)}
+	metadata := &types.TokenMetadata{
+		Source:          types.TokenMetadataSourceToken2022,
+		UpdateAuthority: r.publicKey(),
+		Mint:            r.publicKey(),
+		Name:            r.string(),
+		Symbol:          r.string(),
+		URI:             r.string(),
+	}
+	metadata.IsMutable = !metadata.UpdateAuthority.IsZero()
+
+	fields := r.u32()
+	if r.err == nil && int(fields) > r.dec.Remaining()/8 {
+		return nil, pointer, fmt.Errorf("invalid additional metadata count(%d)", fields)
+	}
+	for range fields {
+		metadata.AdditionalMetadata = append(metadata.AdditionalMetadata, [2]string{r.string(), r.string()})
+	}
+
+	if r.err != nil {
+		return nil, pointer, fmt.Errorf("cannot decode token metadata extension: %w", r.err)
+	}
+	return metadata, pointer, nil
+}
+
+// GetTokenMetadata fetches the metadata of a mint. A Token-2022 mint is read through its metadata
+// pointer, other mints and Token-2022 mints without a pointer through the Metaplex metadata account.
+func GetTokenMetadata(
+	ctx context.Context,
+	conn *rpc.Client,
+	mint solana.PublicKey,
+	commitment rpc.CommitmentType,
+) (*types.TokenMetadata, error) {
+
+	account, err := conn.GetAccountInfoWithOpts(ctx, mint, &rpc.GetAccountInfoOpts{Commitment: commitment})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch mint(%s): %w", mint, err)
+	}
+
+	metadataAddress := DeriveMintMetadata(mint)
+	if account.Value.Owner.Equals(solana.Token2022ProgramID) {
+		metadata, pointer, err := GetTokenMetadataFromMintData(account.Value.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("mint(%s): %w", mint, err)
+		}
+
+		switch {
+		case pointer.Equals(mint) && metadata != nil:
+			metadata.Address = mint
+			return metadata, nil
+		case pointer.Equals(mint):
+			return nil, fmt.Errorf("mint(%s) points to itself but has no token metadata", mint)
+		case !pointer.IsZero():
+			metadataAddress = pointer
+		}
+	}
+
+	account, err = conn.GetAccountInfoWithOpts(ctx, metadataAddress, &rpc.GetAccountInfoOpts{Commitment: commitment})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch metadata(%s) of mint(%s): %w", metadataAddress, mint, err)
+	}
+	if !account.Value.Owner.Equals(constants.MetaplexProgramId) {
+		return nil, fmt.Errorf("metadata(%s) owner(%s) is not supported", metadataAddress, account.Value.Owner)
+	}
+
+	metadata, err := DecodeMetaplexMetadata(account.Value.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	if !metadata.Mint.Equals(mint) {
+		return nil, fmt.Errorf("metadata(%s) belongs to mint(%s)", metadataAddress, metadata.Mint)
+	}
+	metadata.Address = metadataAddress
+	return metadata, nil
+}
+
+// ValidateTokenUpdateAuthority checks the metadata update authority against the tokenUpdateAuthority
+// option of the pool config: immutable metadata cannot be updated, otherwise the creator or the partner
+// (fee claimer) is the update authority.
+func ValidateTokenUpdateAuthority(
+	metadata *types.TokenMetadata,
+	tokenUpdateAuthority uint8,
+	creator, partner solana.PublicKey,
+) error {
+
+	var expected solana.PublicKey
+	switch types.TokenUpdateAuthorityOption(tokenUpdateAuthority) {
+	case types.TokenUpdateAuthorityOptionImmutable:
+		if metadata.IsMutable {
+			return fmt.Errorf("metadata is mutable by(%s), config expects immutable metadata", metadata.UpdateAuthority)
+		}
+		return nil
+	case types.TokenUpdateAuthorityOptionCreatorUpdateAuthority,
+		types.TokenUpdateAuthorityOptionCreatorUpdateAndMintAuthority:
+		expected = creator
+	case types.TokenUpdateAuthorityOptionPartnerUpdateAuthority,
+		types.TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority:
+		expected = partner
+	default:
+		return fmt.Errorf("invalid tokenUpdateAuthority(%d)", tokenUpdateAuthority)
+	}
+
+	if !metadata.IsMutable {
+		return fmt.Errorf("metadata is immutable, config expects update authority(%s)", expected)
+	}
+	if !metadata.UpdateAuthority.Equals(expected) {
+		return fmt.Errorf("metadata update authority(%s) is not the expected(%s)", metadata.UpdateAuthority, expected)
+	}
+	return nil
+}
+
+func trimPadding(s string) string {
+	return strings.TrimRight(s, "\x00")
+}
+
+// borshReader keeps the first decoding error so a layout can be read field by field.
+type borshReader struct {
+	dec *bin.Decoder
+	err error
+}
+
+func (r *borshReader) publicKey() solana.PublicKey {
+	if r.err != nil {
+		return solana.PublicKey{}
+	}
+	var b []byte
+	b, r.err = r.dec.ReadNBytes(solana.PublicKeyLength)
+	if r.err != nil {
+		return solana.PublicKey{}
+	}
+	return solana.PublicKeyFromBytes(b)
+}
+
+// string reads a borsh string, its length is a u32 unlike bin.Decoder.ReadRustString.
+func (r *borshReader) string() string {
+	length := r.u32()
+	if r.err != nil {
+		return ""
+	}
+	var b []byte
+	b, r.err = r.dec.ReadNBytes(int(length))
+	return string(b)
+}
+
+func (r *borshReader) u8() (out uint8) {
+	if r.err == nil {
+		out, r.err = r.dec.ReadUint8()
+	}
+	return out
+}
+
+func (r *borshReader) u16() (out uint16) {
+	if r.err == nil {
+		out, r.err = r.dec.ReadUint16(binary.LittleEndian)
+	}
+	return out
+}
+
+func (r *borshReader) u32() (out uint32) {
+	if r.err == nil {
+		out, r.err = r.dec.ReadUint32(binary.LittleEndian)
+	}
+	return out
+}
+
+func (r *borshReader) bool() (out bool) {
+	if r.err == nil {
+		out, r.err = r.dec.ReadBool()
+	}
+	return out
+}
+
+func (r *borshReader) option() (out bool) {
+	if r.err == nil {
+		out, r.err = r.dec.ReadOption()
+	}
+	return out
+}
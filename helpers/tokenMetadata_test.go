@@ -0,0 +1,131 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func appendRustString(data []byte, s string) []byte {
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(s)))
+	return append(data, s...)
+}
+
+func TestDecodeMetaplexMetadata(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	creator := solana.NewWallet().PublicKey()
+
+	data := []byte{4}
+	data = append(data, authority.Bytes()...)
+	data = append(data, mint.Bytes()...)
+	// fixed size fields padded with zero bytes
+	data = appendRustString(data, "Token"+string(make([]byte, 27)))
+	data = appendRustString(data, "TKN"+string(make([]byte, 7)))
+	data = appendRustString(data, "https://example.com/token.json")
+	data = binary.LittleEndian.AppendUint16(data, 500)
+	data = append(data, 1) // creators
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = append(data, creator.Bytes()...)
+	data = append(data, 1, 100)
+	data = append(data, 0, 1) // primary sale happened, is mutable
+	data = append(data, make([]byte, 16)...)
+
+	metadata, err := helpers.DecodeMetaplexMetadata(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.TokenMetadata{
+		Source:               types.TokenMetadataSourceMetaplex,
+		Mint:                 mint,
+		Name:                 "Token",
+		Symbol:               "TKN",
+		URI:                  "https://example.com/token.json",
+		UpdateAuthority:      authority,
+		IsMutable:            true,
+		SellerFeeBasisPoints: 500,
+		Creators:             []types.TokenMetadataCreator{{Address: creator, Verified: true, Share: 100}},
+	}, metadata)
+
+	// not a metadata account
+	_, err = helpers.DecodeMetaplexMetadata(append([]byte{6}, data[1:]...))
+	assert.Error(t, err)
+
+	// truncated
+	_, err = helpers.DecodeMetaplexMetadata(data[:80])
+	assert.Error(t, err)
+}
+
+func TestGetTokenMetadataFromMintData(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	data := make([]byte, 166)
+	data[165] = 1
+
+	// metadata pointer to the mint itself
+	data = binary.LittleEndian.AppendUint16(data, 18)
+	data = binary.LittleEndian.AppendUint16(data, 64)
+	data = append(data, authority.Bytes()...)
+	data = append(data, mint.Bytes()...)
+
+	var extension []byte
+	extension = append(extension, authority.Bytes()...)
+	extension = append(extension, mint.Bytes()...)
+	extension = appendRustString(extension, "Token")
+	extension = appendRustString(extension, "TKN")
+	extension = appendRustString(extension, "https://example.com/token.json")
+	extension = binary.LittleEndian.AppendUint32(extension, 1)
+	extension = appendRustString(extension, "twitter")
+	extension = appendRustString(extension, "@token")
+
+	data = binary.LittleEndian.AppendUint16(data, 19)
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(extension)))
+	data = append(data, extension...)
+
+	metadata, pointer, err := helpers.GetTokenMetadataFromMintData(data)
+	assert.NoError(t, err)
+	assert.Equal(t, mint, pointer)
+	assert.Equal(t, &types.TokenMetadata{
+		Source:             types.TokenMetadataSourceToken2022,
+		Mint:               mint,
+		Name:               "Token",
+		Symbol:             "TKN",
+		URI:                "https://example.com/token.json",
+		UpdateAuthority:    authority,
+		IsMutable:          true,
+		AdditionalMetadata: [][2]string{{"twitter", "@token"}},
+	}, metadata)
+
+	// plain SPL mint
+	metadata, pointer, err = helpers.GetTokenMetadataFromMintData(make([]byte, 82))
+	assert.NoError(t, err)
+	assert.Nil(t, metadata)
+	assert.True(t, pointer.IsZero())
+}
+
+func TestValidateTokenUpdateAuthority(t *testing.T) {
+	creator := solana.NewWallet().PublicKey()
+	partner := solana.NewWallet().PublicKey()
+
+	mutable := &types.TokenMetadata{UpdateAuthority: creator, IsMutable: true}
+	immutable := &types.TokenMetadata{}
+
+	assert.NoError(t, helpers.ValidateTokenUpdateAuthority(
+		mutable, uint8(types.TokenUpdateAuthorityOptionCreatorUpdateAuthority), creator, partner,
+	))
+	assert.NoError(t, helpers.ValidateTokenUpdateAuthority(
+		immutable, uint8(types.TokenUpdateAuthorityOptionImmutable), creator, partner,
+	))
+	assert.Error(t, helpers.ValidateTokenUpdateAuthority(
+		mutable, uint8(types.TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority), creator, partner,
+	))
+	assert.Error(t, helpers.ValidateTokenUpdateAuthority(
+		mutable, uint8(types.TokenUpdateAuthorityOptionImmutable), creator, partner,
+	))
+	assert.Error(t, helpers.ValidateTokenUpdateAuthority(
+		immutable, uint8(types.TokenUpdateAuthorityOptionCreatorUpdateAuthority), creator, partner,
+	))
+}
@@ -0,0 +1,150 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// GetTransactionSize gets the serialized size of a transaction of ixns paid by payer once it is signed.
+func GetTransactionSize(ixns []solana.Instruction, payer solana.PublicKey) (int, error) {
+	tx, err := solana.NewTransaction(ixns, solana.Hash{}, solana.TransactionPayer(payer))
+	if err != nil {
+		return 0, err
+	}
+
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	signatures := int(tx.Message.Header.NumRequiredSignatures)
+	var signatureCount []byte
+	bin.EncodeCompactU16Length(&signatureCount, signatures)
+
+	return len(signatureCount) + signatures*solana.SignatureLength + len(message), nil
+}
+
+// PackTransactions packs groups into as few transactions as fit in MaxTransactionSize, keeping the
+// group order. The pre and post instructions of the groups sharing a transaction are deduplicated,
+// maxGroups bounds the groups of a transaction when above zero.
+func PackTransactions(
+	payer solana.PublicKey,
+	groups []types.TransactionGroup,
+	maxGroups int,
+) ([][]solana.Instruction, error) {
+
+	var (
+		transactions [][]solana.Instruction
+		current      []types.TransactionGroup
+		currentIxns  []solana.Instruction
+	)
+	for i, group := range groups {
+		if len(group.Instructions) == 0 {
+			return nil, fmt.Errorf("group(%d) has no instruction", i)
+		}
+
+		if len(current) > 0 && (maxGroups <= 0 || len(current) < maxGroups) {
+			ixns, err := assembleTransaction(append(current, group))
+			if err != nil {
+				return nil, err
+			}
+			size, err := GetTransactionSize(ixns, payer)
+			if err != nil {
+				return nil, err
+			}
+			if size <= constants.MaxTransactionSize {
+				current, currentIxns = append(current, group), ixns
+				continue
+			}
+		}
+
+		if len(current) > 0 {
+			transactions = append(transactions, currentIxns)
+		}
+
+		ixns, err := assembleTransaction([]types.TransactionGroup{group})
+		if err != nil {
+			return nil, err
+		}
+		size, err := GetTransactionSize(ixns, payer)
+		if err != nil {
+			return nil, err
+		}
+		if size > constants.MaxTransactionSize {
+			return nil, fmt.Errorf("group(%d) size %d exceeds the transaction limit", i, size)
+		}
+		current, currentIxns = []types.TransactionGroup{group}, ixns
+	}
+
+	if len(current) > 0 {
+		transactions = append(transactions, currentIxns)
+	}
+	return transactions, nil
+}
+
+func assembleTransaction(groups []types.TransactionGroup) ([]solana.Instruction, error) {
+	var (
+		pre, ixns, post []solana.Instruction
+		seen            = make(map[string]bool)
+	)
+	appendUnique := func(out []solana.Instruction, ix solana.Instruction) ([]solana.Instruction, error) {
+		key, err := instructionKey(ix)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			return out, nil
+		}
+		seen[key] = true
+		return append(out, ix), nil
+	}
+
+	var err error
+	for _, group := range groups {
+		for _, ix := range group.PreInstructions {
+			if pre, err = appendUnique(pre, ix); err != nil {
+				return nil, err
+			}
+		}
+		ixns = append(ixns, group.Instructions...)
+		for _, ix := range group.PostInstructions {
+			if post, err = appendUnique(post, ix); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := make([]solana.Instruction, 0, len(pre)+len(ixns)+len(post))
+	out = append(out, pre...)
+	out = append(out, ixns...)
+	return append(out, post...), nil
+}
+
+func instructionKey(ix solana.Instruction) (string, error) {
+	if ix == nil {
+		return "", errors.New("nil instruction")
+	}
+
+	data, err := ix.Data()
+	if err != nil {
+		return "", err
+	}
+
+	programID := ix.ProgramID()
+	key := make([]byte, 0, 32*(1+len(ix.Accounts()))+len(data))
+	key = append(key, programID[:]...)
+	for _, account := range ix.Accounts() {
+		key = append(key, account.PublicKey[:]...)
+	}
+	return string(append(key, data...)), nil
+}
+
+// IsAboveDustThreshold checks if a pool fee is worth claiming, either fee reaching its minimum.
+func IsAboveDustThreshold(baseFee, quoteFee, minBaseFee, minQuoteFee uint64) bool {
+	return (baseFee > 0 && baseFee >= minBaseFee) || (quoteFee > 0 && quoteFee >= minQuoteFee)
+}
@@ -0,0 +1,101 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransactionSize(t *testing.T) {
+	payer := solana.NewWallet().PrivateKey
+	to := solana.NewWallet().PublicKey()
+	ixns := []solana.Instruction{
+		system.NewTransferInstruction(1, payer.PublicKey(), to).Build(),
+	}
+
+	size, err := helpers.GetTransactionSize(ixns, payer.PublicKey())
+	assert.NoError(t, err)
+
+	tx, err := solana.NewTransaction(ixns, solana.Hash{}, solana.TransactionPayer(payer.PublicKey()))
+	assert.NoError(t, err)
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey { return &payer })
+	assert.NoError(t, err)
+
+	signed, err := tx.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, len(signed), size)
+}
+
+func TestPackTransactions(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	wSolAta, _, _ := solana.FindAssociatedTokenAddress(owner, solana.WrappedSol)
+	unwrapSolIx, err := helpers.UnwrapSOLInstruction(owner, owner, false)
+	assert.NoError(t, err)
+
+	newGroup := func() types.TransactionGroup {
+		return types.TransactionGroup{
+			PreInstructions: []solana.Instruction{
+				helpers.CreateAssociatedTokenAccountIdempotentInstruction(
+					payer, wSolAta, owner, solana.WrappedSol, solana.TokenProgramID,
+				),
+			},
+			Instructions: []solana.Instruction{
+				system.NewTransferInstruction(1, owner, solana.NewWallet().PublicKey()).Build(),
+			},
+			PostInstructions: []solana.Instruction{unwrapSolIx},
+		}
+	}
+
+	groups := make([]types.TransactionGroup, 60)
+	for i := range groups {
+		groups[i] = newGroup()
+	}
+
+	t.Run("size bound", func(t *testing.T) {
+		transactions, err := helpers.PackTransactions(payer, groups, 0)
+		assert.NoError(t, err)
+		assert.Greater(t, len(transactions), 1)
+
+		transfers := 0
+		for _, ixns := range transactions {
+			size, err := helpers.GetTransactionSize(ixns, payer)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, size, constants.MaxTransactionSize)
+
+			// shared pre and post instructions are kept once per transaction
+			assert.Equal(t, solana.SPLAssociatedTokenAccountProgramID, ixns[0].ProgramID())
+			assert.Equal(t, solana.TokenProgramID, ixns[len(ixns)-1].ProgramID())
+			transfers += len(ixns) - 2
+		}
+		assert.Equal(t, len(groups), transfers)
+	})
+
+	t.Run("group bound", func(t *testing.T) {
+		transactions, err := helpers.PackTransactions(payer, groups[:10], 4)
+		assert.NoError(t, err)
+		assert.Len(t, transactions, 3)
+		assert.Len(t, transactions[2], 4)
+	})
+
+	t.Run("oversized group", func(t *testing.T) {
+		group := newGroup()
+		for range 40 {
+			group.Instructions = append(group.Instructions, newGroup().Instructions...)
+		}
+		_, err := helpers.PackTransactions(payer, []types.TransactionGroup{group}, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestIsAboveDustThreshold(t *testing.T) {
+	assert.False(t, helpers.IsAboveDustThreshold(0, 0, 0, 0))
+	assert.True(t, helpers.IsAboveDustThreshold(1, 0, 0, 0))
+	assert.False(t, helpers.IsAboveDustThreshold(99, 99, 100, 100))
+	assert.True(t, helpers.IsAboveDustThreshold(99, 100, 100, 100))
+}
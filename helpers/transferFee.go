@@ -0,0 +1,107 @@
+package helpers
+
+import (
+	"context"
+	"dbcGoSDK/types"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	// token-2022 mint layout: base mint padded to the token account size, then the account type and TLV extensions
+	token2022AccountTypeOffset = 165
+	token2022AccountTypeMint   = 1
+
+	extensionTypeTransferFeeConfig = 1
+	transferFeeConfigLength        = 108
+)
+
+// GetTransferFeeConfigFromMintData reads the TransferFeeConfig extension from Token-2022 mint account data.
+// It returns nil when the mint has no such extension.
+func GetTransferFeeConfigFromMintData(data []byte) (*types.TransferFeeConfig, error) {
+	extension, err := getMintExtension(data, extensionTypeTransferFeeConfig)
+	if err != nil || extension == nil {
+		return nil, err
+	}
+
+	if len(extension) != transferFeeConfigLength {
+		return nil, fmt.Errorf("unexpected TransferFeeConfig length(%d)", len(extension))
+	}
+	return decodeTransferFeeConfig(extension), nil
+}
+
+// getMintExtension gets the data of a TLV extension from Token-2022 mint account data.
+// It returns nil when the mint has no such extension.
+func getMintExtension(data []byte, wantType uint16) ([]byte, error) {
+	if len(data) <= token2022AccountTypeOffset {
+		// plain SPL mint or token-2022 mint without extensions
+		return nil, nil
+	}
+
+	if data[token2022AccountTypeOffset] != token2022AccountTypeMint {
+		return nil, fmt.Errorf("account type(%d) is not a mint", data[token2022AccountTypeOffset])
+	}
+
+	for offset := token2022AccountTypeOffset + 1; offset+4 <= len(data); {
+		extensionType := binary.LittleEndian.Uint16(data[offset:])
+		length := int(binary.LittleEndian.Uint16(data[offset+2:]))
+		offset += 4
+
+		if offset+length > len(data) {
+			return nil, errors.New("mint extension data is truncated")
+		}
+
+		if extensionType == wantType {
+			return data[offset : offset+length], nil
+		}
+
+		// uninitialized extension marks the end of the TLV data
+		if extensionType == 0 {
+			break
+		}
+
+		offset += length
+	}
+
+	return nil, nil
+}
+
+// GetTransferFeeConfig fetches the mint account and reads its TransferFeeConfig extension.
+func GetTransferFeeConfig(
+	ctx context.Context,
+	conn *rpc.Client,
+	mint solana.PublicKey,
+) (*types.TransferFeeConfig, error) {
+	account, err := conn.GetAccountInfo(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("GetTransferFeeConfig:%w", err)
+	}
+
+	if !account.Value.Owner.Equals(solana.Token2022ProgramID) {
+		return nil, nil
+	}
+
+	return GetTransferFeeConfigFromMintData(account.Value.Data.GetBinary())
+}
+
+func decodeTransferFeeConfig(data []byte) *types.TransferFeeConfig {
+	decodeTransferFee := func(b []byte) types.TransferFee {
+		return types.TransferFee{
+			Epoch:                  binary.LittleEndian.Uint64(b[0:8]),
+			MaximumFee:             binary.LittleEndian.Uint64(b[8:16]),
+			TransferFeeBasisPoints: binary.LittleEndian.Uint16(b[16:18]),
+		}
+	}
+
+	return &types.TransferFeeConfig{
+		TransferFeeConfigAuthority: solana.PublicKeyFromBytes(data[0:32]),
+		WithdrawWithheldAuthority:  solana.PublicKeyFromBytes(data[32:64]),
+		WithheldAmount:             binary.LittleEndian.Uint64(data[64:72]),
+		OlderTransferFee:           decodeTransferFee(data[72:90]),
+		NewerTransferFee:           decodeTransferFee(data[90:108]),
+	}
+}
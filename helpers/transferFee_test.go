@@ -0,0 +1,58 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransferFeeConfigFromMintData(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+
+	// base mint, padding and account type
+	data := make([]byte, 166)
+	data[165] = 1
+
+	// unrelated extension (mint close authority)
+	data = binary.LittleEndian.AppendUint16(data, 3)
+	data = binary.LittleEndian.AppendUint16(data, 32)
+	data = append(data, make([]byte, 32)...)
+
+	// transfer fee config
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, 108)
+	data = append(data, authority.Bytes()...)
+	data = append(data, authority.Bytes()...)
+	data = binary.LittleEndian.AppendUint64(data, 42)
+	for _, fee := range []types.TransferFee{
+		{Epoch: 1, MaximumFee: 5_000, TransferFeeBasisPoints: 50},
+		{Epoch: 7, MaximumFee: 9_000, TransferFeeBasisPoints: 75},
+	} {
+		data = binary.LittleEndian.AppendUint64(data, fee.Epoch)
+		data = binary.LittleEndian.AppendUint64(data, fee.MaximumFee)
+		data = binary.LittleEndian.AppendUint16(data, fee.TransferFeeBasisPoints)
+	}
+
+	config, err := helpers.GetTransferFeeConfigFromMintData(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.TransferFeeConfig{
+		TransferFeeConfigAuthority: authority,
+		WithdrawWithheldAuthority:  authority,
+		WithheldAmount:             42,
+		OlderTransferFee:           types.TransferFee{Epoch: 1, MaximumFee: 5_000, TransferFeeBasisPoints: 50},
+		NewerTransferFee:           types.TransferFee{Epoch: 7, MaximumFee: 9_000, TransferFeeBasisPoints: 75},
+	}, config)
+
+	// plain SPL mint
+	config, err = helpers.GetTransferFeeConfigFromMintData(make([]byte, 82))
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	// truncated extension
+	_, err = helpers.GetTransferFeeConfigFromMintData(data[:len(data)-10])
+	assert.Error(t, err)
+}
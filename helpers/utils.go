@@ -31,16 +31,23 @@ func BpsToFeeNumerator(bps uint64) *big.Int {
 		big.NewInt(constants.BasisPointMax))
 }
 
+// ConvertToLamports converts a UI amount into lamports. It panics when amount is NaN or infinite,
+// use ConvertToLamportsChecked to get an error instead.
 func ConvertToLamports(amount float64, tokenDecimal types.TokenDecimal) *big.Int {
-	floatVal := new(big.Float).Mul(
-		big.NewFloat(amount),
-		new(big.Float).SetPrec(256).SetInt(
-			new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(uint64(tokenDecimal)), nil),
-		),
-	)
-	result := new(big.Int)
-	floatVal.Int(result) // truncate/floor
-	return result
+	lamports, err := ConvertToLamportsChecked(amount, tokenDecimal)
+	if err != nil {
+		panic(err)
+	}
+	return lamports
+}
+
+// ConvertToLamportsChecked converts a UI amount into lamports, returning an error when amount is NaN or infinite.
+func ConvertToLamportsChecked(amount float64, tokenDecimal types.TokenDecimal) (*big.Int, error) {
+	amountRat, err := RatFromFloat64(amount)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToLamportsRat(amountRat, tokenDecimal), nil
 }
 
 func BigIntToUint128(b *big.Int) (ag_binary.Uint128, error) {
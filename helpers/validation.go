@@ -0,0 +1,292 @@
+package helpers
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	mathsPoolfees "dbcGoSDK/maths/poolFees"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ValidateConfigParameters checks configParam against the program's constraints before it is sent on-chain.
+// Every violation found is returned, joined into a single error.
+func ValidateConfigParameters(configParam dbc.ConfigParameters) error {
+	var errs []error
+
+	errs = append(errs, validatePoolFees(configParam)...)
+	errs = append(errs, validateEnums(configParam)...)
+	errs = append(errs, validateCurve(configParam)...)
+	errs = append(errs, validateMigration(configParam)...)
+	errs = append(errs, validateLockedVesting(configParam.LockedVesting)...)
+
+	// supply checks depend on a well formed curve
+	if len(errs) == 0 {
+		errs = append(errs, validateTokenSupply(configParam)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePoolFees(configParam dbc.ConfigParameters) []error {
+	var errs []error
+	baseFee := configParam.PoolFees.BaseFee
+	cliffFeeNumerator := new(big.Int).SetUint64(baseFee.CliffFeeNumerator)
+
+	if baseFee.CliffFeeNumerator < constants.MinFeeNumerator || baseFee.CliffFeeNumerator > constants.MaxFeeNumerator {
+		errs = append(errs, fmt.Errorf(
+			"cliff fee numerator (%d) must be between %d and %d",
+			baseFee.CliffFeeNumerator, constants.MinFeeNumerator, constants.MaxFeeNumerator,
+		))
+	}
+
+	switch types.BaseFeeMode(baseFee.BaseFeeMode) {
+	case types.BaseFeeModeFeeSchedulerLinear, types.BaseFeeModeFeeSchedulerExponential:
+		numberOfPeriod, periodFrequency, reductionFactor := baseFee.FirstFactor, baseFee.SecondFactor, baseFee.ThirdFactor
+		if numberOfPeriod == 0 && periodFrequency == 0 && reductionFactor == 0 {
+			break
+		}
+		if numberOfPeriod == 0 || periodFrequency == 0 || reductionFactor == 0 {
+			errs = append(errs, errors.New("fee scheduler numberOfPeriod, periodFrequency and reductionFactor must all be zero or all be non-zero"))
+			break
+		}
+		if types.BaseFeeMode(baseFee.BaseFeeMode) == types.BaseFeeModeFeeSchedulerExponential &&
+			reductionFactor >= constants.BasisPointMax {
+			errs = append(errs, fmt.Errorf("exponential fee scheduler reductionFactor (%d) must be less than %d", reductionFactor, constants.BasisPointMax))
+			break
+		}
+		minFeeNumerator, err := mathsPoolfees.GetMinBaseFeeNumerator(
+			cliffFeeNumerator,
+			numberOfPeriod,
+			new(big.Int).SetUint64(periodFrequency),
+			new(big.Int).SetUint64(reductionFactor),
+			types.BaseFeeMode(baseFee.BaseFeeMode),
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fee scheduler min fee numerator: %w", err))
+		} else if minFeeNumerator.Cmp(big.NewInt(constants.MinFeeNumerator)) < 0 {
+			errs = append(errs, fmt.Errorf(
+				"fee scheduler min fee numerator (%s) must be at least %d", minFeeNumerator, constants.MinFeeNumerator,
+			))
+		}
+
+	case types.BaseFeeModeRateLimiter:
+		feeIncrementBps, maxLimiterDuration, referenceAmount := baseFee.FirstFactor, baseFee.SecondFactor, baseFee.ThirdFactor
+		if types.CollectFeeMode(configParam.CollectFeeMode) != types.CollectFeeModeQuoteToken {
+			errs = append(errs, errors.New("rate limiter requires collect fee mode to be quote token"))
+		}
+		if feeIncrementBps == 0 && maxLimiterDuration == 0 && referenceAmount == 0 {
+			break
+		}
+		if feeIncrementBps == 0 || maxLimiterDuration == 0 || referenceAmount == 0 {
+			errs = append(errs, errors.New("rate limiter feeIncrementBps, maxLimiterDuration and referenceAmount must all be zero or all be non-zero"))
+			break
+		}
+		maxDuration := uint64(constants.MaxRateLimiterDurationInSeconds)
+		if types.ActivationType(configParam.ActivationType) == types.ActivationTypeSlot {
+			maxDuration = constants.MaxRateLimiterDurationInSlots
+		}
+		if maxLimiterDuration > maxDuration {
+			errs = append(errs, fmt.Errorf("rate limiter max duration (%d) exceeds %d", maxLimiterDuration, maxDuration))
+		}
+		if feeIncrementBps > constants.MaxFeeBPS {
+			errs = append(errs, fmt.Errorf("rate limiter fee increment (%d bps) exceeds %d bps", feeIncrementBps, constants.MaxFeeBPS))
+		}
+		maxIndex, err := mathsPoolfees.GetMaxIndex(cliffFeeNumerator, new(big.Int).SetUint64(uint64(feeIncrementBps)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rate limiter: %w", err))
+		} else if maxIndex.Sign() <= 0 {
+			errs = append(errs, errors.New("rate limiter fee increment is too large for the cliff fee"))
+		}
+
+	default:
+		errs = append(errs, fmt.Errorf("invalid base fee mode: %d", baseFee.BaseFeeMode))
+	}
+
+	if dynamicFee := configParam.PoolFees.DynamicFee; dynamicFee != nil {
+		if dynamicFee.BinStep != constants.BinStepBpsDefault {
+			errs = append(errs, fmt.Errorf("dynamic fee bin step (%d) must be %d", dynamicFee.BinStep, constants.BinStepBpsDefault))
+		}
+		if dynamicFee.BinStepU128.BigInt().Cmp(constants.BinStepBpsU128Default) != 0 {
+			errs = append(errs, fmt.Errorf("dynamic fee bin step u128 must be %s", constants.BinStepBpsU128Default))
+		}
+		if dynamicFee.FilterPeriod >= dynamicFee.DecayPeriod {
+			errs = append(errs, fmt.Errorf(
+				"dynamic fee filter period (%d) must be less than decay period (%d)", dynamicFee.FilterPeriod, dynamicFee.DecayPeriod,
+			))
+		}
+		if dynamicFee.ReductionFactor > constants.BasisPointMax {
+			errs = append(errs, fmt.Errorf("dynamic fee reduction factor (%d) exceeds %d", dynamicFee.ReductionFactor, constants.BasisPointMax))
+		}
+	}
+
+	return errs
+}
+
+func validateEnums(configParam dbc.ConfigParameters) []error {
+	var errs []error
+
+	if configParam.CollectFeeMode > uint8(types.CollectFeeModeOutputToken) {
+		errs = append(errs, fmt.Errorf("invalid collect fee mode: %d", configParam.CollectFeeMode))
+	}
+	if configParam.ActivationType > uint8(types.ActivationTypeTimestamp) {
+		errs = append(errs, fmt.Errorf("invalid activation type: %d", configParam.ActivationType))
+	}
+	if configParam.TokenType > uint8(types.TokenTypeToken2022) {
+		errs = append(errs, fmt.Errorf("invalid token type: %d", configParam.TokenType))
+	}
+	if configParam.TokenDecimal < uint8(types.TokenDecimalSIX) || configParam.TokenDecimal > uint8(types.TokenDecimalNINE) {
+		errs = append(errs, fmt.Errorf("token decimal (%d) must be between %d and %d",
+			configParam.TokenDecimal, types.TokenDecimalSIX, types.TokenDecimalNINE))
+	}
+	if configParam.TokenUpdateAuthority > uint8(types.TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority) {
+		errs = append(errs, fmt.Errorf("invalid token update authority: %d", configParam.TokenUpdateAuthority))
+	}
+	if configParam.CreatorTradingFeePercentage > 100 {
+		errs = append(errs, fmt.Errorf("creator trading fee percentage (%d) exceeds 100", configParam.CreatorTradingFeePercentage))
+	}
+
+	return errs
+}
+
+func validateCurve(configParam dbc.ConfigParameters) []error {
+	var errs []error
+
+	if configParam.MigrationQuoteThreshold == 0 {
+		errs = append(errs, errors.New("migration quote threshold must be greater than zero"))
+	}
+
+	sqrtStartPrice := configParam.SqrtStartPrice.BigInt()
+	if sqrtStartPrice.Cmp(constants.MinSqrtPrice) < 0 || sqrtStartPrice.Cmp(constants.MaxSqrtPrice) >= 0 {
+		errs = append(errs, fmt.Errorf("sqrt start price (%s) must be within [%s, %s)",
+			sqrtStartPrice, constants.MinSqrtPrice, constants.MaxSqrtPrice))
+	}
+
+	if len(configParam.Curve) == 0 || len(configParam.Curve) > constants.MaxCurvePoint {
+		errs = append(errs, fmt.Errorf("curve must have between 1 and %d points, got %d", constants.MaxCurvePoint, len(configParam.Curve)))
+		return errs
+	}
+
+	prevSqrtPrice := sqrtStartPrice
+	for i, point := range configParam.Curve {
+		sqrtPrice := point.SqrtPrice.BigInt()
+		if sqrtPrice.Cmp(prevSqrtPrice) <= 0 {
+			errs = append(errs, fmt.Errorf("curve[%d] sqrt price (%s) must be greater than %s", i, sqrtPrice, prevSqrtPrice))
+		}
+		if sqrtPrice.Cmp(constants.MaxSqrtPrice) > 0 {
+			errs = append(errs, fmt.Errorf("curve[%d] sqrt price (%s) exceeds %s", i, sqrtPrice, constants.MaxSqrtPrice))
+		}
+		if point.Liquidity.BigInt().Sign() == 0 {
+			errs = append(errs, fmt.Errorf("curve[%d] liquidity must be greater than zero", i))
+		}
+		prevSqrtPrice = sqrtPrice
+	}
+
+	return errs
+}
+
+func validateMigration(configParam dbc.ConfigParameters) []error {
+	var errs []error
+	migrationOption := types.MigrationOption(configParam.MigrationOption)
+
+	if migrationOption > types.MigrationOptionMET_DAMM_V2 {
+		errs = append(errs, fmt.Errorf("invalid migration option: %d", configParam.MigrationOption))
+	}
+
+	lpPercentage := uint64(configParam.PartnerLpPercentage) +
+		uint64(configParam.PartnerLockedLpPercentage) +
+		uint64(configParam.CreatorLpPercentage) +
+		uint64(configParam.CreatorLockedLpPercentage)
+	if lpPercentage != 100 {
+		errs = append(errs, fmt.Errorf("sum of LP percentages must equal 100, got %d", lpPercentage))
+	}
+
+	if configParam.MigrationFee.FeePercentage > constants.MaxMigrationFeePercentage {
+		errs = append(errs, fmt.Errorf("migration fee percentage (%d) exceeds %d",
+			configParam.MigrationFee.FeePercentage, constants.MaxMigrationFeePercentage))
+	}
+	if configParam.MigrationFee.CreatorFeePercentage > constants.MaxCreatorMigrationFeePercentage {
+		errs = append(errs, fmt.Errorf("creator migration fee percentage (%d) exceeds %d",
+			configParam.MigrationFee.CreatorFeePercentage, constants.MaxCreatorMigrationFeePercentage))
+	}
+
+	migrationFeeOption := types.MigrationFeeOption(configParam.MigrationFeeOption)
+	migratedPoolFee := configParam.MigratedPoolFee
+	switch {
+	case migrationFeeOption > types.MigrationFeeOptionCustomizable:
+		errs = append(errs, fmt.Errorf("invalid migration fee option: %d", configParam.MigrationFeeOption))
+
+	case migrationFeeOption == types.MigrationFeeOptionCustomizable:
+		if migrationOption != types.MigrationOptionMET_DAMM_V2 {
+			errs = append(errs, errors.New("customizable migration fee option is only supported for DAMM v2"))
+		}
+		if migratedPoolFee.PoolFeeBps < constants.MinMigratedPoolFeeBps || migratedPoolFee.PoolFeeBps > constants.MaxMigratedPoolFeeBps {
+			errs = append(errs, fmt.Errorf("migrated pool fee (%d bps) must be between %d and %d bps",
+				migratedPoolFee.PoolFeeBps, constants.MinMigratedPoolFeeBps, constants.MaxMigratedPoolFeeBps))
+		}
+		if migratedPoolFee.CollectFeeMode > 1 {
+			errs = append(errs, fmt.Errorf("invalid migrated pool collect fee mode: %d", migratedPoolFee.CollectFeeMode))
+		}
+		if migratedPoolFee.DynamicFee > 1 {
+			errs = append(errs, fmt.Errorf("invalid migrated pool dynamic fee: %d", migratedPoolFee.DynamicFee))
+		}
+
+	default:
+		if migratedPoolFee != (dbc.MigratedPoolFee{}) {
+			errs = append(errs, errors.New("migrated pool fee must be empty unless the migration fee option is customizable"))
+		}
+	}
+
+	return errs
+}
+
+func validateLockedVesting(lockedVesting dbc.LockedVestingParams) []error {
+	if lockedVesting == (dbc.LockedVestingParams{}) {
+		return nil
+	}
+
+	var errs []error
+	if lockedVesting.Frequency == 0 {
+		errs = append(errs, errors.New("locked vesting frequency must be greater than zero"))
+	}
+	if lockedVesting.NumberOfPeriod > 0 && lockedVesting.AmountPerPeriod == 0 {
+		errs = append(errs, errors.New("locked vesting amount per period must be greater than zero when there are vesting periods"))
+	}
+	if GetTotalVestingAmount(lockedVesting).Sign() == 0 {
+		errs = append(errs, errors.New("locked vesting total amount must be greater than zero"))
+	}
+
+	return errs
+}
+
+func validateTokenSupply(configParam dbc.ConfigParameters) []error {
+	tokenSupply := configParam.TokenSupply
+	if tokenSupply == nil {
+		return nil
+	}
+
+	var errs []error
+	if tokenSupply.PreMigrationTokenSupply > tokenSupply.PostMigrationTokenSupply {
+		errs = append(errs, fmt.Errorf("pre migration token supply (%d) exceeds post migration token supply (%d)",
+			tokenSupply.PreMigrationTokenSupply, tokenSupply.PostMigrationTokenSupply))
+	}
+
+	minimumSupply, err := GetTotalSupplyFromCurve(
+		new(big.Int).SetUint64(configParam.MigrationQuoteThreshold),
+		configParam.SqrtStartPrice.BigInt(),
+		configParam.Curve,
+		configParam.LockedVesting,
+		types.MigrationOption(configParam.MigrationOption),
+		big.NewInt(0),
+		float64(configParam.MigrationFee.FeePercentage),
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("total supply from curve: %w", err))
+	} else if minimumSupply.Cmp(new(big.Int).SetUint64(tokenSupply.PreMigrationTokenSupply)) > 0 {
+		errs = append(errs, fmt.Errorf("pre migration token supply (%d) is less than the %s required by the curve",
+			tokenSupply.PreMigrationTokenSupply, minimumSupply))
+	}
+
+	return errs
+}
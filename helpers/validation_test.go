@@ -0,0 +1,73 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigParameters(t *testing.T) {
+	config, err := helpers.BuildCurve(types.BuildCurveParam{
+		BuildCurveBaseParam: types.BuildCurveBaseParam{
+			TotalTokenSupply:  1_000_000_000,
+			MigrationOption:   types.MigrationOptionMET_DAMM_V2,
+			TokenBaseDecimal:  types.TokenDecimalSIX,
+			TokenQuoteDecimal: types.TokenDecimalNINE,
+			BaseFeeParams: types.BaseFeeParams{
+				BaseFeeMode: types.BaseFeeModeFeeSchedulerLinear,
+				FeeSchedulerParam: &types.FeeSchedulerParams{
+					StartingFeeBps: 100,
+					EndingFeeBps:   100,
+				},
+			},
+			DynamicFeeEnabled:         true,
+			ActivationType:            types.ActivationTypeSlot,
+			CollectFeeMode:            types.CollectFeeModeQuoteToken,
+			MigrationFeeOption:        types.MigrationFeeOptionFixedBps100,
+			TokenType:                 types.TokenTypeSPL,
+			PartnerLockedLpPercentage: 100,
+			Leftover:                  10_000,
+		},
+		PercentageSupplyOnMigration: 2.983257229832572,
+		MigrationQuoteThreshold:     95.07640791476408,
+	})
+	if err != nil {
+		t.Fatalf("BuildCurve errored: %s", err.Error())
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, helpers.ValidateConfigParameters(config))
+	})
+
+	t.Run("returns every violation", func(t *testing.T) {
+		invalid := config
+		invalid.PoolFees.BaseFee.CliffFeeNumerator = 1
+		invalid.TokenDecimal = 12
+		invalid.PartnerLockedLpPercentage = 90
+		invalid.MigrationFee = dbc.MigrationFee{FeePercentage: 51}
+		invalid.LockedVesting = dbc.LockedVestingParams{NumberOfPeriod: 1}
+		invalid.Curve = append([]dbc.LiquidityDistributionParameters{}, config.Curve...)
+		invalid.Curve[0].SqrtPrice = config.SqrtStartPrice
+
+		err := helpers.ValidateConfigParameters(invalid)
+		assert.Error(t, err)
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		assert.True(t, ok)
+		assert.Len(t, joined.Unwrap(), 8)
+	})
+
+	t.Run("customizable migration fee requires damm v2", func(t *testing.T) {
+		invalid := config
+		invalid.MigrationOption = uint8(types.MigrationOptionMET_DAMM)
+		invalid.MigrationFeeOption = uint8(types.MigrationFeeOptionCustomizable)
+		invalid.MigratedPoolFee = dbc.MigratedPoolFee{PoolFeeBps: 100}
+
+		err := helpers.ValidateConfigParameters(invalid)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "only supported for DAMM v2")
+	})
+}
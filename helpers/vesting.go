@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"math/big"
+)
+
+// GetVestingSchedule gets the unlock schedule of a locked vesting config, it is the reverse of GetLockedVestingParams.
+// Vesting starts at migrationTime, the cliff amount unlocks at the cliff time and each period unlocks one frequency later.
+//
+//	cliffTime = migrationTime + cliffDurationFromMigrationTime
+//	endTime = cliffTime + frequency * numberOfPeriod
+func GetVestingSchedule(
+	lockedVesting dbc.LockedVestingConfig,
+	migrationTime uint64,
+	tokenBaseDecimal types.TokenDecimal,
+) types.VestingSchedule {
+	cliffTime := migrationTime + lockedVesting.CliffDurationFromMigrationTime
+	totalAmount := getTotalVestingAmountFromConfig(lockedVesting)
+
+	schedule := types.VestingSchedule{
+		StartTime:     migrationTime,
+		CliffTime:     cliffTime,
+		EndTime:       cliffTime + lockedVesting.Frequency*lockedVesting.NumberOfPeriod,
+		TotalAmount:   totalAmount,
+		TotalAmountUI: ConvertFromLamportsRat(totalAmount, tokenBaseDecimal),
+		Unlocks:       make([]types.VestingUnlock, 0, lockedVesting.NumberOfPeriod+1),
+	}
+	if totalAmount.Sign() == 0 {
+		return schedule
+	}
+
+	cumulativeAmount := big.NewInt(0)
+	addUnlock := func(time, amount uint64) {
+		amountBN := new(big.Int).SetUint64(amount)
+		cumulativeAmount = new(big.Int).Add(cumulativeAmount, amountBN)
+		schedule.Unlocks = append(schedule.Unlocks, types.VestingUnlock{
+			Time:               time,
+			Amount:             amountBN,
+			CumulativeAmount:   cumulativeAmount,
+			AmountUI:           ConvertFromLamportsRat(amountBN, tokenBaseDecimal),
+			CumulativeAmountUI: ConvertFromLamportsRat(cumulativeAmount, tokenBaseDecimal),
+		})
+	}
+
+	if lockedVesting.CliffUnlockAmount > 0 {
+		addUnlock(cliffTime, lockedVesting.CliffUnlockAmount)
+	}
+	if lockedVesting.AmountPerPeriod > 0 {
+		for period := uint64(1); period <= lockedVesting.NumberOfPeriod; period++ {
+			addUnlock(cliffTime+period*lockedVesting.Frequency, lockedVesting.AmountPerPeriod)
+		}
+	}
+
+	return schedule
+}
+
+// GetVestingUnlockedAmount gets the amount of a locked vesting config unlocked at currentTime.
+//
+//	unlocked = cliffUnlockAmount + min((currentTime - cliffTime) / frequency, numberOfPeriod) * amountPerPeriod
+func GetVestingUnlockedAmount(
+	lockedVesting dbc.LockedVestingConfig,
+	migrationTime, currentTime uint64,
+) *big.Int {
+	cliffTime := migrationTime + lockedVesting.CliffDurationFromMigrationTime
+	if currentTime < cliffTime {
+		return big.NewInt(0)
+	}
+
+	period := lockedVesting.NumberOfPeriod
+	if lockedVesting.Frequency > 0 {
+		period = min((currentTime-cliffTime)/lockedVesting.Frequency, lockedVesting.NumberOfPeriod)
+	}
+
+	return new(big.Int).Add(
+		new(big.Int).SetUint64(lockedVesting.CliffUnlockAmount),
+		new(big.Int).Mul(
+			new(big.Int).SetUint64(lockedVesting.AmountPerPeriod),
+			new(big.Int).SetUint64(period),
+		),
+	)
+}
+
+// GetVestingStatus gets the unlocked, claimable and still locked amounts of a locked vesting config at currentTime.
+// claimedAmount is the amount already claimed from the escrow.
+func GetVestingStatus(
+	lockedVesting dbc.LockedVestingConfig,
+	migrationTime, currentTime, claimedAmount uint64,
+	tokenBaseDecimal types.TokenDecimal,
+) types.VestingStatus {
+	unlockedAmount := GetVestingUnlockedAmount(lockedVesting, migrationTime, currentTime)
+
+	claimableAmount := new(big.Int).Sub(unlockedAmount, new(big.Int).SetUint64(claimedAmount))
+	if claimableAmount.Sign() < 0 {
+		claimableAmount.SetInt64(0)
+	}
+
+	lockedAmount := new(big.Int).Sub(getTotalVestingAmountFromConfig(lockedVesting), unlockedAmount)
+
+	var nextUnlockTime uint64
+	if lockedAmount.Sign() > 0 {
+		cliffTime := migrationTime + lockedVesting.CliffDurationFromMigrationTime
+		nextUnlockTime = cliffTime
+		if currentTime >= cliffTime && lockedVesting.Frequency > 0 {
+			nextUnlockTime = cliffTime + ((currentTime-cliffTime)/lockedVesting.Frequency+1)*lockedVesting.Frequency
+		}
+	}
+
+	return types.VestingStatus{
+		UnlockedAmount:    unlockedAmount,
+		ClaimableAmount:   claimableAmount,
+		LockedAmount:      lockedAmount,
+		UnlockedAmountUI:  ConvertFromLamportsRat(unlockedAmount, tokenBaseDecimal),
+		ClaimableAmountUI: ConvertFromLamportsRat(claimableAmount, tokenBaseDecimal),
+		LockedAmountUI:    ConvertFromLamportsRat(lockedAmount, tokenBaseDecimal),
+		NextUnlockTime:    nextUnlockTime,
+	}
+}
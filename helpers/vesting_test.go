@@ -0,0 +1,61 @@
+package helpers_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVestingSchedule(t *testing.T) {
+	params, err := helpers.GetLockedVestingParams(1_000, 3, 100, 3*86_400, 3_600, types.TokenDecimalSIX)
+	assert.NoError(t, err)
+
+	lockedVesting := dbc.LockedVestingConfig{
+		AmountPerPeriod:                params.AmountPerPeriod,
+		CliffDurationFromMigrationTime: params.CliffDurationFromMigrationTime,
+		Frequency:                      params.Frequency,
+		NumberOfPeriod:                 params.NumberOfPeriod,
+		CliffUnlockAmount:              params.CliffUnlockAmount,
+	}
+	migrationTime := uint64(1_700_000_000)
+
+	schedule := helpers.GetVestingSchedule(lockedVesting, migrationTime, types.TokenDecimalSIX)
+	assert.Equal(t, migrationTime+3_600, schedule.CliffTime)
+	assert.Equal(t, migrationTime+3_600+3*86_400, schedule.EndTime)
+	assert.Equal(t, big.NewRat(1_000, 1), schedule.TotalAmountUI)
+	assert.Len(t, schedule.Unlocks, 4)
+	assert.Equal(t, big.NewRat(100, 1), schedule.Unlocks[0].AmountUI)
+	assert.Equal(t, big.NewRat(300, 1), schedule.Unlocks[1].AmountUI)
+	assert.Equal(t, schedule.EndTime, schedule.Unlocks[3].Time)
+	assert.Equal(t, schedule.TotalAmount, schedule.Unlocks[3].CumulativeAmount)
+
+	t.Run("status", func(t *testing.T) {
+		status := helpers.GetVestingStatus(lockedVesting, migrationTime, migrationTime+60, 0, types.TokenDecimalSIX)
+		assert.Zero(t, status.UnlockedAmount.Sign())
+		assert.Equal(t, schedule.TotalAmount, status.LockedAmount)
+		assert.Equal(t, schedule.CliffTime, status.NextUnlockTime)
+
+		status = helpers.GetVestingStatus(
+			lockedVesting, migrationTime, schedule.CliffTime+86_400+1, 100_000_000, types.TokenDecimalSIX,
+		)
+		assert.Equal(t, big.NewRat(400, 1), status.UnlockedAmountUI)
+		assert.Equal(t, big.NewRat(300, 1), status.ClaimableAmountUI)
+		assert.Equal(t, big.NewRat(600, 1), status.LockedAmountUI)
+		assert.Equal(t, schedule.Unlocks[2].Time, status.NextUnlockTime)
+
+		status = helpers.GetVestingStatus(lockedVesting, migrationTime, schedule.EndTime, 0, types.TokenDecimalSIX)
+		assert.Equal(t, schedule.TotalAmount, status.UnlockedAmount)
+		assert.Zero(t, status.LockedAmount.Sign())
+		assert.Zero(t, status.NextUnlockTime)
+	})
+
+	t.Run("no vesting", func(t *testing.T) {
+		schedule := helpers.GetVestingSchedule(dbc.LockedVestingConfig{}, migrationTime, types.TokenDecimalSIX)
+		assert.Empty(t, schedule.Unlocks)
+		assert.Zero(t, schedule.TotalAmount.Sign())
+	})
+}
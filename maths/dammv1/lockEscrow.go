@@ -0,0 +1,85 @@
+package dammv1
+
+import (
+	amm "dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/types"
+	"errors"
+	"math/big"
+)
+
+// virtualPriceScale is the fixed point offset of the DAMM v1 virtual price.
+const virtualPriceScale = 64
+
+// GetVirtualPrice gets the constant product virtual price of one pool LP token.
+//
+//	virtualPrice = sqrt(tokenAAmount * tokenBAmount) << 64 / lpSupply
+func GetVirtualPrice(tokenAAmount, tokenBAmount, lpSupply *big.Int) *big.Int {
+	if lpSupply.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	d := new(big.Int).Sqrt(new(big.Int).Mul(tokenAAmount, tokenBAmount))
+	return d.Quo(d.Lsh(d, virtualPriceScale), lpSupply)
+}
+
+// GetLockEscrowUnclaimedFee gets the LP amount a lock escrow can claim as fee at virtualPrice.
+// The locked LP grows in value with the virtual price, the growth since the last claim is the fee.
+//
+//	fee = pending + totalLockedAmount * (virtualPrice - lpPerToken) / virtualPrice
+func GetLockEscrowUnclaimedFee(
+	totalLockedAmount uint64,
+	lpPerToken *big.Int,
+	unclaimedFeePending uint64,
+	virtualPrice *big.Int,
+) *big.Int {
+	fee := new(big.Int).SetUint64(unclaimedFeePending)
+	if virtualPrice.Sign() == 0 || virtualPrice.Cmp(lpPerToken) <= 0 {
+		return fee
+	}
+
+	newFee := new(big.Int).Mul(
+		new(big.Int).SetUint64(totalLockedAmount),
+		new(big.Int).Sub(virtualPrice, lpPerToken),
+	)
+	return fee.Add(fee, newFee.Quo(newFee, virtualPrice))
+}
+
+// GetLockEscrowClaimableFee gets the fee a lock escrow can claim at currentTime, in pool LP and
+// in the token A and token B amounts withdrawn for it.
+func GetLockEscrowClaimableFee(
+	state types.DammV1PoolState,
+	lpSupply *big.Int,
+	totalLockedAmount uint64,
+	lpPerToken *big.Int,
+	unclaimedFeePending uint64,
+	currentTime uint64,
+) (types.DammV1LockEscrowFee, error) {
+	if lpSupply == nil || lpSupply.Sign() < 0 {
+		return types.DammV1LockEscrowFee{}, errors.New("lpSupply must be a non negative value")
+	}
+
+	if state.Pool != nil && state.Pool.CurveType != nil {
+		if _, ok := state.Pool.CurveType.Value.(amm.CurveTypeStableTuple); ok {
+			return types.DammV1LockEscrowFee{}, errors.New("stable curve pools are not supported")
+		}
+	}
+
+	tokenAAmount, tokenBAmount, err := GetPoolTokenAmounts(state, currentTime)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, err
+	}
+
+	virtualPrice := GetVirtualPrice(tokenAAmount, tokenBAmount, lpSupply)
+	lpAmount := GetLockEscrowUnclaimedFee(totalLockedAmount, lpPerToken, unclaimedFeePending, virtualPrice)
+
+	fee := types.DammV1LockEscrowFee{
+		LpAmount:     lpAmount,
+		TokenAAmount: big.NewInt(0),
+		TokenBAmount: big.NewInt(0),
+		VirtualPrice: virtualPrice,
+	}
+	if lpSupply.Sign() > 0 {
+		fee.TokenAAmount.Quo(new(big.Int).Mul(lpAmount, tokenAAmount), lpSupply)
+		fee.TokenBAmount.Quo(new(big.Int).Mul(lpAmount, tokenBAmount), lpSupply)
+	}
+	return fee, nil
+}
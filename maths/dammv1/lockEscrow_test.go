@@ -0,0 +1,50 @@
+package dammv1_test
+
+import (
+	mathsDammv1 "dbcGoSDK/maths/dammv1"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVirtualPrice(t *testing.T) {
+	// sqrt(4 * 9) = 6 per 3 lp
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(2), 64),
+		mathsDammv1.GetVirtualPrice(big.NewInt(4), big.NewInt(9), big.NewInt(3)))
+	assert.Equal(t, big.NewInt(0), mathsDammv1.GetVirtualPrice(big.NewInt(4), big.NewInt(9), big.NewInt(0)))
+}
+
+func TestGetLockEscrowUnclaimedFee(t *testing.T) {
+	lpPerToken := new(big.Int).Lsh(big.NewInt(4), 64)
+	virtualPrice := new(big.Int).Lsh(big.NewInt(5), 64)
+
+	// 1000 * (5 - 4) / 5 + 7
+	assert.Equal(t, big.NewInt(207), mathsDammv1.GetLockEscrowUnclaimedFee(1_000, lpPerToken, 7, virtualPrice))
+	assert.Equal(t, big.NewInt(7), mathsDammv1.GetLockEscrowUnclaimedFee(1_000, virtualPrice, 7, lpPerToken))
+	assert.Equal(t, big.NewInt(0), mathsDammv1.GetLockEscrowUnclaimedFee(1_000, lpPerToken, 0, big.NewInt(0)))
+}
+
+func TestGetLockEscrowClaimableFee(t *testing.T) {
+	state := newPoolState()
+
+	// 1_000_000_000_000 token A and 200_000_000_000 token B
+	tokenAAmount, tokenBAmount, err := mathsDammv1.GetPoolTokenAmounts(state, 0)
+	assert.NoError(t, err)
+	lpSupply := big.NewInt(400_000_000_000)
+
+	virtualPrice := mathsDammv1.GetVirtualPrice(tokenAAmount, tokenBAmount, lpSupply)
+	lpPerToken := new(big.Int).Quo(new(big.Int).Mul(virtualPrice, big.NewInt(9)), big.NewInt(10))
+
+	fee, err := mathsDammv1.GetLockEscrowClaimableFee(state, lpSupply, 100_000_000_000, lpPerToken, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, virtualPrice, fee.VirtualPrice)
+	assert.InDelta(t, 10_000_000_000, fee.LpAmount.Uint64(), 1)
+	assert.Equal(t,
+		new(big.Int).Quo(new(big.Int).Mul(fee.LpAmount, tokenAAmount), lpSupply), fee.TokenAAmount)
+	assert.Equal(t,
+		new(big.Int).Quo(new(big.Int).Mul(fee.LpAmount, tokenBAmount), lpSupply), fee.TokenBAmount)
+
+	_, err = mathsDammv1.GetLockEscrowClaimableFee(state, nil, 100_000_000_000, lpPerToken, 0, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package dammv1
+
+import (
+	amm "dbcGoSDK/generated/dammv1"
+	mathsDynamicVault "dbcGoSDK/maths/dynamicVault"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// GetPoolTokenAmounts gets the real token amounts of a pool from its vault LP holdings at currentTime.
+func GetPoolTokenAmounts(
+	state types.DammV1PoolState,
+	currentTime uint64,
+) (tokenAAmount, tokenBAmount *big.Int, err error) {
+	if err := validatePoolState(state); err != nil {
+		return nil, nil, err
+	}
+
+	tokenAAmount = mathsDynamicVault.GetAmountByShare(
+		state.PoolVaultALp,
+		mathsDynamicVault.GetUnlockedAmount(state.VaultA, currentTime),
+		state.VaultALpSupply,
+	)
+	tokenBAmount = mathsDynamicVault.GetAmountByShare(
+		state.PoolVaultBLp,
+		mathsDynamicVault.GetUnlockedAmount(state.VaultB, currentTime),
+		state.VaultBLpSupply,
+	)
+	return tokenAAmount, tokenBAmount, nil
+}
+
+// CalculateFee calculates a pool fee, any non-zero amount pays at least 1.
+//
+//	fee = max(amount * numerator / denominator, 1)
+func CalculateFee(amount *big.Int, numerator, denominator uint64) *big.Int {
+	if numerator == 0 || denominator == 0 || amount.Sign() == 0 {
+		return big.NewInt(0)
+	}
+
+	fee := new(big.Int).Quo(
+		new(big.Int).Mul(amount, new(big.Int).SetUint64(numerator)),
+		new(big.Int).SetUint64(denominator),
+	)
+	if fee.Sign() == 0 {
+		return big.NewInt(1)
+	}
+	return fee
+}
+
+// GetOutAmount gets the constant product output amount, rounding down.
+//
+//	out = destinationAmount * sourceAmount / (swapSourceAmount + sourceAmount)
+func GetOutAmount(sourceAmount, swapSourceAmount, swapDestinationAmount *big.Int) *big.Int {
+	denominator := new(big.Int).Add(swapSourceAmount, sourceAmount)
+	if denominator.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Quo(new(big.Int).Mul(swapDestinationAmount, sourceAmount), denominator)
+}
+
+// SwapQuote calculates an exact in quote on a constant product DAMM v1 pool.
+// Deposits into and withdrawals from the vaults are simulated, so the rounding matches the program.
+func SwapQuote(param types.DammV1SwapQuoteParam) (types.DammV1SwapQuoteResult, error) {
+	if err := validatePoolState(param.DammV1PoolState); err != nil {
+		return types.DammV1SwapQuoteResult{}, err
+	}
+
+	if param.InAmount == nil || param.InAmount.Sign() <= 0 {
+		return types.DammV1SwapQuoteResult{}, errors.New("inAmount must be greater than zero")
+	}
+
+	if param.SlippageBps > 10_000 {
+		return types.DammV1SwapQuoteResult{}, fmt.Errorf("slippageBps(%d) cannot exceed 10000", param.SlippageBps)
+	}
+
+	if param.Pool.CurveType != nil {
+		if _, ok := param.Pool.CurveType.Value.(amm.CurveTypeStableTuple); ok {
+			return types.DammV1SwapQuoteResult{}, errors.New("stable curve pools are not supported")
+		}
+	}
+
+	if !param.Pool.Enabled {
+		return types.DammV1SwapQuoteResult{}, errors.New("pool is disabled")
+	}
+
+	tokenAAmount, tokenBAmount, err := GetPoolTokenAmounts(param.DammV1PoolState, param.CurrentTime)
+	if err != nil {
+		return types.DammV1SwapQuoteResult{}, err
+	}
+
+	var (
+		sourceVault, destinationVault                 = param.VaultA, param.VaultB
+		poolSourceVaultLp, poolDestinationVaultLp     = param.PoolVaultALp, param.PoolVaultBLp
+		sourceVaultLpSupply, destinationVaultLpSupply = param.VaultALpSupply, param.VaultBLpSupply
+		swapSourceAmount, swapDestinationAmount       = tokenAAmount, tokenBAmount
+	)
+	switch {
+	case param.InTokenMint.Equals(param.Pool.TokenAMint):
+	case param.InTokenMint.Equals(param.Pool.TokenBMint):
+		sourceVault, destinationVault = param.VaultB, param.VaultA
+		poolSourceVaultLp, poolDestinationVaultLp = param.PoolVaultBLp, param.PoolVaultALp
+		sourceVaultLpSupply, destinationVaultLpSupply = param.VaultBLpSupply, param.VaultALpSupply
+		swapSourceAmount, swapDestinationAmount = tokenBAmount, tokenAAmount
+	default:
+		return types.DammV1SwapQuoteResult{},
+			fmt.Errorf("inTokenMint(%s) is not a token of the pool", param.InTokenMint)
+	}
+
+	fees := param.Pool.Fees
+	tradeFee := CalculateFee(param.InAmount, fees.TradeFeeNumerator, fees.TradeFeeDenominator)
+	protocolFee := CalculateFee(tradeFee, fees.ProtocolTradeFeeNumerator, fees.ProtocolTradeFeeDenominator)
+	tradeFee = new(big.Int).Sub(tradeFee, protocolFee)
+
+	inAmountAfterProtocolFee := new(big.Int).Sub(param.InAmount, protocolFee)
+
+	// deposit into the source vault
+	sourceUnlockedAmount := mathsDynamicVault.GetUnlockedAmount(sourceVault, param.CurrentTime)
+	sourceVaultLp := mathsDynamicVault.GetUnmintAmount(
+		inAmountAfterProtocolFee, sourceUnlockedAmount, sourceVaultLpSupply,
+	)
+	afterSwapSourceAmount := mathsDynamicVault.GetAmountByShare(
+		new(big.Int).Add(poolSourceVaultLp, sourceVaultLp),
+		new(big.Int).Add(sourceUnlockedAmount, inAmountAfterProtocolFee),
+		new(big.Int).Add(sourceVaultLpSupply, sourceVaultLp),
+	)
+
+	actualSourceAmountIn := new(big.Int).Sub(afterSwapSourceAmount, swapSourceAmount)
+	sourceAmountWithFee := new(big.Int).Sub(actualSourceAmountIn, tradeFee)
+	if sourceAmountWithFee.Sign() <= 0 {
+		return types.DammV1SwapQuoteResult{}, errors.New("inAmount is too small to cover the trade fee")
+	}
+
+	destinationAmount := GetOutAmount(sourceAmountWithFee, swapSourceAmount, swapDestinationAmount)
+
+	// withdraw from the destination vault
+	destinationUnlockedAmount := mathsDynamicVault.GetUnlockedAmount(destinationVault, param.CurrentTime)
+	destinationVaultLp := mathsDynamicVault.GetUnmintAmount(
+		destinationAmount, destinationUnlockedAmount, destinationVaultLpSupply,
+	)
+	if destinationVaultLp.Cmp(poolDestinationVaultLp) > 0 {
+		return types.DammV1SwapQuoteResult{}, errors.New("not enough liquidity in the destination vault")
+	}
+
+	swapOutAmount := mathsDynamicVault.GetAmountByShare(
+		destinationVaultLp, destinationUnlockedAmount, destinationVaultLpSupply,
+	)
+	if destinationVault.TotalAmount < swapOutAmount.Uint64() {
+		return types.DammV1SwapQuoteResult{}, errors.New("destination vault does not hold enough tokens")
+	}
+
+	minSwapOutAmount := new(big.Int).Quo(
+		new(big.Int).Mul(swapOutAmount, new(big.Int).SetUint64(10_000-param.SlippageBps)),
+		big.NewInt(10_000),
+	)
+
+	// priceImpact = 1 - swapOutAmount / (inAmount * swapDestinationAmount / swapSourceAmount)
+	priceImpact := new(big.Rat)
+	if swapSourceAmount.Sign() > 0 && swapDestinationAmount.Sign() > 0 {
+		spotOutAmount := new(big.Rat).SetFrac(
+			new(big.Int).Mul(param.InAmount, swapDestinationAmount), swapSourceAmount,
+		)
+		priceImpact.Sub(big.NewRat(1, 1), new(big.Rat).Quo(new(big.Rat).SetInt(swapOutAmount), spotOutAmount))
+	}
+
+	return types.DammV1SwapQuoteResult{
+		SwapInAmount:     new(big.Int).Set(param.InAmount),
+		SwapOutAmount:    swapOutAmount,
+		MinSwapOutAmount: minSwapOutAmount,
+		TradeFee:         tradeFee,
+		ProtocolFee:      protocolFee,
+		PriceImpact:      priceImpact,
+	}, nil
+}
+
+func validatePoolState(state types.DammV1PoolState) error {
+	if state.Pool == nil || state.VaultA == nil || state.VaultB == nil ||
+		state.PoolVaultALp == nil || state.PoolVaultBLp == nil ||
+		state.VaultALpSupply == nil || state.VaultBLpSupply == nil {
+		return fmt.Errorf("DammV1PoolState: nil value: %+v", state)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package dammv1_test
+
+import (
+	amm "dbcGoSDK/generated/dammv1"
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
+	mathsDammv1 "dbcGoSDK/maths/dammv1"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPoolState() types.DammV1PoolState {
+	return types.DammV1PoolState{
+		Pool: &amm.PoolAccount{
+			TokenAMint: solana.NewWallet().PublicKey(),
+			TokenBMint: solana.NewWallet().PublicKey(),
+			Enabled:    true,
+			Fees: amm.PoolFees{
+				TradeFeeNumerator:           25,
+				TradeFeeDenominator:         10_000,
+				ProtocolTradeFeeNumerator:   20,
+				ProtocolTradeFeeDenominator: 100,
+			},
+		},
+		VaultA:         &dynamic_vault.VaultAccount{TotalAmount: 2_000_000_000_000},
+		VaultB:         &dynamic_vault.VaultAccount{TotalAmount: 500_000_000_000},
+		PoolVaultALp:   big.NewInt(1_000_000_000_000),
+		PoolVaultBLp:   big.NewInt(100_000_000_000),
+		VaultALpSupply: big.NewInt(2_000_000_000_000),
+		VaultBLpSupply: big.NewInt(250_000_000_000),
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	assert.Equal(t, big.NewInt(1), mathsDammv1.CalculateFee(big.NewInt(1), 25, 10_000))
+	assert.Equal(t, big.NewInt(25), mathsDammv1.CalculateFee(big.NewInt(10_000), 25, 10_000))
+	assert.Equal(t, big.NewInt(0), mathsDammv1.CalculateFee(big.NewInt(10_000), 0, 10_000))
+	assert.Equal(t, big.NewInt(0), mathsDammv1.CalculateFee(big.NewInt(0), 25, 10_000))
+}
+
+func TestSwapQuote(t *testing.T) {
+	state := newPoolState()
+
+	tokenAAmount, tokenBAmount, err := mathsDammv1.GetPoolTokenAmounts(state, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1_000_000_000_000), tokenAAmount)
+	assert.Equal(t, big.NewInt(200_000_000_000), tokenBAmount)
+
+	t.Run("a to b", func(t *testing.T) {
+		param := types.DammV1SwapQuoteParam{
+			DammV1PoolState: state,
+			InTokenMint:     state.Pool.TokenAMint,
+			InAmount:        big.NewInt(1_000_000_000),
+			SlippageBps:     100,
+		}
+		got, err := mathsDammv1.SwapQuote(param)
+		assert.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(2_000_000), got.TradeFee)
+		assert.Equal(t, big.NewInt(500_000), got.ProtocolFee)
+		// 200e9 * (1e9 - 2.5e6) / (1e12 + 1e9 - 2.5e6), rounded down to the vault share
+		assert.Equal(t, big.NewInt(199_301_196), got.SwapOutAmount)
+		assert.Equal(t, big.NewInt(197_308_184), got.MinSwapOutAmount)
+		assert.Equal(t, 1, got.PriceImpact.Sign())
+
+		again, err := mathsDammv1.SwapQuote(param)
+		assert.NoError(t, err)
+		assert.Equal(t, got, again)
+	})
+
+	t.Run("b to a", func(t *testing.T) {
+		got, err := mathsDammv1.SwapQuote(types.DammV1SwapQuoteParam{
+			DammV1PoolState: state,
+			InTokenMint:     state.Pool.TokenBMint,
+			InAmount:        big.NewInt(1_000_000),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, got.SwapOutAmount, got.MinSwapOutAmount)
+		assert.True(t, got.SwapOutAmount.Cmp(big.NewInt(4_980_000)) > 0)
+		assert.True(t, got.SwapOutAmount.Cmp(big.NewInt(5_000_000)) < 0)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		param := types.DammV1SwapQuoteParam{
+			DammV1PoolState: state,
+			InTokenMint:     solana.NewWallet().PublicKey(),
+			InAmount:        big.NewInt(1_000),
+		}
+		_, err := mathsDammv1.SwapQuote(param)
+		assert.Error(t, err)
+
+		param.InTokenMint = state.Pool.TokenAMint
+		param.SlippageBps = 10_001
+		_, err = mathsDammv1.SwapQuote(param)
+		assert.Error(t, err)
+
+		param.SlippageBps = 0
+		param.InAmount = big.NewInt(0)
+		_, err = mathsDammv1.SwapQuote(param)
+		assert.Error(t, err)
+
+		stable := newPoolState()
+		stable.Pool.CurveType = &amm.CurveType{Value: amm.CurveTypeStableTuple{}}
+		_, err = mathsDammv1.SwapQuote(types.DammV1SwapQuoteParam{
+			DammV1PoolState: stable,
+			InTokenMint:     stable.Pool.TokenAMint,
+			InAmount:        big.NewInt(1_000),
+		})
+		assert.Error(t, err)
+
+		_, err = mathsDammv1.SwapQuote(types.DammV1SwapQuoteParam{InAmount: big.NewInt(1)})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,169 @@
+package dammv2
+
+import (
+	"dbcGoSDK/constants"
+	cpamm "dbcGoSDK/generated/dammv2"
+	mathsPoolfees "dbcGoSDK/maths/poolFees"
+	"dbcGoSDK/types"
+	"fmt"
+	"math/big"
+)
+
+// collect fee modes of a DAMM V2 pool.
+const (
+	CollectFeeModeBothToken uint8 = iota // fees are collected in the output token
+	CollectFeeModeOnlyB                  // fees are always collected in token B
+)
+
+// GetFeeMode gets fee mode, FeesOnBaseToken means the fees are collected in token A.
+func GetFeeMode(
+	collectFeeMode uint8,
+	swapAToB bool,
+	hasReferral bool,
+) types.FeeMode {
+	// (CollectFeeMode::BothToken, TradeDirection::AtoB) => (false, false),
+	// (CollectFeeMode::BothToken, TradeDirection::BtoA) => (false, true),
+	// (CollectFeeMode::OnlyB, TradeDirection::AtoB) => (false, false),
+	// (CollectFeeMode::OnlyB, TradeDirection::BtoA) => (true, false),
+
+	if swapAToB {
+		return types.FeeMode{HasReferral: hasReferral}
+	}
+
+	if collectFeeMode == CollectFeeModeBothToken {
+		return types.FeeMode{
+			FeesOnBaseToken: true,
+			HasReferral:     hasReferral,
+		}
+	}
+
+	return types.FeeMode{
+		FeesOnInput: true,
+		HasReferral: hasReferral,
+	}
+}
+
+// GetBaseFeeNumerator gets the fee scheduler numerator at currentPoint.
+// Before the activation point the scheduler is at its last period.
+func GetBaseFeeNumerator(
+	baseFee cpamm.BaseFeeStruct,
+	currentPoint, activationPoint *big.Int,
+) (*big.Int, error) {
+	cliffFeeNumerator := new(big.Int).SetUint64(baseFee.CliffFeeNumerator)
+	if baseFee.PeriodFrequency == 0 {
+		return cliffFeeNumerator, nil
+	}
+
+	period := new(big.Int).SetUint64(uint64(baseFee.NumberOfPeriod))
+	if currentPoint.Cmp(activationPoint) >= 0 {
+		period = new(big.Int).Quo(
+			new(big.Int).Sub(currentPoint, activationPoint),
+			new(big.Int).SetUint64(baseFee.PeriodFrequency),
+		)
+	}
+
+	return mathsPoolfees.GetBaseFeeNumeratorByPeriod(
+		cliffFeeNumerator,
+		baseFee.NumberOfPeriod,
+		period,
+		new(big.Int).SetUint64(baseFee.ReductionFactor),
+		types.BaseFeeMode(baseFee.FeeSchedulerMode),
+	)
+}
+
+// GetVariableFeeNumerator gets variable fee numerator from dynamic fee.
+//
+//	Formula: ((volatility_accumulator * bin_step)^2 * variable_fee_control + 99_999_999_999) / 100_000_000_000
+func GetVariableFeeNumerator(dynamicFee cpamm.DynamicFeeStruct) *big.Int {
+	if dynamicFee.Initialized == 0 {
+		return big.NewInt(0)
+	}
+
+	volatilityTimesBinStep := new(big.Int).Mul(
+		dynamicFee.VolatilityAccumulator.BigInt(),
+		new(big.Int).SetUint64(uint64(dynamicFee.BinStep)),
+	)
+	vFee := new(big.Int).Mul(
+		new(big.Int).Mul(volatilityTimesBinStep, volatilityTimesBinStep),
+		new(big.Int).SetUint64(uint64(dynamicFee.VariableFeeControl)),
+	)
+
+	return new(big.Int).Quo(
+		new(big.Int).Add(vFee, constants.DynamicFeeRoundingOffset),
+		constants.DynamicFeeScalingFactor,
+	)
+}
+
+// GetTotalFeeNumerator gets base fee plus variable fee numerator, capped at MaxFeeNumerator.
+func GetTotalFeeNumerator(
+	poolFees cpamm.PoolFeesStruct,
+	currentPoint, activationPoint *big.Int,
+) (*big.Int, error) {
+	baseFeeNumerator, err := GetBaseFeeNumerator(poolFees.BaseFee, currentPoint, activationPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	totalFeeNumerator := new(big.Int).Add(
+		baseFeeNumerator,
+		GetVariableFeeNumerator(poolFees.DynamicFee),
+	)
+	if maxFeeNumeratorBN := big.NewInt(constants.MaxFeeNumerator); totalFeeNumerator.Cmp(maxFeeNumeratorBN) > 0 {
+		return maxFeeNumeratorBN, nil
+	}
+
+	return totalFeeNumerator, nil
+}
+
+// SplitFees splits a trading fee into lp, protocol, partner and referral fees.
+func SplitFees(
+	poolFees cpamm.PoolFeesStruct,
+	feeAmount *big.Int,
+	hasReferral, hasPartner bool,
+) (struct{ LpFee, ProtocolFee, PartnerFee, ReferralFee *big.Int }, error) {
+	protocolFee := new(big.Int).Quo(
+		new(big.Int).Mul(feeAmount, new(big.Int).SetUint64(uint64(poolFees.ProtocolFeePercent))),
+		big.NewInt(100),
+	)
+
+	lpFee := new(big.Int).Sub(feeAmount, protocolFee)
+	if lpFee.Sign() < 0 {
+		return struct{ LpFee, ProtocolFee, PartnerFee, ReferralFee *big.Int }{},
+			fmt.Errorf("SplitFees:safeMath requires value not negative: value is %s", lpFee)
+	}
+
+	referralFee := big.NewInt(0)
+	if hasReferral {
+		referralFee = new(big.Int).Quo(
+			new(big.Int).Mul(protocolFee, new(big.Int).SetUint64(uint64(poolFees.ReferralFeePercent))),
+			big.NewInt(100),
+		)
+	}
+	protocolFee.Sub(protocolFee, referralFee)
+
+	partnerFee := big.NewInt(0)
+	if hasPartner && poolFees.PartnerFeePercent > 0 {
+		partnerFee = new(big.Int).Quo(
+			new(big.Int).Mul(protocolFee, new(big.Int).SetUint64(uint64(poolFees.PartnerFeePercent))),
+			big.NewInt(100),
+		)
+	}
+	protocolFee.Sub(protocolFee, partnerFee)
+
+	if protocolFee.Sign() < 0 {
+		return struct{ LpFee, ProtocolFee, PartnerFee, ReferralFee *big.Int }{},
+			fmt.Errorf("SplitFees:safeMath requires value not negative: value is %s", protocolFee)
+	}
+
+	return struct {
+		LpFee       *big.Int
+		ProtocolFee *big.Int
+		PartnerFee  *big.Int
+		ReferralFee *big.Int
+	}{
+		LpFee:       lpFee,
+		ProtocolFee: protocolFee,
+		PartnerFee:  partnerFee,
+		ReferralFee: referralFee,
+	}, nil
+}
@@ -0,0 +1,355 @@
+package dammv2
+
+import (
+	cpamm "dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/maths"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// GetSwapAmountFromInput gets the output amount and next sqrt price of an input amount
+// within the [sqrtMinPrice, sqrtMaxPrice] range of the pool.
+func GetSwapAmountFromInput(
+	sqrtPrice, sqrtMinPrice, sqrtMaxPrice, liquidity, amountIn *big.Int,
+	swapAToB bool,
+) (types.SwapAmount, error) {
+	nextSqrtPrice, err := maths.GetNextSqrtPriceFromInput(sqrtPrice, liquidity, amountIn, swapAToB)
+	if err != nil {
+		return types.SwapAmount{}, err
+	}
+
+	var outputAmount *big.Int
+	if swapAToB {
+		if nextSqrtPrice.Cmp(sqrtMinPrice) < 0 {
+			return types.SwapAmount{}, fmt.Errorf("nextSqrtPrice(%s) is below sqrtMinPrice(%s)", nextSqrtPrice, sqrtMinPrice)
+		}
+		outputAmount, err = maths.GetDeltaAmountQuoteUnsigned(nextSqrtPrice, sqrtPrice, liquidity, types.RoundingDown)
+	} else {
+		if nextSqrtPrice.Cmp(sqrtMaxPrice) > 0 {
+			return types.SwapAmount{}, fmt.Errorf("nextSqrtPrice(%s) is above sqrtMaxPrice(%s)", nextSqrtPrice, sqrtMaxPrice)
+		}
+		outputAmount, err = maths.GetDeltaAmountBaseUnsigned(sqrtPrice, nextSqrtPrice, liquidity, types.RoundingDown)
+	}
+	if err != nil {
+		return types.SwapAmount{}, err
+	}
+
+	return types.SwapAmount{
+		OutputAmount:  outputAmount,
+		NextSqrtPrice: nextSqrtPrice,
+		AmountLeft:    big.NewInt(0),
+	}, nil
+}
+
+// GetSwapAmountFromOutput gets the input amount and next sqrt price of an output amount
+// within the [sqrtMinPrice, sqrtMaxPrice] range of the pool. OutputAmount of the result is the input amount.
+func GetSwapAmountFromOutput(
+	sqrtPrice, sqrtMinPrice, sqrtMaxPrice, liquidity, amountOut *big.Int,
+	swapAToB bool,
+) (types.SwapAmount, error) {
+	nextSqrtPrice, err := maths.GetNextSqrtPriceFromOutput(sqrtPrice, liquidity, amountOut, swapAToB)
+	if err != nil {
+		return types.SwapAmount{}, err
+	}
+
+	var inputAmount *big.Int
+	if swapAToB {
+		if nextSqrtPrice.Cmp(sqrtMinPrice) < 0 {
+			return types.SwapAmount{}, fmt.Errorf("nextSqrtPrice(%s) is below sqrtMinPrice(%s)", nextSqrtPrice, sqrtMinPrice)
+		}
+		inputAmount, err = maths.GetDeltaAmountBaseUnsigned(nextSqrtPrice, sqrtPrice, liquidity, types.RoundingUp)
+	} else {
+		if nextSqrtPrice.Cmp(sqrtMaxPrice) > 0 {
+			return types.SwapAmount{}, fmt.Errorf("nextSqrtPrice(%s) is above sqrtMaxPrice(%s)", nextSqrtPrice, sqrtMaxPrice)
+		}
+		inputAmount, err = maths.GetDeltaAmountQuoteUnsigned(sqrtPrice, nextSqrtPrice, liquidity, types.RoundingUp)
+	}
+	if err != nil {
+		return types.SwapAmount{}, err
+	}
+
+	return types.SwapAmount{
+		OutputAmount:  inputAmount,
+		NextSqrtPrice: nextSqrtPrice,
+		AmountLeft:    big.NewInt(0),
+	}, nil
+}
+
+// GetSwapResultFromExactInput gets swap result of an exact input amount.
+func GetSwapResultFromExactInput(
+	pool *cpamm.PoolAccount,
+	swapAToB bool,
+	amountIn *big.Int,
+	hasReferral bool,
+	currentPoint *big.Int,
+) (types.DammV2SwapQuoteResult, error) {
+	feeMode := GetFeeMode(pool.CollectFeeMode, swapAToB, hasReferral)
+
+	tradeFeeNumerator, err := GetTotalFeeNumerator(
+		pool.PoolFees,
+		currentPoint,
+		new(big.Int).SetUint64(pool.ActivationPoint),
+	)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	feeAmount, actualAmountIn := big.NewInt(0), new(big.Int).Set(amountIn)
+	if feeMode.FeesOnInput {
+		out, err := maths.GetExcludedFeeAmount(tradeFeeNumerator, amountIn)
+		if err != nil {
+			return types.DammV2SwapQuoteResult{}, err
+		}
+		feeAmount, actualAmountIn = out.TradingFee, out.ExcludedFeeAmount
+	}
+
+	swapAmount, err := GetSwapAmountFromInput(
+		pool.SqrtPrice.BigInt(),
+		pool.SqrtMinPrice.BigInt(),
+		pool.SqrtMaxPrice.BigInt(),
+		pool.Liquidity.BigInt(),
+		actualAmountIn,
+		swapAToB,
+	)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	actualAmountOut := swapAmount.OutputAmount
+	if !feeMode.FeesOnInput {
+		out, err := maths.GetExcludedFeeAmount(tradeFeeNumerator, swapAmount.OutputAmount)
+		if err != nil {
+			return types.DammV2SwapQuoteResult{}, err
+		}
+		feeAmount, actualAmountOut = out.TradingFee, out.ExcludedFeeAmount
+	}
+
+	return getSwapResult(pool, feeAmount, amountIn, actualAmountIn, actualAmountOut, swapAmount.NextSqrtPrice, hasReferral)
+}
+
+// GetSwapResultFromExactOutput gets swap result of an exact output amount.
+func GetSwapResultFromExactOutput(
+	pool *cpamm.PoolAccount,
+	swapAToB bool,
+	amountOut *big.Int,
+	hasReferral bool,
+	currentPoint *big.Int,
+) (types.DammV2SwapQuoteResult, error) {
+	feeMode := GetFeeMode(pool.CollectFeeMode, swapAToB, hasReferral)
+
+	tradeFeeNumerator, err := GetTotalFeeNumerator(
+		pool.PoolFees,
+		currentPoint,
+		new(big.Int).SetUint64(pool.ActivationPoint),
+	)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	feeAmount, includedFeeAmountOut := big.NewInt(0), new(big.Int).Set(amountOut)
+	if !feeMode.FeesOnInput {
+		out, err := maths.GetIncludedFeeAmount(tradeFeeNumerator, amountOut)
+		if err != nil {
+			return types.DammV2SwapQuoteResult{}, err
+		}
+		feeAmount, includedFeeAmountOut = out.FeeAmount, out.IncludedFeeAmount
+	}
+
+	swapAmount, err := GetSwapAmountFromOutput(
+		pool.SqrtPrice.BigInt(),
+		pool.SqrtMinPrice.BigInt(),
+		pool.SqrtMaxPrice.BigInt(),
+		pool.Liquidity.BigInt(),
+		includedFeeAmountOut,
+		swapAToB,
+	)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	excludedFeeAmountIn := swapAmount.OutputAmount
+	includedFeeAmountIn := new(big.Int).Set(excludedFeeAmountIn)
+	if feeMode.FeesOnInput {
+		out, err := maths.GetIncludedFeeAmount(tradeFeeNumerator, excludedFeeAmountIn)
+		if err != nil {
+			return types.DammV2SwapQuoteResult{}, err
+		}
+		feeAmount, includedFeeAmountIn = out.FeeAmount, out.IncludedFeeAmount
+	}
+
+	return getSwapResult(pool, feeAmount, includedFeeAmountIn, excludedFeeAmountIn, amountOut, swapAmount.NextSqrtPrice, hasReferral)
+}
+
+// SwapQuoteExactIn calculates quote for a swap with exact input amount on a DAMM V2 pool.
+func SwapQuoteExactIn(
+	pool *cpamm.PoolAccount,
+	swapAToB bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+) (types.DammV2SwapQuoteResult, error) {
+	if err := validateSwap(pool, amountIn, slippageBps); err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	result, err := GetSwapResultFromExactInput(pool, swapAToB, amountIn, hasReferral, currentPoint)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	// minimum amount out: amountOut * (10000 - slippageBps) / 10000
+	result.MinimumAmountOut = new(big.Int).Quo(
+		new(big.Int).Mul(
+			new(big.Int).SetUint64(result.OutputAmount),
+			new(big.Int).SetUint64(10_000-slippageBps),
+		),
+		big.NewInt(10_000),
+	).Uint64()
+
+	return result, nil
+}
+
+// SwapQuoteExactInWithTransferFee is SwapQuoteExactIn with Token-2022 transfer fees: the pool receives
+// amountIn less the input transfer fee, and MinimumAmountOut applies to the output less its transfer fee
+// as the program checks it after the output transfer.
+func SwapQuoteExactInWithTransferFee(
+	pool *cpamm.PoolAccount,
+	swapAToB bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	inputTransferFee, outputTransferFee types.TransferFee,
+) (types.DammV2SwapQuoteResult, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return types.DammV2SwapQuoteResult{}, errors.New("amount must be greater than zero")
+	}
+
+	input := maths.CalculateTransferFeeExcludedAmount(inputTransferFee, amountIn)
+	if input.Amount.Sign() == 0 {
+		return types.DammV2SwapQuoteResult{}, errors.New("amountIn after transfer fee cannot be zero")
+	}
+
+	result, err := SwapQuoteExactIn(pool, swapAToB, input.Amount, slippageBps, hasReferral, currentPoint)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	output := maths.CalculateTransferFeeExcludedAmount(
+		outputTransferFee, new(big.Int).SetUint64(result.OutputAmount),
+	)
+
+	// minimum amount out: (amountOut - outputTransferFee) * (10000 - slippageBps) / 10000
+	result.MinimumAmountOut = new(big.Int).Quo(
+		new(big.Int).Mul(output.Amount, new(big.Int).SetUint64(10_000-slippageBps)),
+		big.NewInt(10_000),
+	).Uint64()
+	result.TransferFeeResult = types.TransferFeeResult{
+		InputTransferFee:  input.TransferFee.Uint64(),
+		OutputTransferFee: output.TransferFee.Uint64(),
+		NetOutputAmount:   output.Amount.Uint64(),
+	}
+	return result, nil
+}
+
+// SwapQuoteExactOut calculates quote for a swap with exact output amount on a DAMM V2 pool.
+func SwapQuoteExactOut(
+	pool *cpamm.PoolAccount,
+	swapAToB bool,
+	amountOut *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+) (types.DammV2SwapQuoteResult, error) {
+	if err := validateSwap(pool, amountOut, slippageBps); err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	result, err := GetSwapResultFromExactOutput(pool, swapAToB, amountOut, hasReferral, currentPoint)
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	// maximum amount in: amountIn * (10000 + slippageBps) / 10000
+	maximumAmountIn := new(big.Int).Quo(
+		new(big.Int).Mul(
+			new(big.Int).SetUint64(result.IncludedFeeInputAmount),
+			new(big.Int).SetUint64(10_000+slippageBps),
+		),
+		big.NewInt(10_000),
+	)
+	if !maximumAmountIn.IsUint64() {
+		return types.DammV2SwapQuoteResult{}, fmt.Errorf("cannot fit maximumAmountIn(%s) into uint64", maximumAmountIn)
+	}
+	result.MaximumAmountIn = maximumAmountIn.Uint64()
+
+	return result, nil
+}
+
+func getSwapResult(
+	pool *cpamm.PoolAccount,
+	feeAmount, includedFeeAmountIn, excludedFeeAmountIn, amountOut, nextSqrtPrice *big.Int,
+	hasReferral bool,
+) (types.DammV2SwapQuoteResult, error) {
+	fees, err := SplitFees(pool.PoolFees, feeAmount, hasReferral, !pool.Partner.Equals(solana.PublicKey{}))
+	if err != nil {
+		return types.DammV2SwapQuoteResult{}, err
+	}
+
+	if !includedFeeAmountIn.IsUint64() ||
+		!excludedFeeAmountIn.IsUint64() ||
+		!amountOut.IsUint64() ||
+		!feeAmount.IsUint64() {
+		return types.DammV2SwapQuoteResult{},
+			fmt.Errorf(
+				"one of the values cannot fit into uint64: "+
+					"IncludedFeeInputAmount(%s), ExcludedFeeInputAmount(%s), OutputAmount(%s), FeeAmount(%s)",
+				includedFeeAmountIn,
+				excludedFeeAmountIn,
+				amountOut,
+				feeAmount,
+			)
+	}
+
+	return types.DammV2SwapQuoteResult{
+		SwapResult: cpamm.SwapResult{
+			OutputAmount:  amountOut.Uint64(),
+			NextSqrtPrice: maths.MustBigIntToUint128(nextSqrtPrice),
+			LpFee:         fees.LpFee.Uint64(),
+			ProtocolFee:   fees.ProtocolFee.Uint64(),
+			PartnerFee:    fees.PartnerFee.Uint64(),
+			ReferralFee:   fees.ReferralFee.Uint64(),
+		},
+		IncludedFeeInputAmount: includedFeeAmountIn.Uint64(),
+		ExcludedFeeInputAmount: excludedFeeAmountIn.Uint64(),
+	}, nil
+}
+
+func validateSwap(pool *cpamm.PoolAccount, amount *big.Int, slippageBps uint64) error {
+	if pool == nil {
+		return errors.New("pool cannot be nil")
+	}
+
+	if pool.PoolStatus != 0 {
+		return errors.New("pool is disabled")
+	}
+
+	if amount == nil || amount.Sign() <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+
+	if slippageBps > 10_000 {
+		return fmt.Errorf("slippageBps(%d) cannot exceed 10000", slippageBps)
+	}
+
+	if pool.Liquidity.BigInt().Sign() == 0 {
+		return errors.New("pool has no liquidity")
+	}
+
+	return nil
+}
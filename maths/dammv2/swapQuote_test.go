@@ -0,0 +1,184 @@
+package dammv2_test
+
+import (
+	"dbcGoSDK/constants"
+	cpamm "dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/maths"
+	mathsDammv2 "dbcGoSDK/maths/dammv2"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPool() *cpamm.PoolAccount {
+	return &cpamm.PoolAccount{
+		PoolFees: cpamm.PoolFeesStruct{
+			BaseFee: cpamm.BaseFeeStruct{
+				CliffFeeNumerator: 500_000_000,
+				NumberOfPeriod:    20,
+				PeriodFrequency:   10,
+				ReductionFactor:   10_000_000,
+			},
+			ProtocolFeePercent: 20,
+			PartnerFeePercent:  50,
+			ReferralFeePercent: 20,
+		},
+		Partner:         solana.NewWallet().PublicKey(),
+		Liquidity:       maths.MustBigIntToUint128(new(big.Int).Lsh(big.NewInt(1_000_000_000_000), 64)),
+		SqrtMinPrice:    maths.MustBigIntToUint128(constants.MinSqrtPrice),
+		SqrtMaxPrice:    maths.MustBigIntToUint128(constants.MaxSqrtPrice),
+		SqrtPrice:       maths.MustBigIntToUint128(maths.Q64(1)),
+		ActivationPoint: 100,
+		CollectFeeMode:  mathsDammv2.CollectFeeModeOnlyB,
+	}
+}
+
+func TestGetFeeMode(t *testing.T) {
+	assert.Equal(t, types.FeeMode{}, mathsDammv2.GetFeeMode(mathsDammv2.CollectFeeModeBothToken, true, false))
+	assert.Equal(t, types.FeeMode{FeesOnBaseToken: true}, mathsDammv2.GetFeeMode(mathsDammv2.CollectFeeModeBothToken, false, false))
+	assert.Equal(t, types.FeeMode{HasReferral: true}, mathsDammv2.GetFeeMode(mathsDammv2.CollectFeeModeOnlyB, true, true))
+	assert.Equal(t, types.FeeMode{FeesOnInput: true}, mathsDammv2.GetFeeMode(mathsDammv2.CollectFeeModeOnlyB, false, false))
+}
+
+func TestGetTotalFeeNumerator(t *testing.T) {
+	pool := newPool()
+	activationPoint := big.NewInt(100)
+
+	for _, tc := range []struct {
+		currentPoint int64
+		expected     int64
+	}{
+		{50, 300_000_000},  // before activation, last period
+		{100, 500_000_000}, // cliff
+		{135, 470_000_000}, // 3 periods
+		{1_000, 300_000_000},
+	} {
+		got, err := mathsDammv2.GetTotalFeeNumerator(pool.PoolFees, big.NewInt(tc.currentPoint), activationPoint)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(tc.expected), got, "currentPoint %d", tc.currentPoint)
+	}
+
+	pool.PoolFees.DynamicFee = cpamm.DynamicFeeStruct{
+		Initialized:           1,
+		BinStep:               1,
+		VariableFeeControl:    100_000,
+		VolatilityAccumulator: maths.MustBigIntToUint128(big.NewInt(10_000_000)),
+	}
+	// (10_000_000 * 1)^2 * 100_000 / 1e11 = 100_000_000
+	assert.Equal(t, big.NewInt(100_000_000), mathsDammv2.GetVariableFeeNumerator(pool.PoolFees.DynamicFee))
+
+	got, err := mathsDammv2.GetTotalFeeNumerator(pool.PoolFees, big.NewInt(135), activationPoint)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(570_000_000), got)
+}
+
+func TestSplitFees(t *testing.T) {
+	fees, err := mathsDammv2.SplitFees(newPool().PoolFees, big.NewInt(1_000), true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(800), fees.LpFee)
+	assert.Equal(t, big.NewInt(40), fees.ReferralFee)
+	assert.Equal(t, big.NewInt(80), fees.PartnerFee)
+	assert.Equal(t, big.NewInt(80), fees.ProtocolFee)
+
+	fees, err = mathsDammv2.SplitFees(newPool().PoolFees, big.NewInt(1_000), false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(200), fees.ProtocolFee)
+	assert.Equal(t, big.NewInt(0), fees.PartnerFee)
+}
+
+func TestSwapQuote(t *testing.T) {
+	pool := newPool()
+	currentPoint := big.NewInt(1_000)
+	amountIn := big.NewInt(1_000_000_000)
+
+	t.Run("a to b collects the fee on output", func(t *testing.T) {
+		got, err := mathsDammv2.SwapQuoteExactIn(pool, true, amountIn, 100, false, currentPoint)
+		assert.NoError(t, err)
+
+		assert.Equal(t, amountIn.Uint64(), got.IncludedFeeInputAmount)
+		assert.Equal(t, amountIn.Uint64(), got.ExcludedFeeInputAmount)
+		// 1e9 * 1e12 / (1e12 + 1e9) = 999_000_999, minus 30% fee rounded up
+		assert.Equal(t, uint64(699_300_699), got.OutputAmount)
+		assert.Equal(t, got.OutputAmount*9_900/10_000, got.MinimumAmountOut)
+		assert.Equal(t, uint64(299_700_300), got.LpFee+got.ProtocolFee+got.PartnerFee+got.ReferralFee)
+		assert.True(t, got.NextSqrtPrice.BigInt().Cmp(pool.SqrtPrice.BigInt()) < 0)
+	})
+
+	t.Run("b to a collects the fee on input", func(t *testing.T) {
+		got, err := mathsDammv2.SwapQuoteExactIn(pool, false, amountIn, 0, true, currentPoint)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint64(700_000_000), got.ExcludedFeeInputAmount)
+		assert.Equal(t, uint64(300_000_000), got.LpFee+got.ProtocolFee+got.PartnerFee+got.ReferralFee)
+		assert.NotZero(t, got.ReferralFee)
+		assert.Equal(t, got.OutputAmount, got.MinimumAmountOut)
+		assert.True(t, got.NextSqrtPrice.BigInt().Cmp(pool.SqrtPrice.BigInt()) > 0)
+	})
+
+	t.Run("exact out covers the exact in output", func(t *testing.T) {
+		for _, swapAToB := range []bool{true, false} {
+			exactIn, err := mathsDammv2.SwapQuoteExactIn(pool, swapAToB, amountIn, 0, false, currentPoint)
+			assert.NoError(t, err)
+
+			exactOut, err := mathsDammv2.SwapQuoteExactOut(
+				pool, swapAToB, new(big.Int).SetUint64(exactIn.OutputAmount), 100, false, currentPoint,
+			)
+			assert.NoError(t, err)
+
+			assert.Equal(t, exactIn.OutputAmount, exactOut.OutputAmount)
+			assert.True(t, exactOut.IncludedFeeInputAmount <= amountIn.Uint64())
+			assert.True(t, exactOut.IncludedFeeInputAmount >= amountIn.Uint64()-2)
+			assert.Equal(t, exactOut.IncludedFeeInputAmount*10_100/10_000, exactOut.MaximumAmountIn)
+		}
+	})
+
+	t.Run("exact in with transfer fee", func(t *testing.T) {
+		inputTransferFee := types.TransferFee{TransferFeeBasisPoints: 100, MaximumFee: 1_000_000_000}
+		outputTransferFee := types.TransferFee{TransferFeeBasisPoints: 50, MaximumFee: 1_000}
+
+		got, err := mathsDammv2.SwapQuoteExactInWithTransferFee(
+			pool, true, amountIn, 100, false, currentPoint, inputTransferFee, outputTransferFee,
+		)
+		assert.NoError(t, err)
+
+		withoutFee, err := mathsDammv2.SwapQuoteExactIn(pool, true, big.NewInt(990_000_000), 100, false, currentPoint)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint64(10_000_000), got.InputTransferFee)
+		assert.Equal(t, uint64(1_000), got.OutputTransferFee)
+		assert.Equal(t, withoutFee.OutputAmount, got.OutputAmount)
+		assert.Equal(t, got.OutputAmount-got.OutputTransferFee, got.NetOutputAmount)
+		assert.Equal(t, got.NetOutputAmount*9_900/10_000, got.MinimumAmountOut)
+
+		noFee, err := mathsDammv2.SwapQuoteExactInWithTransferFee(
+			pool, true, amountIn, 100, false, currentPoint, types.TransferFee{}, types.TransferFee{},
+		)
+		assert.NoError(t, err)
+		exactIn, err := mathsDammv2.SwapQuoteExactIn(pool, true, amountIn, 100, false, currentPoint)
+		assert.NoError(t, err)
+		assert.Equal(t, exactIn.SwapResult, noFee.SwapResult)
+		assert.Equal(t, exactIn.MinimumAmountOut, noFee.MinimumAmountOut)
+		assert.Equal(t, exactIn.OutputAmount, noFee.NetOutputAmount)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := mathsDammv2.SwapQuoteExactIn(pool, true, big.NewInt(0), 0, false, currentPoint)
+		assert.Error(t, err)
+
+		_, err = mathsDammv2.SwapQuoteExactIn(pool, true, amountIn, 10_001, false, currentPoint)
+		assert.Error(t, err)
+
+		narrow := newPool()
+		narrow.SqrtMinPrice = maths.MustBigIntToUint128(maths.Q64(0.9999))
+		_, err = mathsDammv2.SwapQuoteExactIn(narrow, true, amountIn, 0, false, currentPoint)
+		assert.Error(t, err)
+
+		disabled := newPool()
+		disabled.PoolStatus = 1
+		_, err = mathsDammv2.SwapQuoteExactIn(disabled, true, amountIn, 0, false, currentPoint)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,206 @@
+package dynamicvault
+
+import (
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// LockedProfitDegradationDenominator is the denominator of LockedProfitTracker.LockedProfitDegradation.
+const LockedProfitDegradationDenominator = 1_000_000_000_000
+
+// GetLockedProfit gets the profit that is still locked at currentTime.
+//
+//	ratio = (currentTime - lastReport) * lockedProfitDegradation
+//	lockedProfit = lastUpdatedLockedProfit * (denominator - ratio) / denominator
+func GetLockedProfit(
+	tracker dynamic_vault.LockedProfitTracker,
+	currentTime uint64,
+) *big.Int {
+	if currentTime <= tracker.LastReport {
+		return new(big.Int).SetUint64(tracker.LastUpdatedLockedProfit)
+	}
+
+	denominator := big.NewInt(LockedProfitDegradationDenominator)
+	ratio := new(big.Int).Mul(
+		new(big.Int).SetUint64(currentTime-tracker.LastReport),
+		new(big.Int).SetUint64(tracker.LockedProfitDegradation),
+	)
+	if ratio.Cmp(denominator) > 0 {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).Quo(
+		new(big.Int).Mul(
+			new(big.Int).SetUint64(tracker.LastUpdatedLockedProfit),
+			new(big.Int).Sub(denominator, ratio),
+		),
+		denominator,
+	)
+}
+
+// GetUnlockedAmount gets the vault amount that backs the LP supply at currentTime.
+func GetUnlockedAmount(vault *dynamic_vault.VaultAccount, currentTime uint64) *big.Int {
+	unlockedAmount := new(big.Int).Sub(
+		new(big.Int).SetUint64(vault.TotalAmount),
+		GetLockedProfit(vault.LockedProfitTracker, currentTime),
+	)
+	if unlockedAmount.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return unlockedAmount
+}
+
+// GetAmountByShare gets the underlying amount of an LP share, rounding down.
+//
+//	amount = share * unlockedAmount / totalSupply
+func GetAmountByShare(share, unlockedAmount, totalSupply *big.Int) *big.Int {
+	if totalSupply.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Quo(new(big.Int).Mul(share, unlockedAmount), totalSupply)
+}
+
+// GetUnmintAmount gets the LP share of an underlying amount, rounding down.
+//
+//	share = amount * totalSupply / unlockedAmount
+func GetUnmintAmount(amount, unlockedAmount, totalSupply *big.Int) *big.Int {
+	if unlockedAmount.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Quo(new(big.Int).Mul(amount, totalSupply), unlockedAmount)
+}
+
+// GetLpAmountByAmount gets the LP share of an underlying amount with the given rounding.
+// Round up to get the LP amount to burn for an exact withdraw amount.
+func GetLpAmountByAmount(amount, unlockedAmount, totalSupply *big.Int, rounding types.Rounding) (*big.Int, error) {
+	if unlockedAmount.Sign() == 0 {
+		return nil, errors.New("vault unlocked amount is zero")
+	}
+
+	prod := new(big.Int).Mul(amount, totalSupply)
+	if rounding == types.RoundingUp {
+		prod.Add(prod, new(big.Int).Sub(unlockedAmount, big.NewInt(1)))
+	}
+	return prod.Quo(prod, unlockedAmount), nil
+}
+
+// GetDepositLpAmount gets the LP amount minted for a deposit of tokenAmount at currentTime.
+// The first deposit mints LP one to one.
+func GetDepositLpAmount(
+	vault *dynamic_vault.VaultAccount,
+	lpSupply, tokenAmount *big.Int,
+	currentTime uint64,
+) (*big.Int, error) {
+	if tokenAmount.Sign() <= 0 {
+		return nil, errors.New("tokenAmount must be greater than zero")
+	}
+
+	if lpSupply.Sign() == 0 {
+		return new(big.Int).Set(tokenAmount), nil
+	}
+
+	lpAmount, err := GetLpAmountByAmount(
+		tokenAmount,
+		GetUnlockedAmount(vault, currentTime),
+		lpSupply,
+		types.RoundingDown,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lpAmount.Sign() == 0 {
+		return nil, fmt.Errorf("tokenAmount(%s) is too small to mint any lp", tokenAmount)
+	}
+	return lpAmount, nil
+}
+
+// GetWithdrawAmount gets the underlying amount received for burning unmintAmount LP at currentTime.
+func GetWithdrawAmount(
+	vault *dynamic_vault.VaultAccount,
+	lpSupply, unmintAmount *big.Int,
+	currentTime uint64,
+) (*big.Int, error) {
+	if unmintAmount.Sign() <= 0 {
+		return nil, errors.New("unmintAmount must be greater than zero")
+	}
+
+	if unmintAmount.Cmp(lpSupply) > 0 {
+		return nil, fmt.Errorf("unmintAmount(%s) cannot exceed lp supply(%s)", unmintAmount, lpSupply)
+	}
+
+	return GetAmountByShare(unmintAmount, GetUnlockedAmount(vault, currentTime), lpSupply), nil
+}
+
+// GetWithdrawLpAmount gets the LP amount to burn to receive at least tokenAmount at currentTime.
+func GetWithdrawLpAmount(
+	vault *dynamic_vault.VaultAccount,
+	lpSupply, tokenAmount *big.Int,
+	currentTime uint64,
+) (*big.Int, error) {
+	unlockedAmount := GetUnlockedAmount(vault, currentTime)
+	if tokenAmount.Cmp(unlockedAmount) > 0 {
+		return nil, fmt.Errorf("tokenAmount(%s) cannot exceed vault unlocked amount(%s)", tokenAmount, unlockedAmount)
+	}
+
+	return GetLpAmountByAmount(tokenAmount, unlockedAmount, lpSupply, types.RoundingUp)
+}
+
+// GetDepositQuote quotes the LP minted for a deposit of tokenAmount at currentTime, with the
+// minimum LP accepted after slippageBps.
+func GetDepositQuote(
+	vault *dynamic_vault.VaultAccount,
+	lpSupply, tokenAmount *big.Int,
+	slippageBps uint64,
+	currentTime uint64,
+) (types.VaultDepositQuote, error) {
+	if slippageBps > 10_000 {
+		return types.VaultDepositQuote{}, fmt.Errorf("slippageBps(%d) cannot exceed 10000", slippageBps)
+	}
+
+	lpAmount, err := GetDepositLpAmount(vault, lpSupply, tokenAmount, currentTime)
+	if err != nil {
+		return types.VaultDepositQuote{}, err
+	}
+
+	return types.VaultDepositQuote{
+		TokenAmount: new(big.Int).Set(tokenAmount),
+		LpAmount:    lpAmount,
+		MinLpAmount: applySlippage(lpAmount, slippageBps),
+	}, nil
+}
+
+// GetWithdrawQuote quotes the tokens received for burning lpAmount at currentTime, with the
+// minimum amount accepted after slippageBps.
+func GetWithdrawQuote(
+	vault *dynamic_vault.VaultAccount,
+	lpSupply, lpAmount *big.Int,
+	slippageBps uint64,
+	currentTime uint64,
+) (types.VaultWithdrawQuote, error) {
+	if slippageBps > 10_000 {
+		return types.VaultWithdrawQuote{}, fmt.Errorf("slippageBps(%d) cannot exceed 10000", slippageBps)
+	}
+
+	tokenAmount, err := GetWithdrawAmount(vault, lpSupply, lpAmount, currentTime)
+	if err != nil {
+		return types.VaultWithdrawQuote{}, err
+	}
+
+	return types.VaultWithdrawQuote{
+		LpAmount:       new(big.Int).Set(lpAmount),
+		TokenAmount:    tokenAmount,
+		MinTokenAmount: applySlippage(tokenAmount, slippageBps),
+	}, nil
+}
+
+// applySlippage gets amount * (10000 - slippageBps) / 10000.
+func applySlippage(amount *big.Int, slippageBps uint64) *big.Int {
+	return new(big.Int).Quo(
+		new(big.Int).Mul(amount, new(big.Int).SetUint64(10_000-slippageBps)),
+		big.NewInt(10_000),
+	)
+}
@@ -0,0 +1,115 @@
+package dynamicvault_test
+
+import (
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
+	mathsDynamicVault "dbcGoSDK/maths/dynamicVault"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLockedProfit(t *testing.T) {
+	// fully unlocked after 1000 seconds
+	tracker := dynamic_vault.LockedProfitTracker{
+		LastUpdatedLockedProfit: 1_000_000,
+		LastReport:              100,
+		LockedProfitDegradation: mathsDynamicVault.LockedProfitDegradationDenominator / 1_000,
+	}
+
+	assert.Equal(t, big.NewInt(1_000_000), mathsDynamicVault.GetLockedProfit(tracker, 50))
+	assert.Equal(t, big.NewInt(1_000_000), mathsDynamicVault.GetLockedProfit(tracker, 100))
+	assert.Equal(t, big.NewInt(750_000), mathsDynamicVault.GetLockedProfit(tracker, 350))
+	assert.Equal(t, big.NewInt(0), mathsDynamicVault.GetLockedProfit(tracker, 1_100))
+	assert.Equal(t, big.NewInt(0), mathsDynamicVault.GetLockedProfit(tracker, 5_000))
+
+	vault := &dynamic_vault.VaultAccount{TotalAmount: 10_000_000, LockedProfitTracker: tracker}
+	assert.Equal(t, big.NewInt(9_250_000), mathsDynamicVault.GetUnlockedAmount(vault, 350))
+	assert.Equal(t, big.NewInt(10_000_000), mathsDynamicVault.GetUnlockedAmount(vault, 1_100))
+}
+
+func TestVaultShareConversion(t *testing.T) {
+	unlocked, supply := big.NewInt(3_000), big.NewInt(1_000)
+
+	assert.Equal(t, big.NewInt(3), mathsDynamicVault.GetAmountByShare(big.NewInt(1), unlocked, supply))
+	assert.Zero(t, mathsDynamicVault.GetUnmintAmount(big.NewInt(2), unlocked, supply).Sign())
+	assert.Equal(t, big.NewInt(1), mathsDynamicVault.GetUnmintAmount(big.NewInt(5), unlocked, supply))
+
+	assert.Equal(t, big.NewInt(0), mathsDynamicVault.GetAmountByShare(big.NewInt(1), unlocked, big.NewInt(0)))
+	assert.Equal(t, big.NewInt(0), mathsDynamicVault.GetUnmintAmount(big.NewInt(1), big.NewInt(0), supply))
+}
+
+func TestVaultDepositWithdraw(t *testing.T) {
+	vault := &dynamic_vault.VaultAccount{
+		TotalAmount: 3_000_100,
+		LockedProfitTracker: dynamic_vault.LockedProfitTracker{
+			LastUpdatedLockedProfit: 100,
+			LastReport:              1_000,
+			LockedProfitDegradation: mathsDynamicVault.LockedProfitDegradationDenominator / 100,
+		},
+	}
+	lpSupply := big.NewInt(1_000_000)
+
+	t.Run("first deposit mints one to one", func(t *testing.T) {
+		got, err := mathsDynamicVault.GetDepositLpAmount(vault, big.NewInt(0), big.NewInt(500), 1_000)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(500), got)
+	})
+
+	t.Run("deposit rounds down", func(t *testing.T) {
+		// locked profit still counts against the deposit price
+		got, err := mathsDynamicVault.GetDepositLpAmount(vault, lpSupply, big.NewInt(3_001), 1_000)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(1_000), got)
+
+		_, err = mathsDynamicVault.GetDepositLpAmount(vault, lpSupply, big.NewInt(2), 1_000)
+		assert.Error(t, err)
+	})
+
+	t.Run("withdraw", func(t *testing.T) {
+		got, err := mathsDynamicVault.GetWithdrawAmount(vault, lpSupply, big.NewInt(1_000), 1_000)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(3_000), got)
+
+		// locked profit is fully released after 100 seconds
+		got, err = mathsDynamicVault.GetWithdrawAmount(vault, lpSupply, lpSupply, 1_100)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(3_000_100), got)
+
+		_, err = mathsDynamicVault.GetWithdrawAmount(vault, lpSupply, big.NewInt(1_000_001), 1_100)
+		assert.Error(t, err)
+	})
+
+	t.Run("withdraw lp amount rounds up", func(t *testing.T) {
+		lpAmount, err := mathsDynamicVault.GetWithdrawLpAmount(vault, lpSupply, big.NewInt(3_001), 1_000)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(1_001), lpAmount)
+
+		got, err := mathsDynamicVault.GetWithdrawAmount(vault, lpSupply, lpAmount, 1_000)
+		assert.NoError(t, err)
+		assert.True(t, got.Cmp(big.NewInt(3_001)) >= 0)
+
+		_, err = mathsDynamicVault.GetWithdrawLpAmount(vault, lpSupply, big.NewInt(3_000_001), 1_000)
+		assert.Error(t, err)
+	})
+}
+
+func TestVaultQuotes(t *testing.T) {
+	vault := &dynamic_vault.VaultAccount{TotalAmount: 3_000_000}
+	lpSupply := big.NewInt(1_000_000)
+
+	deposit, err := mathsDynamicVault.GetDepositQuote(vault, lpSupply, big.NewInt(300_000), 100, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100_000), deposit.LpAmount)
+	assert.Equal(t, big.NewInt(99_000), deposit.MinLpAmount)
+
+	withdraw, err := mathsDynamicVault.GetWithdrawQuote(vault, lpSupply, deposit.LpAmount, 50, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(300_000), withdraw.TokenAmount)
+	assert.Equal(t, big.NewInt(298_500), withdraw.MinTokenAmount)
+
+	_, err = mathsDynamicVault.GetDepositQuote(vault, lpSupply, big.NewInt(300_000), 10_001, 0)
+	assert.Error(t, err)
+	_, err = mathsDynamicVault.GetWithdrawQuote(vault, lpSupply, big.NewInt(0), 0, 0)
+	assert.Error(t, err)
+}
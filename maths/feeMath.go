@@ -5,6 +5,7 @@ import (
 	"dbcGoSDK/generated/dbc"
 	mathsPoolfees "dbcGoSDK/maths/poolFees"
 	"dbcGoSDK/types"
+	"errors"
 	"fmt"
 	"math/big"
 )
@@ -300,3 +301,100 @@ func SplitFees(
 	}, nil
 
 }
+
+// GetDeltaBinId gets the number of bins crossed between two sqrt prices, doubled as in the program.
+//
+//	delta_bin_id = ((upper << 64) / lower - 1) / bin_step_u128 * 2
+func GetDeltaBinId(binStepU128, sqrtPriceA, sqrtPriceB *big.Int) (*big.Int, error) {
+	upper, lower := sqrtPriceA, sqrtPriceB
+	if sqrtPriceA.Cmp(sqrtPriceB) <= 0 {
+		upper, lower = sqrtPriceB, sqrtPriceA
+	}
+
+	if lower.Sign() == 0 || binStepU128.Sign() == 0 {
+		return nil, errors.New("GetDeltaBinId: sqrt price and bin step cannot be zero")
+	}
+
+	priceRatio := new(big.Int).Quo(new(big.Int).Lsh(upper, constants.RESOLUTION), lower)
+	deltaBinId := priceRatio.Sub(priceRatio, constants.OneQ64)
+	deltaBinId.Quo(deltaBinId, binStepU128)
+	return deltaBinId.Mul(deltaBinId, big.NewInt(2)), nil
+}
+
+// UpdateVolatilityReferences updates the volatility tracker references before a swap,
+// decaying the volatility reference once the filter period has elapsed.
+func UpdateVolatilityReferences(
+	dynamicFee dbc.DynamicFeeConfig,
+	volatilityTracker dbc.VolatilityTracker,
+	sqrtPrice *big.Int,
+	currentTimestamp uint64,
+) (dbc.VolatilityTracker, error) {
+	if !mathsPoolfees.IsDynamicFeeEnabled(dynamicFee) {
+		return volatilityTracker, nil
+	}
+
+	if currentTimestamp < volatilityTracker.LastUpdateTimestamp {
+		return dbc.VolatilityTracker{}, fmt.Errorf(
+			"UpdateVolatilityReferences: currentTimestamp(%d) is before lastUpdateTimestamp(%d)",
+			currentTimestamp, volatilityTracker.LastUpdateTimestamp,
+		)
+	}
+
+	elapsed := currentTimestamp - volatilityTracker.LastUpdateTimestamp
+	if elapsed < uint64(dynamicFee.FilterPeriod) {
+		return volatilityTracker, nil
+	}
+
+	volatilityTracker.SqrtPriceReference = MustBigIntToUint128(sqrtPrice)
+
+	volatilityReference := big.NewInt(0)
+	if elapsed < uint64(dynamicFee.DecayPeriod) {
+		volatilityReference = new(big.Int).Quo(
+			new(big.Int).Mul(
+				volatilityTracker.VolatilityAccumulator.BigInt(),
+				new(big.Int).SetUint64(uint64(dynamicFee.ReductionFactor)),
+			),
+			big.NewInt(constants.BasisPointMax),
+		)
+	}
+	volatilityTracker.VolatilityReference = MustBigIntToUint128(volatilityReference)
+
+	return volatilityTracker, nil
+}
+
+// UpdateVolatilityAccumulator updates the volatility accumulator after a swap moved the price to sqrtPrice.
+// The last update timestamp only moves when at least one bin was crossed.
+func UpdateVolatilityAccumulator(
+	dynamicFee dbc.DynamicFeeConfig,
+	volatilityTracker dbc.VolatilityTracker,
+	sqrtPrice *big.Int,
+	currentTimestamp uint64,
+) (dbc.VolatilityTracker, error) {
+	if !mathsPoolfees.IsDynamicFeeEnabled(dynamicFee) {
+		return volatilityTracker, nil
+	}
+
+	deltaBinId, err := GetDeltaBinId(
+		dynamicFee.BinStepU128.BigInt(),
+		sqrtPrice,
+		volatilityTracker.SqrtPriceReference.BigInt(),
+	)
+	if err != nil {
+		return dbc.VolatilityTracker{}, err
+	}
+
+	volatilityAccumulator := new(big.Int).Add(
+		volatilityTracker.VolatilityReference.BigInt(),
+		new(big.Int).Mul(deltaBinId, big.NewInt(constants.BasisPointMax)),
+	)
+	if maxVolatilityAccumulator := new(big.Int).SetUint64(uint64(dynamicFee.MaxVolatilityAccumulator)); volatilityAccumulator.Cmp(maxVolatilityAccumulator) > 0 {
+		volatilityAccumulator = maxVolatilityAccumulator
+	}
+	volatilityTracker.VolatilityAccumulator = MustBigIntToUint128(volatilityAccumulator)
+
+	if deltaBinId.Sign() > 0 {
+		volatilityTracker.LastUpdateTimestamp = currentTimestamp
+	}
+
+	return volatilityTracker, nil
+}
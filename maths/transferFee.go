@@ -0,0 +1,331 @@
+package maths
+
+import (
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// GetEpochTransferFee gets the transfer fee that applies in an epoch, a nil config has no fee.
+func GetEpochTransferFee(config *types.TransferFeeConfig, epoch uint64) types.TransferFee {
+	if config == nil {
+		return types.TransferFee{}
+	}
+	if epoch >= config.NewerTransferFee.Epoch {
+		return config.NewerTransferFee
+	}
+	return config.OlderTransferFee
+}
+
+// CalculateTransferFee calculates the fee withheld when transferring amount.
+//
+//	fee = min(ceil(amount * transferFeeBasisPoints / 10000), maximumFee)
+func CalculateTransferFee(transferFee types.TransferFee, amount *big.Int) *big.Int {
+	if transferFee.TransferFeeBasisPoints == 0 || amount.Sign() == 0 {
+		return big.NewInt(0)
+	}
+
+	fee, _ := MulDiv(
+		amount,
+		big.NewInt(int64(transferFee.TransferFeeBasisPoints)),
+		big.NewInt(constants.BasisPointMax),
+		types.RoundingUp,
+	)
+	if maximumFee := new(big.Int).SetUint64(transferFee.MaximumFee); fee.Cmp(maximumFee) > 0 {
+		return maximumFee
+	}
+	return fee
+}
+
+// CalculateTransferFeeExcludedAmount calculates the amount received when transferring an amount.
+func CalculateTransferFeeExcludedAmount(
+	transferFee types.TransferFee,
+	transferFeeIncludedAmount *big.Int,
+) types.TransferFeeAmountResult {
+	fee := CalculateTransferFee(transferFee, transferFeeIncludedAmount)
+	return types.TransferFeeAmountResult{
+		Amount:      new(big.Int).Sub(transferFeeIncludedAmount, fee),
+		TransferFee: fee,
+	}
+}
+
+// CalculateTransferFeeIncludedAmount calculates the amount to transfer so that the receiver gets
+// transferFeeExcludedAmount, it mirrors calculate_pre_fee_amount of the token-2022 program.
+func CalculateTransferFeeIncludedAmount(
+	transferFee types.TransferFee,
+	transferFeeExcludedAmount *big.Int,
+) (types.TransferFeeAmountResult, error) {
+	if transferFee.TransferFeeBasisPoints == 0 || transferFeeExcludedAmount.Sign() == 0 {
+		return types.TransferFeeAmountResult{
+			Amount:      new(big.Int).Set(transferFeeExcludedAmount),
+			TransferFee: big.NewInt(0),
+		}, nil
+	}
+
+	maximumFee := new(big.Int).SetUint64(transferFee.MaximumFee)
+	if transferFee.TransferFeeBasisPoints >= constants.BasisPointMax {
+		return types.TransferFeeAmountResult{
+			Amount:      new(big.Int).Add(transferFeeExcludedAmount, maximumFee),
+			TransferFee: maximumFee,
+		}, nil
+	}
+
+	rawIncludedAmount, err := MulDiv(
+		transferFeeExcludedAmount,
+		big.NewInt(constants.BasisPointMax),
+		big.NewInt(constants.BasisPointMax-int64(transferFee.TransferFeeBasisPoints)),
+		types.RoundingUp,
+	)
+	if err != nil {
+		return types.TransferFeeAmountResult{}, err
+	}
+
+	if new(big.Int).Sub(rawIncludedAmount, transferFeeExcludedAmount).Cmp(maximumFee) >= 0 {
+		return types.TransferFeeAmountResult{
+			Amount:      new(big.Int).Add(transferFeeExcludedAmount, maximumFee),
+			TransferFee: maximumFee,
+		}, nil
+	}
+
+	return types.TransferFeeAmountResult{
+		Amount:      rawIncludedAmount,
+		TransferFee: new(big.Int).Sub(rawIncludedAmount, transferFeeExcludedAmount),
+	}, nil
+}
+
+// getInputOutputTransferFee gets the input and output transfer fee of a swap direction.
+func getInputOutputTransferFee(
+	swapBaseForQuote bool,
+	transferFeeParam types.TransferFeeParam,
+) (input, output types.TransferFee) {
+	base := GetEpochTransferFee(transferFeeParam.BaseTransferFeeConfig, transferFeeParam.Epoch)
+	quote := GetEpochTransferFee(transferFeeParam.QuoteTransferFeeConfig, transferFeeParam.Epoch)
+	if swapBaseForQuote {
+		return base, quote
+	}
+	return quote, base
+}
+
+// getNetMinimumAmountOut applies slippage to the net output amount, the amount left after the output
+// transfer fee. A minimum on the net amount holds whether the program compares it before or after the
+// output transfer fee is withheld, so the swap does not revert on the fee alone.
+//
+//	minimumAmountOut = netOutputAmount * (10000 - slippageBps) / 10000
+func getNetMinimumAmountOut(netOutputAmount *big.Int, slippageBps uint64) (uint64, error) {
+	minimumAmountOut := new(big.Int).Quo(
+		new(big.Int).Mul(netOutputAmount, new(big.Int).SetUint64(10_000-slippageBps)),
+		big.NewInt(10_000),
+	)
+	if !minimumAmountOut.IsUint64() {
+		return 0, fmt.Errorf("cannot fit minimumAmountOut(%s) into uint64", minimumAmountOut)
+	}
+	return minimumAmountOut.Uint64(), nil
+}
+
+// SwapQuoteWithTransferFee calculates quote for a swap with exact input amount (for swapQuote v1),
+// deducting Token-2022 transfer fees on the input and output legs.
+// NetOutputAmount is what the user receives and MinimumAmountOut applies to it.
+func SwapQuoteWithTransferFee(
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	swapBaseForQuote bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	transferFeeParam types.TransferFeeParam,
+) (types.SwapQuoteResult, error) {
+	inputTransferFee, outputTransferFee := getInputOutputTransferFee(swapBaseForQuote, transferFeeParam)
+
+	input := CalculateTransferFeeExcludedAmount(inputTransferFee, amountIn)
+	if input.Amount.Sign() == 0 {
+		return types.SwapQuoteResult{}, errors.New("amountIn after transfer fee cannot be zero")
+	}
+
+	result, err := SwapQuote(
+		virtualPool,
+		config,
+		swapBaseForQuote,
+		input.Amount,
+		slippageBps,
+		hasReferral,
+		currentPoint,
+	)
+	if err != nil {
+		return types.SwapQuoteResult{}, err
+	}
+
+	output := CalculateTransferFeeExcludedAmount(
+		outputTransferFee, new(big.Int).SetUint64(result.OutputAmount),
+	)
+
+	if result.MinimumAmountOut, err = getNetMinimumAmountOut(output.Amount, slippageBps); err != nil {
+		return types.SwapQuoteResult{}, err
+	}
+	result.TransferFeeResult = types.TransferFeeResult{
+		InputTransferFee:  input.TransferFee.Uint64(),
+		OutputTransferFee: output.TransferFee.Uint64(),
+		NetOutputAmount:   output.Amount.Uint64(),
+	}
+	return result, nil
+}
+
+// SwapQuoteExactInWithTransferFee is SwapQuoteExactIn with Token-2022 transfer fees.
+func SwapQuoteExactInWithTransferFee(
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	swapBaseForQuote bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	transferFeeParam types.TransferFeeParam,
+) (types.SwapQuote2Result, error) {
+	return swapQuoteFromInputWithTransferFee(
+		SwapQuoteExactIn,
+		virtualPool, config, swapBaseForQuote, amountIn, slippageBps, hasReferral, currentPoint,
+		transferFeeParam,
+	)
+}
+
+// SwapQuotePartialFillWithTransferFee is SwapQuotePartialFill with Token-2022 transfer fees.
+// When the swap is partially filled only the consumed input is transferred.
+func SwapQuotePartialFillWithTransferFee(
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	swapBaseForQuote bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	transferFeeParam types.TransferFeeParam,
+) (types.SwapQuote2Result, error) {
+	return swapQuoteFromInputWithTransferFee(
+		SwapQuotePartialFill,
+		virtualPool, config, swapBaseForQuote, amountIn, slippageBps, hasReferral, currentPoint,
+		transferFeeParam,
+	)
+}
+
+func swapQuoteFromInputWithTransferFee(
+	swapQuote func(
+		*dbc.VirtualPoolAccount, *dbc.PoolConfigAccount, bool, *big.Int, uint64, bool, *big.Int,
+	) (types.SwapQuote2Result, error),
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	swapBaseForQuote bool,
+	amountIn *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	transferFeeParam types.TransferFeeParam,
+) (types.SwapQuote2Result, error) {
+	inputTransferFee, outputTransferFee := getInputOutputTransferFee(swapBaseForQuote, transferFeeParam)
+
+	input := CalculateTransferFeeExcludedAmount(inputTransferFee, amountIn)
+	if input.Amount.Sign() == 0 {
+		return types.SwapQuote2Result{}, errors.New("amountIn after transfer fee cannot be zero")
+	}
+
+	result, err := swapQuote(
+		virtualPool,
+		config,
+		swapBaseForQuote,
+		input.Amount,
+		slippageBps,
+		hasReferral,
+		currentPoint,
+	)
+	if err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+
+	inputFee := input.TransferFee
+	if result.AmountLeft > 0 {
+		consumed, err := CalculateTransferFeeIncludedAmount(
+			inputTransferFee, new(big.Int).SetUint64(result.IncludedFeeInputAmount),
+		)
+		if err != nil {
+			return types.SwapQuote2Result{}, err
+		}
+		inputFee = consumed.TransferFee
+	}
+
+	output := CalculateTransferFeeExcludedAmount(
+		outputTransferFee, new(big.Int).SetUint64(result.OutputAmount),
+	)
+
+	if result.MinimumAmountOut, err = getNetMinimumAmountOut(output.Amount, slippageBps); err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+	result.TransferFeeResult = types.TransferFeeResult{
+		InputTransferFee:  inputFee.Uint64(),
+		OutputTransferFee: output.TransferFee.Uint64(),
+		NetOutputAmount:   output.Amount.Uint64(),
+	}
+	return result, nil
+}
+
+// SwapQuoteExactOutWithTransferFee is SwapQuoteExactOut with Token-2022 transfer fees,
+// amountOut is the amount the user receives after the output transfer fee.
+// MaximumAmountIn includes the input transfer fee.
+func SwapQuoteExactOutWithTransferFee(
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	swapBaseForQuote bool,
+	amountOut *big.Int,
+	slippageBps uint64,
+	hasReferral bool,
+	currentPoint *big.Int,
+	transferFeeParam types.TransferFeeParam,
+) (types.SwapQuote2Result, error) {
+	inputTransferFee, outputTransferFee := getInputOutputTransferFee(swapBaseForQuote, transferFeeParam)
+
+	output, err := CalculateTransferFeeIncludedAmount(outputTransferFee, amountOut)
+	if err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+
+	result, err := SwapQuoteExactOut(
+		virtualPool,
+		config,
+		swapBaseForQuote,
+		output.Amount,
+		slippageBps,
+		hasReferral,
+		currentPoint,
+	)
+	if err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+
+	input, err := CalculateTransferFeeIncludedAmount(
+		inputTransferFee, new(big.Int).SetUint64(result.IncludedFeeInputAmount),
+	)
+	if err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+
+	maximumAmountIn, err := CalculateTransferFeeIncludedAmount(
+		inputTransferFee, new(big.Int).SetUint64(result.MaximumAmountIn),
+	)
+	if err != nil {
+		return types.SwapQuote2Result{}, err
+	}
+	if !maximumAmountIn.Amount.IsUint64() {
+		return types.SwapQuote2Result{}, fmt.Errorf("cannot fit maximumAmountIn(%s) into uint64", maximumAmountIn.Amount)
+	}
+
+	netOutput := CalculateTransferFeeExcludedAmount(outputTransferFee, new(big.Int).SetUint64(result.OutputAmount))
+
+	result.MaximumAmountIn = maximumAmountIn.Amount.Uint64()
+	result.TransferFeeResult = types.TransferFeeResult{
+		InputTransferFee:  input.TransferFee.Uint64(),
+		OutputTransferFee: netOutput.TransferFee.Uint64(),
+		NetOutputAmount:   netOutput.Amount.Uint64(),
+	}
+	return result, nil
+}
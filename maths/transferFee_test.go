@@ -0,0 +1,137 @@
+package maths_test
+
+import (
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/maths"
+	"dbcGoSDK/types"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferFee(t *testing.T) {
+	config := &types.TransferFeeConfig{
+		OlderTransferFee: types.TransferFee{Epoch: 0, MaximumFee: 1_000, TransferFeeBasisPoints: 100},
+		NewerTransferFee: types.TransferFee{Epoch: 10, MaximumFee: 1_000_000, TransferFeeBasisPoints: 250},
+	}
+
+	t.Run("epoch fee", func(t *testing.T) {
+		assert.Equal(t, config.OlderTransferFee, maths.GetEpochTransferFee(config, 9))
+		assert.Equal(t, config.NewerTransferFee, maths.GetEpochTransferFee(config, 10))
+		assert.Equal(t, types.TransferFee{}, maths.GetEpochTransferFee(nil, 10))
+	})
+
+	t.Run("fee is rounded up and capped", func(t *testing.T) {
+		fee := config.OlderTransferFee
+		assert.Equal(t, big.NewInt(1), maths.CalculateTransferFee(fee, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(100), maths.CalculateTransferFee(fee, big.NewInt(10_000)))
+		assert.Equal(t, big.NewInt(1_000), maths.CalculateTransferFee(fee, big.NewInt(1_000_000_000)))
+		assert.Equal(t, big.NewInt(0), maths.CalculateTransferFee(types.TransferFee{}, big.NewInt(1_000)))
+	})
+
+	t.Run("included amount round trips", func(t *testing.T) {
+		for _, fee := range []types.TransferFee{config.OlderTransferFee, config.NewerTransferFee, {}} {
+			for _, amount := range []int64{1, 99, 10_000, 123_456_789, 1_000_000_000_000} {
+				included, err := maths.CalculateTransferFeeIncludedAmount(fee, big.NewInt(amount))
+				assert.NoError(t, err)
+
+				excluded := maths.CalculateTransferFeeExcludedAmount(fee, included.Amount)
+				assert.Equal(t, big.NewInt(amount), excluded.Amount, "fee %+v amount %d", fee, amount)
+				assert.Equal(t, included.TransferFee, excluded.TransferFee)
+			}
+		}
+	})
+}
+
+func TestSwapQuoteWithTransferFee(t *testing.T) {
+	config := &dbc.PoolConfigAccount{
+		PoolFees: dbc.PoolFeesConfig{
+			BaseFee:            dbc.BaseFeeConfig{CliffFeeNumerator: 2_500_000},
+			ProtocolFeePercent: 20,
+		},
+		CollectFeeMode:          uint8(types.CollectFeeModeQuoteToken),
+		MigrationQuoteThreshold: 1_000_000_000_000,
+		SqrtStartPrice:          maths.MustBigIntToUint128(maths.Q64(1)),
+	}
+	liquidity, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	config.Curve[0] = dbc.LiquidityDistributionConfig{
+		SqrtPrice: maths.MustBigIntToUint128(maths.Q64(2)),
+		Liquidity: maths.MustBigIntToUint128(liquidity),
+	}
+	pool := &dbc.VirtualPoolAccount{SqrtPrice: config.SqrtStartPrice}
+
+	transferFeeParam := types.TransferFeeParam{
+		BaseTransferFeeConfig: &types.TransferFeeConfig{
+			NewerTransferFee: types.TransferFee{MaximumFee: 1_000_000_000, TransferFeeBasisPoints: 200},
+		},
+		QuoteTransferFeeConfig: &types.TransferFeeConfig{
+			NewerTransferFee: types.TransferFee{MaximumFee: 1_000_000_000, TransferFeeBasisPoints: 100},
+		},
+	}
+	amountIn, currentPoint := big.NewInt(1_000_000), big.NewInt(0)
+
+	t.Run("exact in deducts the input fee before quoting", func(t *testing.T) {
+		got, err := maths.SwapQuoteExactInWithTransferFee(
+			pool, config, false, amountIn, 100, false, currentPoint, transferFeeParam,
+		)
+		assert.NoError(t, err)
+
+		expected, err := maths.SwapQuoteExactIn(pool, config, false, big.NewInt(990_000), 100, false, currentPoint)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected.SwapResult2, got.SwapResult2)
+		assert.Equal(t, uint64(10_000), got.InputTransferFee)
+		assert.Equal(t,
+			maths.CalculateTransferFee(transferFeeParam.BaseTransferFeeConfig.NewerTransferFee,
+				new(big.Int).SetUint64(got.OutputAmount)).Uint64(),
+			got.OutputTransferFee,
+		)
+
+		// the user receives the output less its transfer fee and the minimum applies to that
+		assert.NotZero(t, got.OutputTransferFee)
+		assert.Equal(t, got.OutputAmount-got.OutputTransferFee, got.NetOutputAmount)
+		assert.Equal(t, got.NetOutputAmount*9_900/10_000, got.MinimumAmountOut)
+		assert.Less(t, got.MinimumAmountOut, expected.MinimumAmountOut)
+	})
+
+	t.Run("swap quote v1 returns the net output", func(t *testing.T) {
+		got, err := maths.SwapQuoteWithTransferFee(
+			pool, config, false, amountIn, 100, false, currentPoint, transferFeeParam,
+		)
+		assert.NoError(t, err)
+
+		expected, err := maths.SwapQuote(pool, config, false, big.NewInt(990_000), 100, false, currentPoint)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected.SwapResult, got.SwapResult)
+		// base output carries a 2% transfer fee
+		assert.Equal(t, got.OutputAmount-(got.OutputAmount*200+9_999)/10_000, got.NetOutputAmount)
+		assert.Equal(t, got.NetOutputAmount*9_900/10_000, got.MinimumAmountOut)
+	})
+
+	t.Run("no transfer fee matches the plain quote", func(t *testing.T) {
+		got, err := maths.SwapQuoteWithTransferFee(
+			pool, config, false, amountIn, 100, false, currentPoint, types.TransferFeeParam{},
+		)
+		assert.NoError(t, err)
+
+		expected, err := maths.SwapQuote(pool, config, false, amountIn, 100, false, currentPoint)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.SwapResult, got.SwapResult)
+		assert.Equal(t, expected.MinimumAmountOut, got.MinimumAmountOut)
+		assert.Equal(t, expected.OutputAmount, got.NetOutputAmount)
+	})
+
+	t.Run("exact out grosses up the output and input", func(t *testing.T) {
+		amountOut := big.NewInt(500_000)
+		got, err := maths.SwapQuoteExactOutWithTransferFee(
+			pool, config, false, amountOut, 100, false, currentPoint, transferFeeParam,
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, got.OutputAmount-got.OutputTransferFee, amountOut.Uint64())
+		assert.Equal(t, amountOut.Uint64(), got.NetOutputAmount)
+		assert.True(t, got.MaximumAmountIn >= got.IncludedFeeInputAmount+got.InputTransferFee)
+	})
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/anchor"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// maxMultipleAccounts is the most accounts getMultipleAccounts returns in one call.
+const maxMultipleAccounts = 100
+
+// claimAllPool is a pool selected for a batch claim with the accounts its claim instruction needs.
+type claimAllPool struct {
+	address           solana.PublicKey
+	pool              *dbc.VirtualPoolAccount
+	config            *dbc.PoolConfigAccount
+	tokenBaseAccount  solana.PublicKey
+	tokenQuoteAccount solana.PublicKey
+	tokenBaseProgram  solana.PublicKey
+	tokenQuoteProgram solana.PublicKey
+	isSOLQuoteMint    bool
+}
+
+// ClaimAllPartnerTradingFee claims the partner trading fee of every pool of a config above the dust
+// threshold, packed into as few transactions as possible. Token accounts are only created when missing.
+func (p *PartnerService) ClaimAllPartnerTradingFee(
+	ctx context.Context,
+	param types.ClaimAllPartnerTradingFeeParam,
+) (types.ClaimAllTradingFeeResponse, error) {
+
+	poolConfigState, err := p.state.GetPoolConfig(ctx, param.Config)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, fmt.Errorf("pool config(%s) not found: error: %w", param.Config.String(), err)
+	}
+	if !poolConfigState.FeeClaimer.Equals(param.FeeClaimer) {
+		return types.ClaimAllTradingFeeResponse{}, fmt.Errorf("fee claimer(%s) is not the fee claimer of config(%s)", param.FeeClaimer, param.Config)
+	}
+
+	pools, err := p.state.GetPoolsByConfig(ctx, param.Config)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, err
+	}
+
+	selected := make([]anchor.ProgramAccount[*dbc.VirtualPoolAccount], 0, len(pools))
+	for _, pool := range pools {
+		if helpers.IsAboveDustThreshold(
+			pool.Account.PartnerBaseFee, pool.Account.PartnerQuoteFee, param.MinBaseFee, param.MinQuoteFee,
+		) {
+			selected = append(selected, pool)
+		}
+	}
+
+	return claimAll(
+		ctx,
+		p.state,
+		selected,
+		map[solana.PublicKey]*dbc.PoolConfigAccount{param.Config: poolConfigState},
+		param.FeeClaimer,
+		param.Payer,
+		param.Receiver,
+		param.TempWSolAcc,
+		param.MaxPoolsPerTransaction,
+		func(claim claimAllPool) (solana.Instruction, error) {
+			claimTradingFeePtr := dbc.NewClaimTradingFeeInstruction(
+				claim.pool.PartnerBaseFee,
+				claim.pool.PartnerQuoteFee,
+				p.state.GetPoolAuthority(),
+				claim.pool.Config,
+				claim.address,
+				claim.tokenBaseAccount,
+				claim.tokenQuoteAccount,
+				claim.pool.BaseVault,
+				claim.pool.QuoteVault,
+				claim.pool.BaseMint,
+				claim.config.QuoteMint,
+				param.FeeClaimer,
+				claim.tokenBaseProgram,
+				claim.tokenQuoteProgram,
+				solana.PublicKey{},
+				constants.DBCProgramId,
+			)
+
+			eventAuthPDA, _, err := claimTradingFeePtr.FindEventAuthorityAddress()
+			if err != nil {
+				return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+			}
+
+			return claimTradingFeePtr.
+				SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+		},
+	)
+}
+
+// ClaimAllCreatorTradingFee claims the creator trading fee of every pool of a creator above the dust
+// threshold, packed into as few transactions as possible. Token accounts are only created when missing.
+func (c *CreatorService) ClaimAllCreatorTradingFee(
+	ctx context.Context,
+	param types.ClaimAllCreatorTradingFeeParam,
+) (types.ClaimAllTradingFeeResponse, error) {
+
+	pools, err := c.state.GetPoolsByCreator(ctx, param.Creator)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, err
+	}
+
+	selected := make([]anchor.ProgramAccount[*dbc.VirtualPoolAccount], 0, len(pools))
+	configs := make(map[solana.PublicKey]*dbc.PoolConfigAccount)
+	for _, pool := range pools {
+		if !helpers.IsAboveDustThreshold(
+			pool.Account.CreatorBaseFee, pool.Account.CreatorQuoteFee, param.MinBaseFee, param.MinQuoteFee,
+		) {
+			continue
+		}
+		selected = append(selected, pool)
+
+		if _, ok := configs[pool.Account.Config]; ok {
+			continue
+		}
+		poolConfigState, err := c.state.GetPoolConfig(ctx, pool.Account.Config)
+		if err != nil {
+			return types.ClaimAllTradingFeeResponse{}, fmt.Errorf("pool config(%s) not found: error: %w", pool.Account.Config.String(), err)
+		}
+		configs[pool.Account.Config] = poolConfigState
+	}
+
+	return claimAll(
+		ctx,
+		c.state,
+		selected,
+		configs,
+		param.Creator,
+		param.Payer,
+		param.Receiver,
+		param.TempWSolAcc,
+		param.MaxPoolsPerTransaction,
+		func(claim claimAllPool) (solana.Instruction, error) {
+			claimCreatorTradingFeePtr := dbc.NewClaimCreatorTradingFeeInstruction(
+				claim.pool.CreatorBaseFee,
+				claim.pool.CreatorQuoteFee,
+				c.state.GetPoolAuthority(),
+				claim.address,
+				claim.tokenBaseAccount,
+				claim.tokenQuoteAccount,
+				claim.pool.BaseVault,
+				claim.pool.QuoteVault,
+				claim.pool.BaseMint,
+				claim.config.QuoteMint,
+				param.Creator,
+				claim.tokenBaseProgram,
+				claim.tokenQuoteProgram,
+				solana.PublicKey{},
+				constants.DBCProgramId,
+			)
+
+			eventAuthPDA, _, err := claimCreatorTradingFeePtr.FindEventAuthorityAddress()
+			if err != nil {
+				return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+			}
+
+			return claimCreatorTradingFeePtr.
+				SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+		},
+	)
+}
+
+// claimAll builds a claim per pool and packs them. Every transaction creates the token accounts its
+// claims need that are missing on chain, so the transactions can land in any order. SOL quote fees go
+// through the wSOL account of the temp account, created and unwrapped once per transaction.
+func claimAll(
+	ctx context.Context,
+	state *StateService,
+	pools []anchor.ProgramAccount[*dbc.VirtualPoolAccount],
+	configs map[solana.PublicKey]*dbc.PoolConfigAccount,
+	claimer, payer, receiver, tempWSolAcc solana.PublicKey,
+	maxPoolsPerTransaction int,
+	newClaimIx func(claim claimAllPool) (solana.Instruction, error),
+) (types.ClaimAllTradingFeeResponse, error) {
+
+	if len(pools) == 0 {
+		return types.ClaimAllTradingFeeResponse{}, nil
+	}
+
+	// if receiver is present and not equal to claimer, use tempWSolAcc, otherwise use claimer
+	feeReceiver, tempWSol := claimer, claimer
+	if !receiver.IsZero() {
+		feeReceiver = receiver
+	}
+	if !feeReceiver.Equals(claimer) {
+		tempWSol = tempWSolAcc
+	}
+
+	claims := make([]claimAllPool, 0, len(pools))
+	tokenAccounts := make([]solana.PublicKey, 0, 2*len(pools))
+	for _, pool := range pools {
+		poolConfigState := configs[pool.Account.Config]
+		claim := claimAllPool{
+			address:           pool.PublicKey,
+			pool:              pool.Account,
+			config:            poolConfigState,
+			tokenBaseProgram:  helpers.GetTokenProgram(poolConfigState.TokenType),
+			tokenQuoteProgram: helpers.GetTokenProgram(poolConfigState.QuoteTokenFlag),
+			isSOLQuoteMint:    poolConfigState.QuoteMint.Equals(solana.WrappedSol),
+		}
+		if claim.isSOLQuoteMint && tempWSol.IsZero() {
+			return types.ClaimAllTradingFeeResponse{}, fmt.Errorf("tempWSolAcc is required to claim SOL of pool(%s)", pool.PublicKey)
+		}
+
+		var err error
+		claim.tokenBaseAccount, err = helpers.FindAssociatedTokenAddress(
+			feeReceiver, pool.Account.BaseMint, claim.tokenBaseProgram,
+		)
+		if err != nil {
+			return types.ClaimAllTradingFeeResponse{}, err
+		}
+
+		quoteOwner := feeReceiver
+		if claim.isSOLQuoteMint {
+			quoteOwner = tempWSol
+		}
+		claim.tokenQuoteAccount, err = helpers.FindAssociatedTokenAddress(
+			quoteOwner, poolConfigState.QuoteMint, claim.tokenQuoteProgram,
+		)
+		if err != nil {
+			return types.ClaimAllTradingFeeResponse{}, err
+		}
+
+		claims = append(claims, claim)
+		tokenAccounts = append(tokenAccounts, claim.tokenBaseAccount, claim.tokenQuoteAccount)
+	}
+
+	existing, err := getExistingAccounts(ctx, state, tokenAccounts)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, err
+	}
+
+	unwrapSolIx, err := helpers.UnwrapSOLInstruction(tempWSol, feeReceiver, false)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, err
+	}
+
+	response := types.ClaimAllTradingFeeResponse{
+		Pools: make([]solana.PublicKey, 0, len(claims)),
+	}
+	groups := make([]types.TransactionGroup, 0, len(claims))
+	for _, claim := range claims {
+		currentIx, err := newClaimIx(claim)
+		if err != nil {
+			return types.ClaimAllTradingFeeResponse{}, fmt.Errorf("pool(%s): %w", claim.address, err)
+		}
+
+		group := types.TransactionGroup{Instructions: []solana.Instruction{currentIx}}
+		if !existing[claim.tokenBaseAccount] {
+			group.PreInstructions = append(group.PreInstructions,
+				helpers.CreateAssociatedTokenAccountIdempotentInstruction(
+					payer, claim.tokenBaseAccount, feeReceiver, claim.pool.BaseMint, claim.tokenBaseProgram,
+				),
+			)
+		}
+		// the wSOL account is closed at the end of every transaction, so it is always created
+		switch {
+		case claim.isSOLQuoteMint:
+			group.PreInstructions = append(group.PreInstructions,
+				helpers.CreateAssociatedTokenAccountIdempotentInstruction(
+					payer, claim.tokenQuoteAccount, tempWSol, claim.config.QuoteMint, claim.tokenQuoteProgram,
+				),
+			)
+			group.PostInstructions = []solana.Instruction{unwrapSolIx}
+		case !existing[claim.tokenQuoteAccount]:
+			group.PreInstructions = append(group.PreInstructions,
+				helpers.CreateAssociatedTokenAccountIdempotentInstruction(
+					payer, claim.tokenQuoteAccount, feeReceiver, claim.config.QuoteMint, claim.tokenQuoteProgram,
+				),
+			)
+		}
+
+		groups = append(groups, group)
+		response.Pools = append(response.Pools, claim.address)
+	}
+
+	response.Transactions, err = helpers.PackTransactions(payer, groups, maxPoolsPerTransaction)
+	if err != nil {
+		return types.ClaimAllTradingFeeResponse{}, err
+	}
+	return response, nil
+}
+
+// getExistingAccounts checks which of accounts exist on chain.
+func getExistingAccounts(
+	ctx context.Context,
+	state *StateService,
+	accounts []solana.PublicKey,
+) (map[solana.PublicKey]bool, error) {
+
+	existing := make(map[solana.PublicKey]bool, len(accounts))
+	unique := make([]solana.PublicKey, 0, len(accounts))
+	for _, account := range accounts {
+		if _, ok := existing[account]; !ok {
+			existing[account] = false
+			unique = append(unique, account)
+		}
+	}
+
+	for start := 0; start < len(unique); start += maxMultipleAccounts {
+		chunk := unique[start:min(start+maxMultipleAccounts, len(unique))]
+		out, err := state.conn.GetMultipleAccountsWithOpts(
+			ctx, chunk, &rpc.GetMultipleAccountsOpts{Commitment: state.commitment},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch token accounts: %w", err)
+		}
+		for i, account := range out.Value {
+			existing[chunk[i]] = account != nil
+		}
+	}
+
+	return existing, nil
+}
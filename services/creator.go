@@ -665,8 +665,10 @@ func (c *CreatorService) CreatorWithdrawSurplus(
 	}
 
 	finalIxns := make([]solana.Instruction, 0, 1+1+1)
-	finalIxns = append(finalIxns, createQuoteTokenAccountIx)
-	finalIxns = append(finalIxns, currentIx, postInstruction)
+	finalIxns = append(finalIxns, createQuoteTokenAccountIx, currentIx)
+	if postInstruction != nil {
+		finalIxns = append(finalIxns, postInstruction)
+	}
 	return finalIxns, nil
 }
 
@@ -719,7 +721,7 @@ func (c *CreatorService) CreatorWithdrawMigrationFee(
 	}
 
 	payer := param.Sender
-	if !param.FeePayer.IsZero() {
+	if param.FeePayer != nil && !param.FeePayer.IsZero() {
 		payer = *param.FeePayer
 	}
 
@@ -773,5 +775,13 @@ func (c *CreatorService) CreatorWithdrawMigrationFee(
 		return nil, err
 	}
 
-	return append(make([]solana.Instruction, 0, 3), ix, currentIx, postInstruction), nil
+	ixns := make([]solana.Instruction, 0, 3)
+	if ix != nil {
+		ixns = append(ixns, ix)
+	}
+	ixns = append(ixns, currentIx)
+	if postInstruction != nil {
+		ixns = append(ixns, postInstruction)
+	}
+	return ixns, nil
 }
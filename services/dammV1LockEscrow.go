@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/anchor"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/helpers"
+	mathsDammv1 "dbcGoSDK/maths/dammv1"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+type DammV1LockEscrowService struct {
+	state *StateService
+}
+
+func NewDammV1LockEscrowService(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *DammV1LockEscrowService {
+	return &DammV1LockEscrowService{
+		state: NewStateService(conn, commitment),
+	}
+}
+
+// GetLockEscrow gets the DAMM V1 lock escrow of owner in dammPool.
+func (d *DammV1LockEscrowService) GetLockEscrow(
+	ctx context.Context,
+	dammPool, owner solana.PublicKey,
+) (*dammv1.LockEscrowAccount, error) {
+	return anchor.NewPgAccounts(
+		d.state.conn,
+		func() *dammv1.LockEscrowAccount { return &dammv1.LockEscrowAccount{} },
+	).Fetch(
+		ctx,
+		helpers.DeriveDammV1LockEscrowAddress(dammPool, owner),
+		&rpc.GetAccountInfoOpts{Commitment: d.state.commitment},
+	)
+}
+
+// GetClaimableFee gets the trading fee the locked LP of owner in dammPool has earned since the last claim.
+func (d *DammV1LockEscrowService) GetClaimableFee(
+	ctx context.Context,
+	dammPool, owner solana.PublicKey,
+) (types.DammV1LockEscrowFee, error) {
+	fee, _, _, err := d.getClaimableFee(ctx, dammPool, owner)
+	return fee, err
+}
+
+// ClaimFee claims the trading fee of a DAMM V1 lock escrow into the receiver token accounts,
+// creating them when missing. SOL is unwrapped back to the owner when the owner receives it.
+func (d *DammV1LockEscrowService) ClaimFee(
+	ctx context.Context,
+	param types.DammV1ClaimLockEscrowFeeParam,
+) ([]solana.Instruction, error) {
+
+	fee, poolState, lockEscrow, err := d.getClaimableFee(ctx, param.DammPool, param.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAmount := fee.LpAmount.Uint64()
+	if param.MaxAmount != 0 && param.MaxAmount < maxAmount {
+		maxAmount = param.MaxAmount
+	}
+	if maxAmount == 0 {
+		return nil, fmt.Errorf("lock escrow of owner(%s) has no fee to claim", param.Owner)
+	}
+
+	receiver := param.Owner
+	if !param.Receiver.IsZero() {
+		receiver = param.Receiver
+	}
+	payer := param.Owner
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+
+	pool := poolState.Pool
+	vaultPDAsA, err := helpers.DeriveVaultPdas(pool.TokenAMint, solana.PublicKey{})
+	if err != nil {
+		return nil, err
+	}
+	vaultPDAsB, err := helpers.DeriveVaultPdas(pool.TokenBMint, solana.PublicKey{})
+	if err != nil {
+		return nil, err
+	}
+
+	lpMint := pool.LpMint
+
+	prepareTokenAccounts, err := d.state.prepareTokenAccounts(
+		ctx,
+		types.PrepareTokenAccountParams{
+			Owner:         receiver,
+			Payer:         payer,
+			TokenAMint:    pool.TokenAMint,
+			TokenBMint:    pool.TokenBMint,
+			TokenAProgram: solana.TokenProgramID,
+			TokenBProgram: solana.TokenProgramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the claimed LP passes through the owner LP account before it is burnt
+	sourceTokens, createSourceTokensIx, err := helpers.GetOrCreateATAInstruction(
+		ctx,
+		d.state.conn,
+		lpMint,
+		param.Owner,
+		payer,
+		true,
+		solana.TokenProgramID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	currentIx, err := dammv1.NewClaimFeeInstruction(
+		maxAmount,
+		param.DammPool,
+		lpMint,
+		helpers.DeriveDammV1LockEscrowAddress(param.DammPool, param.Owner),
+		param.Owner,
+		sourceTokens,
+		lockEscrow.EscrowVault,
+		solana.TokenProgramID,
+		vaultPDAsA.TokenVaultPDA,
+		vaultPDAsB.TokenVaultPDA,
+		vaultPDAsA.VaultPDA,
+		vaultPDAsB.VaultPDA,
+		helpers.DeriveDammV1VaultLPAddress(vaultPDAsA.VaultPDA, param.DammPool),
+		helpers.DeriveDammV1VaultLPAddress(vaultPDAsB.VaultPDA, param.DammPool),
+		vaultPDAsA.LPMintPDA,
+		vaultPDAsB.LPMintPDA,
+		prepareTokenAccounts.TokenAAta,
+		prepareTokenAccounts.TokenBAta,
+		constants.VaultProgramId,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(prepareTokenAccounts.CreateATAIxns)+3)
+	ixns = append(ixns, prepareTokenAccounts.CreateATAIxns...)
+	if createSourceTokensIx != nil {
+		ixns = append(ixns, createSourceTokensIx)
+	}
+	ixns = append(ixns, currentIx)
+
+	if receiver.Equals(param.Owner) &&
+		(pool.TokenAMint.Equals(solana.WrappedSol) || pool.TokenBMint.Equals(solana.WrappedSol)) {
+		unwrapSolIx, err := helpers.UnwrapSOLInstruction(param.Owner, param.Owner, true)
+		if err != nil {
+			return nil, err
+		}
+		ixns = append(ixns, unwrapSolIx)
+	}
+
+	return ixns, nil
+}
+
+func (d *DammV1LockEscrowService) getClaimableFee(
+	ctx context.Context,
+	dammPool, owner solana.PublicKey,
+) (types.DammV1LockEscrowFee, types.DammV1PoolState, *dammv1.LockEscrowAccount, error) {
+
+	lockEscrow, err := d.GetLockEscrow(ctx, dammPool, owner)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil,
+			fmt.Errorf("lock escrow of owner(%s) not found: error: %w", owner.String(), err)
+	}
+
+	poolState, err := d.state.GetDammV1PoolState(ctx, dammPool)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil, err
+	}
+
+	supply, err := d.state.conn.GetTokenSupply(ctx, poolState.Pool.LpMint, d.state.commitment)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil,
+			fmt.Errorf("cannot fetch pool lp supply(%s): %w", poolState.Pool.LpMint, err)
+	}
+	lpSupply, ok := new(big.Int).SetString(supply.Value.Amount, 10)
+	if !ok {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil,
+			errors.New("invalid pool lp supply")
+	}
+
+	currentTime, err := helpers.GetCurrentPoint(d.state.conn, types.ActivationTypeTimestamp)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil, err
+	}
+
+	fee, err := mathsDammv1.GetLockEscrowClaimableFee(
+		poolState,
+		lpSupply,
+		lockEscrow.TotalLockedAmount,
+		lockEscrow.LpPerToken.BigInt(),
+		lockEscrow.UnclaimedFeePending,
+		currentTime.Uint64(),
+	)
+	if err != nil {
+		return types.DammV1LockEscrowFee{}, types.DammV1PoolState{}, nil, err
+	}
+
+	return fee, poolState, lockEscrow, nil
+}
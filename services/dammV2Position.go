@@ -0,0 +1,619 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/anchor"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+type DammV2PositionService struct {
+	state *StateService
+}
+
+func NewDammV2PositionService(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *DammV2PositionService {
+	return &DammV2PositionService{
+		state: NewStateService(conn, commitment),
+	}
+}
+
+// GetPosition gets DAMM V2 position data.
+func (d *DammV2PositionService) GetPosition(
+	ctx context.Context,
+	positionAddress solana.PublicKey,
+) (*dammv2.PositionAccount, error) {
+	return anchor.NewPgAccounts(
+		d.state.conn,
+		func() *dammv2.PositionAccount { return &dammv2.PositionAccount{} },
+	).Fetch(ctx, positionAddress, &rpc.GetAccountInfoOpts{Commitment: d.state.commitment})
+}
+
+// GetPositionsByOwner gets every DAMM V2 position whose NFT is held by owner, with its pool and unclaimed fees.
+func (d *DammV2PositionService) GetPositionsByOwner(
+	ctx context.Context,
+	owner solana.PublicKey,
+) ([]types.DammV2UserPosition, error) {
+	nfts, err := helpers.GetAllPositionNftAccountByOwner(ctx, d.state.conn, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(nfts) == 0 {
+		return nil, nil
+	}
+
+	positionAddresses := make([]solana.PublicKey, len(nfts))
+	for i, nft := range nfts {
+		positionAddresses[i] = helpers.DerivePositionAddress(nft.PositionNft)
+	}
+
+	positionStates, err := anchor.NewPgAccounts(
+		d.state.conn,
+		func() *dammv2.PositionAccount { return &dammv2.PositionAccount{} },
+	).FetchMultiple(ctx, positionAddresses, &rpc.GetMultipleAccountsOpts{Commitment: d.state.commitment})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	positions := make([]types.DammV2UserPosition, 0, len(nfts))
+	poolAddresses := make([]solana.PublicKey, 0, len(nfts))
+	poolIndex := make(map[solana.PublicKey]int, len(nfts))
+	for i, positionState := range positionStates {
+		// token 2022 accounts holding one token that are not DAMM V2 position NFTs
+		if positionState == nil || positionState.Pool.IsZero() {
+			continue
+		}
+
+		if _, ok := poolIndex[positionState.Pool]; !ok {
+			poolIndex[positionState.Pool] = len(poolAddresses)
+			poolAddresses = append(poolAddresses, positionState.Pool)
+		}
+
+		positions = append(positions, types.DammV2UserPosition{
+			Position:           positionAddresses[i],
+			PositionNft:        nfts[i].PositionNft,
+			PositionNftAccount: nfts[i].PositionNftAccount,
+			PositionState:      positionState,
+			Pool:               positionState.Pool,
+		})
+	}
+	if len(positions) == 0 {
+		return nil, nil
+	}
+
+	poolStates, err := anchor.NewPgAccounts(
+		d.state.conn,
+		func() *dammv2.PoolAccount { return &dammv2.PoolAccount{} },
+	).FetchMultiple(ctx, poolAddresses, &rpc.GetMultipleAccountsOpts{Commitment: d.state.commitment})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pools: %w", err)
+	}
+
+	for i := range positions {
+		positions[i].PoolState = poolStates[poolIndex[positions[i].Pool]]
+		positions[i].UnclaimedFeeA, positions[i].UnclaimedFeeB = helpers.GetDammV2UnclaimedFee(
+			positions[i].PoolState,
+			positions[i].PositionState,
+		)
+	}
+
+	return positions, nil
+}
+
+// ClaimPositionFee claims the trading fees of a DAMM V2 position.
+func (d *DammV2PositionService) ClaimPositionFee(
+	ctx context.Context,
+	param types.DammV2ClaimPositionFeeParam,
+) ([]solana.Instruction, error) {
+
+	accounts, err := d.preparePositionAccounts(ctx, param.Owner, param.Position, param.Receiver, param.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	claimIx, err := d.claimPositionFeeIx(param.Owner, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(accounts.preInstructions)+1+len(accounts.postInstructions))
+	ixns = append(ixns, accounts.preInstructions...)
+	ixns = append(ixns, claimIx)
+	return append(ixns, accounts.postInstructions...), nil
+}
+
+// AddLiquidity adds liquidity to a DAMM V2 position, wrapping SOL up to the max amount when needed.
+func (d *DammV2PositionService) AddLiquidity(
+	ctx context.Context,
+	param types.DammV2AddLiquidityParam,
+) ([]solana.Instruction, error) {
+
+	accounts, err := d.preparePositionAccounts(ctx, param.Owner, param.Position, param.Owner, param.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidityDelta := param.LiquidityDelta
+	if liquidityDelta == nil {
+		if liquidityDelta, err = helpers.GetDammV2LiquidityDelta(
+			accounts.poolState,
+			param.MaxAmountA,
+			param.MaxAmountB,
+		); err != nil {
+			return nil, err
+		}
+	}
+	if liquidityDelta.Sign() <= 0 {
+		return nil, errors.New("liquidity delta must be greater than 0")
+	}
+
+	liquidityDeltaU128, err := helpers.BigIntToUint128(liquidityDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	addLiquidityPtr := dammv2.NewAddLiquidityInstruction(
+		dammv2.AddLiquidityParameters{
+			LiquidityDelta:        liquidityDeltaU128,
+			TokenAAmountThreshold: param.MaxAmountA,
+			TokenBAmountThreshold: param.MaxAmountB,
+		},
+		accounts.pool,
+		param.Position,
+		accounts.tokenAAccount,
+		accounts.tokenBAccount,
+		accounts.poolState.TokenAVault,
+		accounts.poolState.TokenBVault,
+		accounts.poolState.TokenAMint,
+		accounts.poolState.TokenBMint,
+		accounts.positionNftAccount,
+		param.Owner,
+		accounts.tokenAProgram,
+		accounts.tokenBProgram,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := addLiquidityPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	currentIx, err := addLiquidityPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(accounts.preInstructions)+2+1+len(accounts.postInstructions))
+	ixns = append(ixns, accounts.preInstructions...)
+	if accounts.poolState.TokenAMint.Equals(solana.WrappedSol) {
+		ixns = append(ixns, helpers.WrapSOLInstruction(param.Owner, accounts.tokenAAccount, param.MaxAmountA)...)
+	}
+	if accounts.poolState.TokenBMint.Equals(solana.WrappedSol) {
+		ixns = append(ixns, helpers.WrapSOLInstruction(param.Owner, accounts.tokenBAccount, param.MaxAmountB)...)
+	}
+	ixns = append(ixns, currentIx)
+	return append(ixns, accounts.postInstructions...), nil
+}
+
+// RemoveLiquidity removes liquidity from a DAMM V2 position, all of the unlocked liquidity when no delta is given.
+func (d *DammV2PositionService) RemoveLiquidity(
+	ctx context.Context,
+	param types.DammV2RemoveLiquidityParam,
+) ([]solana.Instruction, error) {
+
+	accounts, err := d.preparePositionAccounts(ctx, param.Owner, param.Position, param.Owner, param.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	var removeIx solana.Instruction
+	if param.LiquidityDelta == nil {
+		removeIx, err = d.removeAllLiquidityIx(param.Owner, accounts, param.MinAmountA, param.MinAmountB)
+	} else {
+		removeIx, err = d.removeLiquidityIx(param.Owner, accounts, param.LiquidityDelta, param.MinAmountA, param.MinAmountB)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(accounts.preInstructions)+1+len(accounts.postInstructions))
+	ixns = append(ixns, accounts.preInstructions...)
+	ixns = append(ixns, removeIx)
+	return append(ixns, accounts.postInstructions...), nil
+}
+
+// LockPosition locks liquidity of a DAMM V2 position into a vesting account.
+func (d *DammV2PositionService) LockPosition(
+	ctx context.Context,
+	param types.DammV2LockPositionParam,
+) (*dammv2.Instruction, error) {
+
+	positionState, err := d.GetPosition(ctx, param.Position)
+	if err != nil {
+		return nil, fmt.Errorf("position(%s) not found: error: %w", param.Position.String(), err)
+	}
+
+	cliffUnlock, liquidityPerPeriod := big.NewInt(0), big.NewInt(0)
+	if param.CliffUnlock != nil {
+		cliffUnlock = param.CliffUnlock
+	}
+	if param.LiquidityPerPeriod != nil {
+		liquidityPerPeriod = param.LiquidityPerPeriod
+	}
+
+	totalLockLiquidity := new(big.Int).Add(
+		cliffUnlock,
+		new(big.Int).Mul(liquidityPerPeriod, big.NewInt(int64(param.NumberOfPeriod))),
+	)
+	if totalLockLiquidity.Sign() <= 0 {
+		return nil, errors.New("lock liquidity must be greater than 0")
+	}
+	if totalLockLiquidity.Cmp(positionState.UnlockedLiquidity.BigInt()) > 0 {
+		return nil, fmt.Errorf(
+			"lock liquidity(%s) exceeds unlocked liquidity(%s)",
+			totalLockLiquidity, positionState.UnlockedLiquidity.BigInt(),
+		)
+	}
+
+	cliffUnlockU128, err := helpers.BigIntToUint128(cliffUnlock)
+	if err != nil {
+		return nil, err
+	}
+	liquidityPerPeriodU128, err := helpers.BigIntToUint128(liquidityPerPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	payer := param.Owner
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+
+	lockPositionPtr := dammv2.NewLockPositionInstruction(
+		dammv2.VestingParameters{
+			CliffPoint:           param.CliffPoint,
+			PeriodFrequency:      param.PeriodFrequency,
+			CliffUnlockLiquidity: cliffUnlockU128,
+			LiquidityPerPeriod:   liquidityPerPeriodU128,
+			NumberOfPeriod:       param.NumberOfPeriod,
+		},
+		positionState.Pool,
+		param.Position,
+		param.Vesting,
+		helpers.DerivePositionNftAccount(positionState.NftMint),
+		param.Owner,
+		payer,
+		solana.SystemProgramID,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := lockPositionPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return lockPositionPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+// PermanentLockPosition permanently locks liquidity of a DAMM V2 position, all of the unlocked liquidity when none is given.
+func (d *DammV2PositionService) PermanentLockPosition(
+	ctx context.Context,
+	param types.DammV2PermanentLockPositionParam,
+) (*dammv2.Instruction, error) {
+
+	positionState, err := d.GetPosition(ctx, param.Position)
+	if err != nil {
+		return nil, fmt.Errorf("position(%s) not found: error: %w", param.Position.String(), err)
+	}
+
+	liquidity := positionState.UnlockedLiquidity.BigInt()
+	if param.Liquidity != nil {
+		liquidity = param.Liquidity
+	}
+	if liquidity.Sign() <= 0 {
+		return nil, errors.New("permanent lock liquidity must be greater than 0")
+	}
+	if liquidity.Cmp(positionState.UnlockedLiquidity.BigInt()) > 0 {
+		return nil, fmt.Errorf(
+			"permanent lock liquidity(%s) exceeds unlocked liquidity(%s)",
+			liquidity, positionState.UnlockedLiquidity.BigInt(),
+		)
+	}
+
+	liquidityU128, err := helpers.BigIntToUint128(liquidity)
+	if err != nil {
+		return nil, err
+	}
+
+	permanentLockPositionPtr := dammv2.NewPermanentLockPositionInstruction(
+		liquidityU128,
+		positionState.Pool,
+		param.Position,
+		helpers.DerivePositionNftAccount(positionState.NftMint),
+		param.Owner,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := permanentLockPositionPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return permanentLockPositionPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+// ClosePosition claims the fees and removes the liquidity left in a DAMM V2 position, then closes it.
+// Positions with vesting or permanently locked liquidity cannot be closed.
+func (d *DammV2PositionService) ClosePosition(
+	ctx context.Context,
+	param types.DammV2ClosePositionParam,
+) ([]solana.Instruction, error) {
+
+	accounts, err := d.preparePositionAccounts(ctx, param.Owner, param.Position, param.Owner, param.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	if accounts.positionState.VestedLiquidity.BigInt().Sign() != 0 ||
+		accounts.positionState.PermanentLockedLiquidity.BigInt().Sign() != 0 {
+		return nil, errors.New("position with locked liquidity cannot be closed")
+	}
+
+	ixns := make([]solana.Instruction, 0, len(accounts.preInstructions)+3+len(accounts.postInstructions))
+	ixns = append(ixns, accounts.preInstructions...)
+
+	feeA, feeB := helpers.GetDammV2UnclaimedFee(accounts.poolState, accounts.positionState)
+	if feeA.Sign() != 0 || feeB.Sign() != 0 {
+		claimIx, err := d.claimPositionFeeIx(param.Owner, accounts)
+		if err != nil {
+			return nil, err
+		}
+		ixns = append(ixns, claimIx)
+	}
+
+	if accounts.positionState.UnlockedLiquidity.BigInt().Sign() != 0 {
+		removeIx, err := d.removeAllLiquidityIx(param.Owner, accounts, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		ixns = append(ixns, removeIx)
+	}
+
+	rentReceiver := param.Owner
+	if !param.RentReceiver.IsZero() {
+		rentReceiver = param.RentReceiver
+	}
+
+	closePositionPtr := dammv2.NewClosePositionInstruction(
+		accounts.positionState.NftMint,
+		accounts.positionNftAccount,
+		accounts.pool,
+		param.Position,
+		helpers.DeriveDammV2PoolAuthority(),
+		rentReceiver,
+		param.Owner,
+		solana.Token2022ProgramID,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := closePositionPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	closeIx, err := closePositionPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns = append(ixns, closeIx)
+	return append(ixns, accounts.postInstructions...), nil
+}
+
+type dammV2PositionAccounts struct {
+	pool               solana.PublicKey
+	poolState          *dammv2.PoolAccount
+	positionState      *dammv2.PositionAccount
+	positionNftAccount solana.PublicKey
+	tokenAAccount      solana.PublicKey
+	tokenBAccount      solana.PublicKey
+	tokenAProgram      solana.PublicKey
+	tokenBProgram      solana.PublicKey
+	preInstructions    []solana.Instruction
+	postInstructions   []solana.Instruction
+}
+
+// preparePositionAccounts fetches the position and its pool and prepares the receiver token accounts.
+// SOL is unwrapped back to the owner when the owner receives it.
+func (d *DammV2PositionService) preparePositionAccounts(
+	ctx context.Context,
+	owner, position, receiver, payer solana.PublicKey,
+) (dammV2PositionAccounts, error) {
+
+	positionState, err := d.GetPosition(ctx, position)
+	if err != nil {
+		return dammV2PositionAccounts{}, fmt.Errorf("position(%s) not found: error: %w", position.String(), err)
+	}
+
+	poolState, err := d.state.GetDammV2Pool(ctx, positionState.Pool)
+	if err != nil {
+		return dammV2PositionAccounts{}, fmt.Errorf("DAMM V2 pool(%s) not found: error: %w", positionState.Pool.String(), err)
+	}
+
+	if receiver.IsZero() {
+		receiver = owner
+	}
+	if payer.IsZero() {
+		payer = owner
+	}
+
+	tokenAProgram := helpers.GetTokenProgram(poolState.TokenAFlag)
+	tokenBProgram := helpers.GetTokenProgram(poolState.TokenBFlag)
+
+	prepareTokenAccounts, err := d.state.prepareTokenAccounts(
+		ctx,
+		types.PrepareTokenAccountParams{
+			Owner:         receiver,
+			Payer:         payer,
+			TokenAMint:    poolState.TokenAMint,
+			TokenBMint:    poolState.TokenBMint,
+			TokenAProgram: tokenAProgram,
+			TokenBProgram: tokenBProgram,
+		},
+	)
+	if err != nil {
+		return dammV2PositionAccounts{}, err
+	}
+
+	var postInstructions []solana.Instruction
+	if receiver.Equals(owner) &&
+		(poolState.TokenAMint.Equals(solana.WrappedSol) || poolState.TokenBMint.Equals(solana.WrappedSol)) {
+		unwrapSolIx, err := helpers.UnwrapSOLInstruction(owner, owner, true)
+		if err != nil {
+			return dammV2PositionAccounts{}, err
+		}
+		postInstructions = append(postInstructions, unwrapSolIx)
+	}
+
+	return dammV2PositionAccounts{
+		pool:               positionState.Pool,
+		poolState:          poolState,
+		positionState:      positionState,
+		positionNftAccount: helpers.DerivePositionNftAccount(positionState.NftMint),
+		tokenAAccount:      prepareTokenAccounts.TokenAAta,
+		tokenBAccount:      prepareTokenAccounts.TokenBAta,
+		tokenAProgram:      tokenAProgram,
+		tokenBProgram:      tokenBProgram,
+		preInstructions:    prepareTokenAccounts.CreateATAIxns,
+		postInstructions:   postInstructions,
+	}, nil
+}
+
+func (d *DammV2PositionService) claimPositionFeeIx(
+	owner solana.PublicKey,
+	accounts dammV2PositionAccounts,
+) (solana.Instruction, error) {
+	claimPositionFeePtr := dammv2.NewClaimPositionFeeInstruction(
+		helpers.DeriveDammV2PoolAuthority(),
+		accounts.pool,
+		helpers.DerivePositionAddress(accounts.positionState.NftMint),
+		accounts.tokenAAccount,
+		accounts.tokenBAccount,
+		accounts.poolState.TokenAVault,
+		accounts.poolState.TokenBVault,
+		accounts.poolState.TokenAMint,
+		accounts.poolState.TokenBMint,
+		accounts.positionNftAccount,
+		owner,
+		accounts.tokenAProgram,
+		accounts.tokenBProgram,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := claimPositionFeePtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return claimPositionFeePtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+func (d *DammV2PositionService) removeLiquidityIx(
+	owner solana.PublicKey,
+	accounts dammV2PositionAccounts,
+	liquidityDelta *big.Int,
+	minAmountA, minAmountB uint64,
+) (solana.Instruction, error) {
+	if liquidityDelta.Sign() <= 0 {
+		return nil, errors.New("liquidity delta must be greater than 0")
+	}
+	if liquidityDelta.Cmp(accounts.positionState.UnlockedLiquidity.BigInt()) > 0 {
+		return nil, fmt.Errorf(
+			"liquidity delta(%s) exceeds unlocked liquidity(%s)",
+			liquidityDelta, accounts.positionState.UnlockedLiquidity.BigInt(),
+		)
+	}
+
+	liquidityDeltaU128, err := helpers.BigIntToUint128(liquidityDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	removeLiquidityPtr := dammv2.NewRemoveLiquidityInstruction(
+		dammv2.RemoveLiquidityParameters{
+			LiquidityDelta:        liquidityDeltaU128,
+			TokenAAmountThreshold: minAmountA,
+			TokenBAmountThreshold: minAmountB,
+		},
+		helpers.DeriveDammV2PoolAuthority(),
+		accounts.pool,
+		helpers.DerivePositionAddress(accounts.positionState.NftMint),
+		accounts.tokenAAccount,
+		accounts.tokenBAccount,
+		accounts.poolState.TokenAVault,
+		accounts.poolState.TokenBVault,
+		accounts.poolState.TokenAMint,
+		accounts.poolState.TokenBMint,
+		accounts.positionNftAccount,
+		owner,
+		accounts.tokenAProgram,
+		accounts.tokenBProgram,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := removeLiquidityPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return removeLiquidityPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+func (d *DammV2PositionService) removeAllLiquidityIx(
+	owner solana.PublicKey,
+	accounts dammV2PositionAccounts,
+	minAmountA, minAmountB uint64,
+) (solana.Instruction, error) {
+	removeAllLiquidityPtr := dammv2.NewRemoveAllLiquidityInstruction(
+		minAmountA,
+		minAmountB,
+		helpers.DeriveDammV2PoolAuthority(),
+		accounts.pool,
+		helpers.DerivePositionAddress(accounts.positionState.NftMint),
+		accounts.tokenAAccount,
+		accounts.tokenBAccount,
+		accounts.poolState.TokenAVault,
+		accounts.poolState.TokenBVault,
+		accounts.poolState.TokenAMint,
+		accounts.poolState.TokenBMint,
+		accounts.positionNftAccount,
+		owner,
+		accounts.tokenAProgram,
+		accounts.tokenBProgram,
+		solana.PublicKey{},
+		constants.DammV2ProgramId,
+	)
+	eventAuthPDA, _, err := removeAllLiquidityPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return removeAllLiquidityPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
@@ -144,7 +144,10 @@ func (m *MigrationService) WithdrawLeftover(
 	}
 
 	ixns := make([]solana.Instruction, 0, 2)
-	return append(ixns, ix, currentIx), nil
+	if ix != nil {
+		ixns = append(ixns, ix)
+	}
+	return append(ixns, currentIx), nil
 }
 
 ///////////////////////
@@ -235,7 +238,13 @@ func (m *MigrationService) MigrateToDammV1(
 	wg.Wait()
 
 	preInstructions := make([]solana.Instruction, 0, 2)
-	aVaultLpMint, bVaultLpMint := aVaultAccount.LpMint, bVaultAccount.LpMint
+	aVaultLpMint, bVaultLpMint := vaultPDAsA.LPMintPDA, vaultPDAsB.LPMintPDA
+	if aVaultAccount != nil {
+		aVaultLpMint = aVaultAccount.LpMint
+	}
+	if bVaultAccount != nil {
+		bVaultLpMint = bVaultAccount.LpMint
+	}
 	if aVaultAccount == nil {
 		createVaultAIx, err := helpers.CreateInitializePermissionlessDynamicVaultIx(
 			poolState.BaseMint,
@@ -370,8 +379,16 @@ func (m *MigrationService) LockDammV1LpToken(
 		*out = result
 	}(&bVaultAccount)
 
+	wg.Wait()
+
 	preInstructions := make([]solana.Instruction, 0, 2)
-	aVaultLpMint, bVaultLpMint := aVaultAccount.LpMint, bVaultAccount.LpMint
+	aVaultLpMint, bVaultLpMint := vaultPDAsA.LPMintPDA, vaultPDAsB.LPMintPDA
+	if aVaultAccount != nil {
+		aVaultLpMint = aVaultAccount.LpMint
+	}
+	if bVaultAccount != nil {
+		bVaultLpMint = bVaultAccount.LpMint
+	}
 	if aVaultAccount == nil {
 		createVaultAIx, err := helpers.CreateInitializePermissionlessDynamicVaultIx(
 			poolState.BaseMint,
@@ -543,7 +560,7 @@ func (m *MigrationService) ClaimDammV1LpToken(
 
 	} else {
 		destinationToken, err = helpers.FindAssociatedTokenAddress(
-			poolConfigState.FeeClaimer,
+			virtualPoolState.Creator,
 			lpMint,
 			solana.TokenProgramID,
 		)
@@ -639,7 +656,7 @@ func (m *MigrationService) MigrateToDammV2(
 		return types.MigrateToDammV2Response{}, fmt.Errorf("pool config(%s) not found: err: %w", param.VirtualPool.String(), err)
 	}
 
-	dammPool := helpers.DeriveDammV1PoolAddress(
+	dammPool := helpers.DeriveDammV2PoolAddress(
 		param.DammConfig,
 		virtualPoolState.BaseMint,
 		poolConfigState.QuoteMint,
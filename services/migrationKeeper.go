@@ -0,0 +1,475 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// maxKeeperStepsPerRound bounds the steps run for one pool in one round, it is above the
+// number of migration steps so a pool normally completes in a single round.
+const maxKeeperStepsPerRound = 16
+
+// MigrationKeeper watches pool configs for completed curves and runs their migration steps until
+// nothing is left for its signer. Failures are retried with exponential backoff and the progress is
+// checkpointed, so a restarted keeper resumes where it stopped.
+type MigrationKeeper struct {
+	migration  *MigrationService
+	param      types.MigrationKeeperParam
+	checkpoint types.MigrationKeeperCheckpoint
+}
+
+func NewMigrationKeeper(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+	param types.MigrationKeeperParam,
+) (*MigrationKeeper, error) {
+	if param.Signer == nil || param.Sender == nil {
+		return nil, errors.New("signer and sender are required")
+	}
+	if len(param.Configs) == 0 && !param.WatchEvents {
+		return nil, errors.New("nothing to watch: set configs or enable events")
+	}
+
+	if param.PollInterval <= 0 {
+		param.PollInterval = 30 * time.Second
+	}
+	if param.MaxAttempts <= 0 {
+		param.MaxAttempts = 5
+	}
+	if param.BaseBackoff <= 0 {
+		param.BaseBackoff = 5 * time.Second
+	}
+	if param.MaxBackoff <= 0 {
+		param.MaxBackoff = 10 * time.Minute
+	}
+
+	return &MigrationKeeper{
+		migration: NewMigrationService(conn, commitment),
+		param:     param,
+	}, nil
+}
+
+// Run loads the checkpoint and runs a round every PollInterval until ctx is done.
+func (k *MigrationKeeper) Run(ctx context.Context) error {
+	if err := k.load(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(k.param.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := k.round(ctx); err != nil && ctx.Err() == nil {
+			k.report(ctx, types.MigrationKeeperOutcome{Err: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce loads the checkpoint and runs a single round: discover completed curves, run the due
+// migration steps and save the checkpoint.
+func (k *MigrationKeeper) RunOnce(ctx context.Context) error {
+	if err := k.load(ctx); err != nil {
+		return err
+	}
+	return k.round(ctx)
+}
+
+// Checkpoint gets a copy of the keeper progress.
+func (k *MigrationKeeper) Checkpoint() types.MigrationKeeperCheckpoint {
+	checkpoint := types.MigrationKeeperCheckpoint{
+		LastSignature: k.checkpoint.LastSignature,
+		Pools:         make(map[solana.PublicKey]types.MigrationKeeperPoolState, len(k.checkpoint.Pools)),
+	}
+	for pool, state := range k.checkpoint.Pools {
+		checkpoint.Pools[pool] = state
+	}
+	return checkpoint
+}
+
+func (k *MigrationKeeper) load(ctx context.Context) error {
+	if k.checkpoint.Pools != nil {
+		return nil
+	}
+
+	k.checkpoint.Pools = make(map[solana.PublicKey]types.MigrationKeeperPoolState)
+	if k.param.CheckpointStore == nil {
+		return nil
+	}
+
+	checkpoint, err := k.param.CheckpointStore.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpoint.Pools != nil {
+		k.checkpoint = checkpoint
+	}
+	k.checkpoint.LastSignature = checkpoint.LastSignature
+	return nil
+}
+
+func (k *MigrationKeeper) save(ctx context.Context) error {
+	if k.param.CheckpointStore == nil {
+		return nil
+	}
+	return k.param.CheckpointStore.Save(ctx, k.checkpoint)
+}
+
+func (k *MigrationKeeper) round(ctx context.Context) error {
+	discoverErr := k.discover(ctx)
+
+	pools := make([]solana.PublicKey, 0, len(k.checkpoint.Pools))
+	for pool, state := range k.checkpoint.Pools {
+		if !state.Done && !state.Abandoned {
+			pools = append(pools, pool)
+		}
+	}
+	// a stable order keeps rounds reproducible
+	slices.SortFunc(pools, func(a, b solana.PublicKey) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	for _, pool := range pools {
+		if ctx.Err() != nil {
+			break
+		}
+		k.processPool(ctx, pool)
+	}
+
+	if err := k.save(ctx); err != nil {
+		return err
+	}
+	return discoverErr
+}
+
+// discover adds the pools with a completed curve to the checkpoint.
+func (k *MigrationKeeper) discover(ctx context.Context) error {
+	var errs []error
+
+	for _, config := range k.param.Configs {
+		pools, err := k.migration.state.GetPoolsByConfig(ctx, config)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot fetch pools of config(%s): %w", config, err))
+			continue
+		}
+		for _, pool := range pools {
+			if types.MigrationProgress(pool.Account.MigrationProgress) > types.MigrationProgressPreBondingCurve {
+				k.track(pool.PublicKey)
+			}
+		}
+	}
+
+	if k.param.WatchEvents {
+		if err := k.discoverFromEvents(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// discoverFromEvents scans the program transactions landed since the checkpoint for EvtCurveComplete.
+// The first scan only records the newest transaction, older curves are found by polling the configs.
+func (k *MigrationKeeper) discoverFromEvents(ctx context.Context) error {
+	conn, commitment := k.migration.state.conn, k.migration.state.commitment
+	if commitment == rpc.CommitmentProcessed {
+		commitment = rpc.CommitmentConfirmed
+	}
+
+	var (
+		limit      = 1_000
+		signatures []*rpc.TransactionSignature
+		before     solana.Signature
+	)
+	for {
+		page, err := conn.GetSignaturesForAddressWithOpts(ctx, k.migration.state.GetProgramID(), &rpc.GetSignaturesForAddressOpts{
+			Limit:      &limit,
+			Before:     before,
+			Until:      k.checkpoint.LastSignature,
+			Commitment: commitment,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot fetch program signatures: %w", err)
+		}
+		signatures = append(signatures, page...)
+
+		if len(page) < limit || k.checkpoint.LastSignature.IsZero() {
+			break
+		}
+		before = page[len(page)-1].Signature
+	}
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	if !k.checkpoint.LastSignature.IsZero() {
+		configs := make(map[solana.PublicKey]bool, len(k.param.Configs))
+		for _, config := range k.param.Configs {
+			configs[config] = true
+		}
+
+		maxSupportedTransactionVersion := uint64(0)
+		getAddressTables := func(
+			altAddresses []solana.PublicKey,
+		) (map[solana.PublicKey]solana.PublicKeySlice, error) {
+			tables := make(map[solana.PublicKey]solana.PublicKeySlice, len(altAddresses))
+			for _, address := range altAddresses {
+				table, err := addresslookuptable.GetAddressLookupTable(ctx, conn, address)
+				if err != nil {
+					return nil, fmt.Errorf("cannot fetch address lookup table(%s): %w", address, err)
+				}
+				tables[address] = table.Addresses
+			}
+			return tables, nil
+		}
+
+		// oldest first, the checkpoint only moves past fully scanned transactions
+		for i := len(signatures) - 1; i >= 0; i-- {
+			if signatures[i].Err != nil {
+				k.checkpoint.LastSignature = signatures[i].Signature
+				continue
+			}
+
+			tx, err := conn.GetTransaction(ctx, signatures[i].Signature, &rpc.GetTransactionOpts{
+				Encoding:                       solana.EncodingBase64,
+				Commitment:                     commitment,
+				MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+			})
+			if err != nil {
+				return fmt.Errorf("cannot fetch transaction(%s): %w", signatures[i].Signature, err)
+			}
+
+			events, err := dbc.DecodeEvents(tx, k.migration.state.GetProgramID(), getAddressTables)
+			if err != nil {
+				return fmt.Errorf("cannot decode events of transaction(%s): %w", signatures[i].Signature, err)
+			}
+			for _, event := range events {
+				curveComplete, ok := event.Data.(*dbc.EvtCurveCompleteEventData)
+				if !ok {
+					continue
+				}
+				if len(configs) == 0 || configs[curveComplete.Config] {
+					k.track(curveComplete.Pool)
+				}
+			}
+
+			k.checkpoint.LastSignature = signatures[i].Signature
+		}
+		return nil
+	}
+
+	k.checkpoint.LastSignature = signatures[0].Signature
+	return nil
+}
+
+func (k *MigrationKeeper) track(pool solana.PublicKey) {
+	if _, ok := k.checkpoint.Pools[pool]; !ok {
+		k.checkpoint.Pools[pool] = types.MigrationKeeperPoolState{}
+	}
+}
+
+// processPool runs the due migration steps of a pool one at a time. The steps are planned again
+// from the chain after every step, so a step is never built from state an earlier step changed.
+func (k *MigrationKeeper) processPool(ctx context.Context, pool solana.PublicKey) {
+	state := k.checkpoint.Pools[pool]
+	if time.Now().Before(state.NextAttempt) {
+		return
+	}
+	defer func() { k.checkpoint.Pools[pool] = state }()
+
+	var lastStep *types.MigrationStep
+	for range maxKeeperStepsPerRound {
+		steps, err := k.migration.BuildRemainingMigration(ctx, types.MigrationOrchestratorParam{
+			Payer:       k.param.Signer.Payer(),
+			VirtualPool: pool,
+		})
+		if err != nil {
+			k.fail(ctx, pool, &state, nil, err)
+			return
+		}
+
+		index := slices.IndexFunc(steps, func(step types.MigrationStepInstructions) bool {
+			return !slices.ContainsFunc(step.Signers, func(signer solana.PublicKey) bool {
+				return !k.param.Signer.CanSign(signer)
+			})
+		})
+		if index < 0 {
+			state = types.MigrationKeeperPoolState{Done: true}
+			k.report(ctx, types.MigrationKeeperOutcome{Pool: pool, Done: true})
+			return
+		}
+		step := steps[index]
+
+		// the step landed but the chain does not show it yet, wait for the next round
+		if lastStep != nil && *lastStep == step.Step {
+			return
+		}
+
+		signature, err := k.execute(ctx, step)
+		if err != nil {
+			k.fail(ctx, pool, &state, &step.Step, err)
+			return
+		}
+
+		state.Attempts, state.LastError, state.NextAttempt = 0, "", time.Time{}
+		k.report(ctx, types.MigrationKeeperOutcome{Pool: pool, Step: step.Step, Signature: signature})
+		lastStep = &step.Step
+	}
+}
+
+func (k *MigrationKeeper) execute(
+	ctx context.Context,
+	step types.MigrationStepInstructions,
+) (solana.Signature, error) {
+
+	blockhash, err := k.param.Sender.GetLatestBlockhash(ctx)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("cannot get latest blockhash: %w", err)
+	}
+
+	tx, err := solana.NewTransaction(step.Ixns, blockhash, solana.TransactionPayer(k.param.Signer.Payer()))
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("error building txn: %w", err)
+	}
+
+	if len(step.Keypairs) > 0 {
+		if _, err := tx.PartialSign(func(key solana.PublicKey) *solana.PrivateKey {
+			for i := range step.Keypairs {
+				if step.Keypairs[i].PublicKey().Equals(key) {
+					return &step.Keypairs[i]
+				}
+			}
+			return nil
+		}); err != nil {
+			return solana.Signature{}, fmt.Errorf("unable to sign transaction: %w", err)
+		}
+	}
+
+	if err := k.param.Signer.Sign(ctx, tx); err != nil {
+		return solana.Signature{}, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	return k.param.Sender.SendAndConfirm(ctx, tx)
+}
+
+func (k *MigrationKeeper) fail(
+	ctx context.Context,
+	pool solana.PublicKey,
+	state *types.MigrationKeeperPoolState,
+	step *types.MigrationStep,
+	err error,
+) {
+	state.Attempts++
+	state.LastError = err.Error()
+	state.NextAttempt = time.Now().Add(
+		helpers.GetKeeperBackoff(state.Attempts, k.param.BaseBackoff, k.param.MaxBackoff),
+	)
+	state.Abandoned = state.Attempts >= k.param.MaxAttempts
+
+	outcome := types.MigrationKeeperOutcome{
+		Pool:      pool,
+		Attempt:   state.Attempts,
+		Err:       err,
+		Abandoned: state.Abandoned,
+	}
+	if step != nil {
+		outcome.Step = *step
+	}
+	k.report(ctx, outcome)
+}
+
+func (k *MigrationKeeper) report(ctx context.Context, outcome types.MigrationKeeperOutcome) {
+	if k.param.Reporter != nil {
+		k.param.Reporter.Report(ctx, outcome)
+	}
+}
+
+// RpcTransactionSender is a TransactionSender sending through an RPC node and polling the
+// signature status until the transaction reaches the commitment.
+type RpcTransactionSender struct {
+	conn       *rpc.Client
+	commitment rpc.CommitmentType
+}
+
+func NewRpcTransactionSender(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *RpcTransactionSender {
+	return &RpcTransactionSender{conn: conn, commitment: commitment}
+}
+
+func (r *RpcTransactionSender) GetLatestBlockhash(ctx context.Context) (solana.Hash, error) {
+	out, err := r.conn.GetLatestBlockhash(ctx, r.commitment)
+	if err != nil {
+		return solana.Hash{}, err
+	}
+	return out.Value.Blockhash, nil
+}
+
+// SendAndConfirm sends tx and waits for it, a blockhash expires after about a minute.
+func (r *RpcTransactionSender) SendAndConfirm(
+	ctx context.Context,
+	tx *solana.Transaction,
+) (solana.Signature, error) {
+
+	signature, err := r.conn.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		PreflightCommitment: r.commitment,
+	})
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("error from sent txn: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return signature, fmt.Errorf("txn(%s) not confirmed: %w", signature, ctx.Err())
+		case <-ticker.C:
+		}
+
+		statuses, err := r.conn.GetSignatureStatuses(ctx, false, signature)
+		if err != nil || len(statuses.Value) == 0 || statuses.Value[0] == nil {
+			continue
+		}
+
+		status := statuses.Value[0]
+		if status.Err != nil {
+			return signature, fmt.Errorf("txn(%s) failed: %v", signature, status.Err)
+		}
+		if isConfirmed(status.ConfirmationStatus, r.commitment) {
+			return signature, nil
+		}
+	}
+}
+
+func isConfirmed(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	switch commitment {
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	case rpc.CommitmentConfirmed:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	default:
+		return status != ""
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// GetRemainingMigrationSteps gets the steps left to fully migrate a pool from its migration progress,
+// migration metadata, surplus and migration fee state.
+func (m *MigrationService) GetRemainingMigrationSteps(
+	ctx context.Context,
+	virtualPool solana.PublicKey,
+) ([]types.MigrationStep, error) {
+	stepsParam, err := m.getMigrationStepsParam(ctx, virtualPool)
+	if err != nil {
+		return nil, err
+	}
+	return helpers.GetRemainingMigrationSteps(stepsParam)
+}
+
+// BuildRemainingMigration builds the instructions of every migration step still needed, one entry per
+// transaction in execution order. Steps already done on chain are left out, so it is safe to call again
+// after a partial failure. Partner and creator steps must also be signed by the fee claimer or the creator.
+func (m *MigrationService) BuildRemainingMigration(
+	ctx context.Context,
+	param types.MigrationOrchestratorParam,
+) ([]types.MigrationStepInstructions, error) {
+
+	stepsParam, err := m.getMigrationStepsParam(ctx, param.VirtualPool)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := helpers.GetRemainingMigrationSteps(stepsParam)
+	if err != nil {
+		return nil, err
+	}
+
+	poolState, poolConfigState := stepsParam.Pool, stepsParam.Config
+	isDammV1 := types.MigrationOption(poolConfigState.MigrationOption) == types.MigrationOptionMET_DAMM
+
+	migrationFeeAddresses := constants.DammV2MigrationFeeAddresses
+	if isDammV1 {
+		migrationFeeAddresses = constants.DammV1MigrationFeeAddresses
+	}
+	if int(poolConfigState.MigrationFeeOption) >= len(migrationFeeAddresses) {
+		return nil, fmt.Errorf("invalid migrationFeeOption(%d)", poolConfigState.MigrationFeeOption)
+	}
+	dammConfig := migrationFeeAddresses[poolConfigState.MigrationFeeOption]
+
+	partner := &PartnerService{state: m.state}
+	creator := &CreatorService{state: m.state}
+
+	result := make([]types.MigrationStepInstructions, 0, len(steps))
+	for _, step := range steps {
+		current := types.MigrationStepInstructions{Step: step}
+
+		switch step {
+		case types.MigrationStepCreateLocker:
+			current.Ixns, err = m.CreateLocker(ctx, types.CreateLockerParam{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+			})
+
+		case types.MigrationStepCreateMigrationMetadata:
+			metadataParam := types.CreateDammV1MigrationMetadataParam{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+				Config:      poolState.Config,
+			}
+			var ix *dbc.Instruction
+			if isDammV1 {
+				ix, err = m.CreateDammV1MigrationMetadata(metadataParam)
+			} else {
+				ix, err = m.CreateDammV2MigrationMetadata(
+					types.CreateDammV2MigrationMetadataParam{CreateDammV1MigrationMetadataParam: metadataParam},
+				)
+			}
+			if err == nil {
+				current.Ixns = []solana.Instruction{ix}
+			}
+
+		case types.MigrationStepMigrate:
+			migrateParam := types.MigrateToDammV1Param{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+				DammConfig:  dammConfig,
+			}
+			if isDammV1 {
+				current.Ixns, err = m.MigrateToDammV1(ctx, migrateParam)
+			} else {
+				var response types.MigrateToDammV2Response
+				response, err = m.MigrateToDammV2(ctx, types.MigrateToDammV2Param{MigrateToDammV1Param: migrateParam})
+				current.Ixns = response.Ixns
+				current.Keypairs = []solana.PrivateKey{
+					response.FirstPositionNftKeypair,
+					response.SecondPositionNftKeypair,
+				}
+			}
+
+		case types.MigrationStepLockPartnerLp, types.MigrationStepLockCreatorLp:
+			current.Ixns, err = m.LockDammV1LpToken(ctx, types.DammLpTokenParam{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+				DammConfig:  dammConfig,
+				IsPartner:   step == types.MigrationStepLockPartnerLp,
+			})
+
+		case types.MigrationStepClaimPartnerLp, types.MigrationStepClaimCreatorLp:
+			current.Ixns, err = m.ClaimDammV1LpToken(ctx, types.DammLpTokenParam{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+				DammConfig:  dammConfig,
+				IsPartner:   step == types.MigrationStepClaimPartnerLp,
+			})
+
+		case types.MigrationStepWithdrawLeftover:
+			current.Ixns, err = m.WithdrawLeftover(ctx, types.WithdrawLeftoverParam{
+				Payer:       param.Payer,
+				VirtualPool: param.VirtualPool,
+			})
+
+		case types.MigrationStepPartnerWithdrawSurplus:
+			current.Signers = []solana.PublicKey{poolConfigState.FeeClaimer}
+			current.Ixns, err = partner.PartnerWithdrawSurplus(ctx, types.PartnerWithdrawSurplusParam{
+				FeeClaimer:  poolConfigState.FeeClaimer,
+				VirtualPool: param.VirtualPool,
+			})
+
+		case types.MigrationStepCreatorWithdrawSurplus:
+			current.Signers = []solana.PublicKey{poolState.Creator}
+			current.Ixns, err = creator.CreatorWithdrawSurplus(ctx, types.CreatorWithdrawSurplusParam{
+				Creator:     poolState.Creator,
+				VirtualPool: param.VirtualPool,
+			})
+
+		case types.MigrationStepPartnerWithdrawMigrationFee:
+			current.Signers = []solana.PublicKey{poolConfigState.FeeClaimer}
+			current.Ixns, err = partner.PartnerWithdrawMigrationFee(ctx, types.WithdrawMigrationFeeParam{
+				VirtualPool: param.VirtualPool,
+				Sender:      poolConfigState.FeeClaimer,
+				FeePayer:    &param.Payer,
+			})
+
+		case types.MigrationStepCreatorWithdrawMigrationFee:
+			current.Signers = []solana.PublicKey{poolState.Creator}
+			current.Ixns, err = creator.CreatorWithdrawMigrationFee(ctx, types.WithdrawMigrationFeeParam{
+				VirtualPool: param.VirtualPool,
+				Sender:      poolState.Creator,
+				FeePayer:    &param.Payer,
+			})
+
+		default:
+			err = fmt.Errorf("unknown migration step(%d)", step)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("migration step(%d): %w", step, err)
+		}
+
+		result = append(result, current)
+	}
+
+	return result, nil
+}
+
+func (m *MigrationService) getMigrationStepsParam(
+	ctx context.Context,
+	virtualPool solana.PublicKey,
+) (types.MigrationStepsParam, error) {
+
+	poolState, err := m.state.GetPool(ctx, virtualPool)
+	if err != nil {
+		return types.MigrationStepsParam{}, fmt.Errorf("pool(%s) not found: err: %w", virtualPool.String(), err)
+	}
+
+	poolConfigState, err := m.state.GetPoolConfig(ctx, poolState.Config)
+	if err != nil {
+		return types.MigrationStepsParam{}, fmt.Errorf("pool config(%s) not found: err: %w", virtualPool.String(), err)
+	}
+
+	surplus, err := m.state.GetPoolSurplus(ctx, virtualPool)
+	if err != nil {
+		return types.MigrationStepsParam{}, err
+	}
+
+	stepsParam := types.MigrationStepsParam{
+		Pool:    poolState,
+		Config:  poolConfigState,
+		Surplus: surplus,
+	}
+
+	isDammV1 := types.MigrationOption(poolConfigState.MigrationOption) == types.MigrationOptionMET_DAMM
+
+	migrationMetadata := helpers.DeriveDammV2MigrationMetadataAddress(virtualPool)
+	if isDammV1 {
+		migrationMetadata = helpers.DeriveDammV1MigrationMetadataAddress(virtualPool)
+	}
+	_, err = m.state.conn.GetAccountInfoWithOpts(
+		ctx, migrationMetadata, &rpc.GetAccountInfoOpts{Commitment: m.state.commitment},
+	)
+	switch {
+	case err == nil:
+		stepsParam.MigrationMetadataExists = true
+	case !errors.Is(err, rpc.ErrNotFound):
+		return types.MigrationStepsParam{}, fmt.Errorf("cannot fetch migration metadata(%s): %w", migrationMetadata, err)
+	}
+
+	if isDammV1 && stepsParam.MigrationMetadataExists {
+		if stepsParam.DammV1MigrationMetadata, err = m.state.GetDammV1MigrationMetadata(ctx, virtualPool); err != nil {
+			return types.MigrationStepsParam{}, fmt.Errorf("cannot fetch damm v1 migration metadata: %w", err)
+		}
+	}
+
+	return stepsParam, nil
+}
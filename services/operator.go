@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/anchor"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/types"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+type OperatorService struct {
+	state *StateService
+}
+
+func NewOperatorService(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *OperatorService {
+	return &OperatorService{
+		state: NewStateService(conn, commitment),
+	}
+}
+
+// CreateClaimFeeOperator lets the program admin authorise an operator to claim protocol fees.
+func (o *OperatorService) CreateClaimFeeOperator(
+	param types.CreateClaimFeeOperatorParam,
+) (*dbc.Instruction, error) {
+
+	createClaimFeeOperatorPtr := dbc.NewCreateClaimFeeOperatorInstruction(
+		helpers.DeriveClaimFeeOperator(param.Operator),
+		param.Operator,
+		param.Admin,
+		solana.SystemProgramID,
+		solana.PublicKey{},
+		constants.DBCProgramId,
+	)
+	eventAuthPDA, _, err := createClaimFeeOperatorPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return createClaimFeeOperatorPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+// CloseClaimFeeOperator lets the program admin revoke an operator, returning the rent to rentReceiver.
+func (o *OperatorService) CloseClaimFeeOperator(
+	param types.CloseClaimFeeOperatorParam,
+) (*dbc.Instruction, error) {
+
+	rentReceiver := param.Admin
+	if !param.RentReceiver.IsZero() {
+		rentReceiver = param.RentReceiver
+	}
+
+	closeClaimFeeOperatorPtr := dbc.NewCloseClaimFeeOperatorInstruction(
+		helpers.DeriveClaimFeeOperator(param.Operator),
+		rentReceiver,
+		param.Admin,
+		solana.PublicKey{},
+		constants.DBCProgramId,
+	)
+	eventAuthPDA, _, err := closeClaimFeeOperatorPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	return closeClaimFeeOperatorPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+}
+
+// ClaimProtocolFee claims the protocol base and quote fees of a pool into the receiver token accounts,
+// creating them when missing.
+func (o *OperatorService) ClaimProtocolFee(
+	ctx context.Context,
+	param types.ClaimProtocolFeeParam,
+) ([]solana.Instruction, error) {
+
+	poolState, err := o.state.GetPool(ctx, param.Pool)
+	if err != nil {
+		return nil, fmt.Errorf("pool(%s) not found: error: %w", param.Pool.String(), err)
+	}
+
+	poolConfigState, err := o.state.GetPoolConfig(ctx, poolState.Config)
+	if err != nil {
+		return nil, fmt.Errorf("pool config(%s) not found: error: %w", param.Pool.String(), err)
+	}
+
+	tokenBaseProgram := helpers.GetTokenProgram(poolConfigState.TokenType)
+	tokenQuoteProgram := helpers.GetTokenProgram(poolConfigState.QuoteTokenFlag)
+
+	payer := param.Operator
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+	prepareTokenAccounts, err := o.state.prepareTokenAccounts(
+		ctx,
+		types.PrepareTokenAccountParams{
+			Owner:         param.Receiver,
+			Payer:         payer,
+			TokenAMint:    poolState.BaseMint,
+			TokenBMint:    poolConfigState.QuoteMint,
+			TokenAProgram: tokenBaseProgram,
+			TokenBProgram: tokenQuoteProgram,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claimProtocolFeePtr := dbc.NewClaimProtocolFeeInstruction(
+		o.state.GetPoolAuthority(),
+		poolState.Config,
+		param.Pool,
+		poolState.BaseVault,
+		poolState.QuoteVault,
+		poolState.BaseMint,
+		poolConfigState.QuoteMint,
+		prepareTokenAccounts.TokenAAta,
+		prepareTokenAccounts.TokenBAta,
+		helpers.DeriveClaimFeeOperator(param.Operator),
+		param.Operator,
+		tokenBaseProgram,
+		tokenQuoteProgram,
+		solana.PublicKey{},
+		constants.DBCProgramId,
+	)
+	eventAuthPDA, _, err := claimProtocolFeePtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	currentIx, err := claimProtocolFeePtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(prepareTokenAccounts.CreateATAIxns)+1)
+	ixns = append(ixns, prepareTokenAccounts.CreateATAIxns...)
+	return append(ixns, currentIx), nil
+}
+
+// ProtocolWithdrawSurplus withdraws the protocol share of a migrated pool's surplus into the receiver
+// quote token account, creating it when missing.
+func (o *OperatorService) ProtocolWithdrawSurplus(
+	ctx context.Context,
+	param types.ProtocolWithdrawSurplusParam,
+) ([]solana.Instruction, error) {
+
+	poolState, err := o.state.GetPool(ctx, param.Pool)
+	if err != nil {
+		return nil, fmt.Errorf("pool(%s) not found: error: %w", param.Pool.String(), err)
+	}
+
+	poolConfigState, err := o.state.GetPoolConfig(ctx, poolState.Config)
+	if err != nil {
+		return nil, fmt.Errorf("pool config(%s) not found: error: %w", param.Pool.String(), err)
+	}
+
+	tokenQuoteProgram := helpers.GetTokenProgram(poolConfigState.QuoteTokenFlag)
+
+	payer := param.Receiver
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+	tokenQuoteAccount, ix, err := helpers.GetOrCreateATAInstruction(
+		ctx,
+		o.state.conn,
+		poolConfigState.QuoteMint,
+		param.Receiver,
+		payer,
+		true,
+		tokenQuoteProgram,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolWithdrawSurplusPtr := dbc.NewProtocolWithdrawSurplusInstruction(
+		o.state.GetPoolAuthority(),
+		poolState.Config,
+		param.Pool,
+		tokenQuoteAccount,
+		poolState.QuoteVault,
+		poolConfigState.QuoteMint,
+		tokenQuoteProgram,
+		solana.PublicKey{},
+		constants.DBCProgramId,
+	)
+	eventAuthPDA, _, err := protocolWithdrawSurplusPtr.FindEventAuthorityAddress()
+	if err != nil {
+		return nil, fmt.Errorf("err deriving eventAuthPDA: %w", err)
+	}
+
+	currentIx, err := protocolWithdrawSurplusPtr.
+		SetEventAuthorityAccount(eventAuthPDA).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, 2)
+	if ix != nil {
+		ixns = append(ixns, ix)
+	}
+	return append(ixns, currentIx), nil
+}
+
+// GetClaimFeeOperator get the claim fee operator account of an operator wallet.
+func (o *OperatorService) GetClaimFeeOperator(
+	ctx context.Context,
+	operator solana.PublicKey,
+) (*dbc.ClaimFeeOperatorAccount, error) {
+	return anchor.NewPgAccounts(
+		o.state.conn,
+		func() *dbc.ClaimFeeOperatorAccount { return &dbc.ClaimFeeOperatorAccount{} },
+	).Fetch(
+		ctx,
+		helpers.DeriveClaimFeeOperator(operator),
+		&rpc.GetAccountInfoOpts{Commitment: o.state.commitment},
+	)
+}
+
+// GetClaimFeeOperators get all claim fee operator accounts.
+func (o *OperatorService) GetClaimFeeOperators(
+	ctx context.Context,
+) ([]anchor.ProgramAccount[*dbc.ClaimFeeOperatorAccount], error) {
+	return anchor.NewPgAccounts(
+		o.state.conn,
+		func() *dbc.ClaimFeeOperatorAccount { return &dbc.ClaimFeeOperatorAccount{} },
+	).All(
+		ctx,
+		o.state.GetProgramID(),
+		dbc.ClaimFeeOperatorAccountDiscriminator,
+		rpc.GetProgramAccountsOpts{},
+		nil,
+	)
+}
@@ -31,7 +31,10 @@ func (p *PartnerService) CreateConfigParam(
 	param types.CreateConfigParam,
 ) (*dbc.Instruction, error) {
 
-	// TODO: validityChecks
+	if err := helpers.ValidateConfigParameters(param.ConfigParameters); err != nil {
+		return nil, fmt.Errorf("invalid config parameters: %w", err)
+	}
+
 	createConfigPtr := dbc.NewCreateConfigInstruction(
 		param.ConfigParameters,
 		param.Config,
@@ -539,7 +542,7 @@ func (p *PartnerService) PartnerWithdrawSurplus(
 		return nil, err
 	}
 	var unwrapSolIx *token.Instruction
-	if !poolConfigState.QuoteMint.Equals(solana.WrappedSol) {
+	if poolConfigState.QuoteMint.Equals(solana.WrappedSol) {
 		if unwrapSolIx, err = helpers.UnwrapSOLInstruction(
 			param.FeeClaimer,
 			param.FeeClaimer,
@@ -573,8 +576,14 @@ func (p *PartnerService) PartnerWithdrawSurplus(
 	}
 
 	ixns := make([]solana.Instruction, 0, 1+1+1)
-	ixns = append(ixns, ix, currentIx, unwrapSolIx)
-	return append(ixns, currentIx), nil
+	if ix != nil {
+		ixns = append(ixns, ix)
+	}
+	ixns = append(ixns, currentIx)
+	if unwrapSolIx != nil {
+		ixns = append(ixns, unwrapSolIx)
+	}
+	return ixns, nil
 }
 
 // PartnerWithdrawMigrationFee partner  withdraw migration fee.
@@ -596,7 +605,7 @@ func (p *PartnerService) PartnerWithdrawMigrationFee(
 	tokenQuoteProgram := helpers.GetTokenProgram(configState.QuoteTokenFlag)
 
 	feePayer := param.Sender
-	if !param.FeePayer.IsZero() {
+	if param.FeePayer != nil && !param.FeePayer.IsZero() {
 		feePayer = *param.FeePayer
 	}
 	tokenQuoteAccount, ix, err := helpers.GetOrCreateATAInstruction(
@@ -612,7 +621,7 @@ func (p *PartnerService) PartnerWithdrawMigrationFee(
 		return nil, err
 	}
 	var unwrapSolIx *token.Instruction
-	if !configState.QuoteMint.Equals(solana.WrappedSol) {
+	if configState.QuoteMint.Equals(solana.WrappedSol) {
 		if unwrapSolIx, err = helpers.UnwrapSOLInstruction(
 			param.Sender,
 			param.Sender,
@@ -647,6 +656,12 @@ func (p *PartnerService) PartnerWithdrawMigrationFee(
 	}
 
 	ixns := make([]solana.Instruction, 0, 1+1+1)
-	ixns = append(ixns, ix, currentIx, unwrapSolIx)
-	return append(ixns, currentIx), nil
+	if ix != nil {
+		ixns = append(ixns, ix)
+	}
+	ixns = append(ixns, currentIx)
+	if unwrapSolIx != nil {
+		ixns = append(ixns, unwrapSolIx)
+	}
+	return ixns, nil
 }
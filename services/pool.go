@@ -129,7 +129,9 @@ func (p *PoolService) createConfigIx(
 	config, feeClaimer, leftoverReceiver, quoteMint, payer solana.PublicKey,
 ) (*dbc.Instruction, error) {
 
-	// TODO: validation func
+	if err := helpers.ValidateConfigParameters(configParam); err != nil {
+		return nil, fmt.Errorf("invalid config parameters: %w", err)
+	}
 
 	createConfigPtr := dbc.NewCreateConfigInstruction(
 		configParam,
@@ -195,6 +197,33 @@ func (p *PoolService) createPoolIx(
 	})
 }
 
+// useLaunchKeypairs replaces the base mint, and the config when config is not nil, with the keypairs
+// of param.KeypairProvider. They are copied to param.LaunchKeypairs, required to sign the transaction.
+func useLaunchKeypairs(
+	ctx context.Context,
+	param *types.PreCreatePoolParam,
+	config *solana.PublicKey,
+) error {
+	if param.KeypairProvider == nil {
+		return nil
+	}
+	if param.LaunchKeypairs == nil {
+		return errors.New("LaunchKeypairs is required with KeypairProvider to keep the keypairs signing the launch")
+	}
+
+	keypairs, err := param.KeypairProvider.NextLaunchKeypairs(ctx)
+	if err != nil {
+		return err
+	}
+
+	param.BaseMint = keypairs.BaseMint.PublicKey()
+	if config != nil {
+		*config = keypairs.Config.PublicKey()
+	}
+	*param.LaunchKeypairs = keypairs
+	return nil
+}
+
 // cswapBuyIx
 // reates first buy transaction.
 func (p *PoolService) swapBuyIx(
@@ -384,6 +413,9 @@ func (p *PoolService) CreatePool(
 	ctx context.Context,
 	param types.CreatePoolParam,
 ) (*dbc.Instruction, error) {
+	if err := useLaunchKeypairs(ctx, &param.PreCreatePoolParam, nil); err != nil {
+		return nil, err
+	}
 
 	poolConfigState, err := p.state.GetPoolConfig(ctx, param.Config)
 	if err != nil {
@@ -433,8 +465,18 @@ func (p *PoolService) CreatePool(
 // CreateConfigAndPool creates a new config and pool.
 func (p *PoolService) CreateConfigAndPool(
 	param types.CreateConfigAndPoolParam,
+) ([]solana.Instruction, error) {
+	return p.CreateConfigAndPoolWithContext(context.Background(), param)
+}
 
+// CreateConfigAndPoolWithContext creates a new config and pool, ctx bounds param.KeypairProvider.
+func (p *PoolService) CreateConfigAndPoolWithContext(
+	ctx context.Context,
+	param types.CreateConfigAndPoolParam,
 ) ([]solana.Instruction, error) {
+	if err := useLaunchKeypairs(ctx, &param.PreCreatePoolParam, &param.CreateConfigParam.Config); err != nil {
+		return nil, err
+	}
 
 	createConfigIx, err := p.createConfigIx(
 		param.CreateConfigParam.ConfigParameters,
@@ -472,6 +514,13 @@ func (p *PoolService) CreateConfigAndPoolWithFirstBuy(
 	CreateConfigIx, CreatePoolIx *dbc.Instruction
 	SwapBuyIxns                  []solana.Instruction
 }, error) {
+	if err := useLaunchKeypairs(ctx, &param.PreCreatePoolParam, &param.CreateConfigParam.Config); err != nil {
+		return struct {
+			CreateConfigIx *dbc.Instruction
+			CreatePoolIx   *dbc.Instruction
+			SwapBuyIxns    []solana.Instruction
+		}{}, err
+	}
 
 	createConfigIx, err := p.createConfigIx(
 		param.CreateConfigParam.ConfigParameters,
@@ -558,6 +607,13 @@ func (p *PoolService) CreatePoolWithFirstBuy(
 	CreatePoolIx *dbc.Instruction
 	SwapBuyIxns  []solana.Instruction
 }, error) {
+	if err := useLaunchKeypairs(ctx, &param.PreCreatePoolParam, nil); err != nil {
+		return struct {
+			CreatePoolIx *dbc.Instruction
+			SwapBuyIxns  []solana.Instruction
+		}{}, err
+	}
+
 	poolConfigState, err := p.state.GetPoolConfig(ctx, param.Config)
 	if err != nil {
 		return struct {
@@ -634,6 +690,13 @@ func (p *PoolService) CreatePoolWithPartnerAndCreatorFirstBuy(
 	CreatorPoolIx                      *dbc.Instruction
 	PartnerSwapBuyIx, CreatorSwapBuyIx []solana.Instruction
 }, error) {
+	if err := useLaunchKeypairs(ctx, &param.CreatePoolParam.PreCreatePoolParam, nil); err != nil {
+		return struct {
+			CreatorPoolIx    *dbc.Instruction
+			PartnerSwapBuyIx []solana.Instruction
+			CreatorSwapBuyIx []solana.Instruction
+		}{}, err
+	}
 
 	poolConfigState, err := p.state.GetPoolConfig(ctx, param.CreatePoolParam.Config)
 	if err != nil {
@@ -769,8 +832,6 @@ func (p *PoolService) Swap(
 		return nil, fmt.Errorf("swap:pool config (%s) not found: error: %w", param.Pool.String(), err)
 	}
 
-	// TODO: validation checks
-
 	currentPoint, err := helpers.GetCurrentPoint(
 		p.state.conn,
 		types.ActivationType(poolConfigState.ActivationType),
@@ -779,6 +840,62 @@ func (p *PoolService) Swap(
 		return nil, err
 	}
 
+	prepareSwapParams := p.prepareSwapParams(
+		param.SwapBaseForQuote,
+		types.VirtualPoolState{
+			BaseMint: poolState.BaseMint,
+			PoolType: types.TokenType(poolState.PoolType),
+		},
+		types.PoolConfigState{
+			QuoteMint:      poolConfigState.QuoteMint,
+			QuoteTokenFlag: types.TokenType(poolConfigState.QuoteTokenFlag),
+		},
+	)
+
+	// pre-flight checks
+	if err := helpers.ValidateSwapPool(poolState, poolConfigState, currentPoint); err != nil {
+		return nil, fmt.Errorf("swap: %w", err)
+	}
+	if param.AmountIn == 0 {
+		return nil, fmt.Errorf("swap: %w", types.ErrZeroAmount)
+	}
+	transferFeeParam, err := p.state.GetTransferFeeParam(ctx, poolState, poolConfigState)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := p.SwapQuote(types.SwapQuoteParam{
+		VirtualPool:      poolState,
+		Config:           poolConfigState,
+		SwapBaseForQuote: param.SwapBaseForQuote,
+		AmountIn:         new(big.Int).SetUint64(param.AmountIn),
+		HasReferral:      !param.ReferralTokenAccount.IsZero(),
+		CurrentPoint:     currentPoint,
+		TransferFeeParam: transferFeeParam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("swap: cannot quote: %w", err)
+	}
+	if err := helpers.ValidateMinimumAmountOut(quote.OutputAmount, param.MinimumAmountOut); err != nil {
+		return nil, fmt.Errorf("swap: %w", err)
+	}
+	if err := p.validateSwapBalance(
+		ctx, param.Owner, prepareSwapParams.InputMint, prepareSwapParams.InputTokenProgram, param.AmountIn,
+	); err != nil {
+		return nil, fmt.Errorf("swap: %w", err)
+	}
+
+	return p.swapIxns(ctx, param, poolState, poolConfigState, currentPoint)
+}
+
+// swapIxns builds the swap instructions, with ATA creation and SOL wrapping / unwrapping,
+// without the pre-flight checks of Swap.
+func (p *PoolService) swapIxns(
+	ctx context.Context,
+	param types.SwapParam,
+	poolState *dbc.VirtualPoolAccount,
+	poolConfigState *dbc.PoolConfigAccount,
+	currentPoint *big.Int,
+) ([]solana.Instruction, error) {
 	// check if rate limiter is applied if:
 	// 1. rate limiter mode
 	// 2. swap direction is QuoteToBase
@@ -948,8 +1065,6 @@ func (p *PoolService) Swap2(
 		}
 	}
 
-	// TODO: validation checks
-
 	poolState, err := p.state.GetPool(ctx, param.Pool)
 	if err != nil {
 		return nil, fmt.Errorf("swap2:pool (%s) not found: error: %w", param.Pool.String(), err)
@@ -998,6 +1113,11 @@ func (p *PoolService) Swap2(
 		},
 	)
 
+	// pre-flight checks
+	if err := p.validateSwap2(ctx, param, poolState, poolConfigState, currentPoint, prepareSwapParams); err != nil {
+		return nil, fmt.Errorf("swap2: %w", err)
+	}
+
 	// add preInstructions for ATA creation and SOL wrapping
 	payer := param.Owner
 	if !param.Payer.IsZero() {
@@ -1114,11 +1234,125 @@ func (p *PoolService) Swap2(
 	return finalIxns, nil
 }
 
+// validateSwap2 checks the pool state, amounts, slippage bounds and owner balance for Swap2.
+func (p *PoolService) validateSwap2(
+	ctx context.Context,
+	param types.Swap2Param,
+	poolState *dbc.VirtualPoolAccount,
+	poolConfigState *dbc.PoolConfigAccount,
+	currentPoint *big.Int,
+	prepareSwapParams types.PrepareSwapParams,
+) error {
+	if err := helpers.ValidateSwapPool(poolState, poolConfigState, currentPoint); err != nil {
+		return err
+	}
+
+	quoteParam := types.SwapQuote2Param{
+		VirtualPool:      poolState,
+		Config:           poolConfigState,
+		SwapBaseForQuote: param.SwapBaseForQuote,
+		HasReferral:      !param.ReferralTokenAccount.IsZero(),
+		CurrentPoint:     currentPoint,
+		SwapMode:         param.SwapMode,
+		AmountIn:         param.AmountIn,
+		AmountOut:        param.AmountOut,
+	}
+
+	// amount the owner must hold of the input mint
+	requiredAmount := param.AmountIn
+	if param.SwapMode == types.SwapModeExactOut {
+		if param.AmountOut == nil || param.AmountOut.Sign() <= 0 {
+			return types.ErrZeroAmount
+		}
+		if param.MaximumAmountIn == nil || !param.MaximumAmountIn.IsUint64() {
+			return fmt.Errorf("cannot fit MaximumAmountIn(%s) into uint64", param.MaximumAmountIn)
+		}
+		requiredAmount = param.MaximumAmountIn
+	} else {
+		if param.AmountIn == nil || param.AmountIn.Sign() <= 0 {
+			return types.ErrZeroAmount
+		}
+		if !param.AmountIn.IsUint64() {
+			return fmt.Errorf("cannot fit AmountIn(%s) into uint64", param.AmountIn)
+		}
+		if param.MinimumAmountOut == nil || !param.MinimumAmountOut.IsUint64() {
+			return fmt.Errorf("cannot fit MinimumAmountOut(%s) into uint64", param.MinimumAmountOut)
+		}
+	}
+
+	transferFeeParam, err := p.state.GetTransferFeeParam(ctx, poolState, poolConfigState)
+	if err != nil {
+		return err
+	}
+	quoteParam.TransferFeeParam = transferFeeParam
+
+	quote, err := p.SwapQuote2(quoteParam)
+	if err != nil {
+		return fmt.Errorf("cannot quote: %w", err)
+	}
+
+	switch param.SwapMode {
+	case types.SwapModeExactOut:
+		err = helpers.ValidateMaximumAmountIn(
+			quote.IncludedFeeInputAmount+quote.InputTransferFee, param.MaximumAmountIn.Uint64(),
+		)
+	default:
+		err = helpers.ValidateMinimumAmountOut(quote.OutputAmount, param.MinimumAmountOut.Uint64())
+	}
+	if err != nil {
+		return err
+	}
+
+	return p.validateSwapBalance(
+		ctx, param.Owner, prepareSwapParams.InputMint, prepareSwapParams.InputTokenProgram, requiredAmount.Uint64(),
+	)
+}
+
+// validateSwapBalance checks owner holds amount of the input mint.
+// Wrapped SOL is checked against the owner's lamports plus the wrapped SOL already in the owner's token account.
+func (p *PoolService) validateSwapBalance(
+	ctx context.Context,
+	owner, inputMint, inputTokenProgram solana.PublicKey,
+	amount uint64,
+) error {
+	var available uint64
+
+	ata, err := helpers.GetAssociatedTokenAddressSync(inputMint, owner, true, inputTokenProgram, solana.PublicKey{})
+	if err != nil {
+		return err
+	}
+	account, err := helpers.GetAccount(ctx, p.state.conn, ata, p.state.commitment, solana.PublicKey{})
+	switch {
+	case err == nil:
+		available = account.Amount
+	case !errors.Is(err, rpc.ErrNotFound):
+		return fmt.Errorf("cannot get owner(%s) token account(%s): %w", owner, ata, err)
+	}
+
+	if inputMint.Equals(solana.WrappedSol) {
+		balance, err := p.state.conn.GetBalance(ctx, owner, p.state.commitment)
+		if err != nil {
+			return fmt.Errorf("cannot get owner(%s) SOL balance: %w", owner, err)
+		}
+		available += balance.Value
+	}
+
+	if available < amount {
+		return &types.InsufficientBalanceError{
+			Mint:      inputMint,
+			Required:  amount,
+			Available: available,
+		}
+	}
+	return nil
+}
+
 // SwapQuote calculates the amount out for a swap (quote) for swap1.
+// Token-2022 transfer fees are deducted when param.TransferFeeParam is set.
 func (p *PoolService) SwapQuote(
 	param types.SwapQuoteParam,
 ) (types.SwapQuoteResult, error) {
-	return maths.SwapQuote(
+	return maths.SwapQuoteWithTransferFee(
 		param.VirtualPool,
 		param.Config,
 		param.SwapBaseForQuote,
@@ -1126,10 +1360,12 @@ func (p *PoolService) SwapQuote(
 		param.SlippageBps,
 		param.HasReferral,
 		param.CurrentPoint,
+		param.TransferFeeParam,
 	)
 }
 
 // SwapQuote2 calculates the amount out for a swap (quote) based on swap mode (for swap2).
+// Token-2022 transfer fees are deducted when param.TransferFeeParam is set.
 func (p *PoolService) SwapQuote2(
 	param types.SwapQuote2Param,
 ) (types.SwapQuote2Result, error) {
@@ -1139,7 +1375,7 @@ func (p *PoolService) SwapQuote2(
 		if param.AmountIn == nil {
 			return types.SwapQuote2Result{}, errors.New("SwapQuote2:amountIn cannot be nil for SwapModeExactIn")
 		}
-		return maths.SwapQuoteExactIn(
+		return maths.SwapQuoteExactInWithTransferFee(
 			param.VirtualPool,
 			param.Config,
 			param.SwapBaseForQuote,
@@ -1147,13 +1383,14 @@ func (p *PoolService) SwapQuote2(
 			param.SlippageBps,
 			param.HasReferral,
 			param.CurrentPoint,
+			param.TransferFeeParam,
 		)
 
 	case types.SwapModePartialFill:
 		if param.AmountIn == nil {
 			return types.SwapQuote2Result{}, errors.New("SwapQuote2:amountIn cannot be nil for SwapModePartialFill")
 		}
-		return maths.SwapQuotePartialFill(
+		return maths.SwapQuotePartialFillWithTransferFee(
 			param.VirtualPool,
 			param.Config,
 			param.SwapBaseForQuote,
@@ -1161,13 +1398,14 @@ func (p *PoolService) SwapQuote2(
 			param.SlippageBps,
 			param.HasReferral,
 			param.CurrentPoint,
+			param.TransferFeeParam,
 		)
 
 	case types.SwapModeExactOut:
 		if param.AmountOut == nil {
-			return types.SwapQuote2Result{}, errors.New("SwapQuote2:actualReferralFeemountOut cannot be nil for SwapModeExactOut")
+			return types.SwapQuote2Result{}, errors.New("SwapQuote2:amountOut cannot be nil for SwapModeExactOut")
 		}
-		return maths.SwapQuoteExactOut(
+		return maths.SwapQuoteExactOutWithTransferFee(
 			param.VirtualPool,
 			param.Config,
 			param.SwapBaseForQuote,
@@ -1175,6 +1413,7 @@ func (p *PoolService) SwapQuote2(
 			param.SlippageBps,
 			param.HasReferral,
 			param.CurrentPoint,
+			param.TransferFeeParam,
 		)
 	}
 
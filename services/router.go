@@ -0,0 +1,449 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/constants"
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/generated/dammv2"
+	"dbcGoSDK/generated/dbc"
+	"dbcGoSDK/helpers"
+	"dbcGoSDK/maths"
+	mathsDammv1 "dbcGoSDK/maths/dammv1"
+	mathsDammv2 "dbcGoSDK/maths/dammv2"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+type RouterService struct {
+	state *StateService
+	pool  *PoolService
+}
+
+func NewRouterService(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *RouterService {
+	return &RouterService{
+		state: NewStateService(conn, commitment),
+		pool:  NewPoolService(conn, commitment),
+	}
+}
+
+// Quote quotes an exact input swap of a base mint wherever it trades now:
+// the DBC curve before migration, or the DAMM V1 / DAMM V2 pool it migrated to.
+// The result includes the instructions to execute the swap when param.Owner is set, they are built
+// without checking the owner holds amountIn. When only building them fails, the error comes with
+// the quote.
+func (r *RouterService) Quote(
+	ctx context.Context,
+	baseMint solana.PublicKey,
+	side types.SwapSide,
+	amountIn *big.Int,
+	param types.RouterQuoteParam,
+) (types.RouterQuoteResult, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return types.RouterQuoteResult{}, errors.New("amountIn must be greater than zero")
+	}
+	if !amountIn.IsUint64() {
+		return types.RouterQuoteResult{}, fmt.Errorf("cannot fit amountIn(%s) into uint64", amountIn)
+	}
+	if param.SlippageBps > 10_000 {
+		return types.RouterQuoteResult{}, fmt.Errorf("slippageBps(%d) cannot exceed 10000", param.SlippageBps)
+	}
+	if param.Payer.IsZero() {
+		param.Payer = param.Owner
+	}
+
+	virtualPool, err := r.state.GetPoolByBaseMint(ctx, baseMint)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("pool of base mint(%s) not found: %w", baseMint, err)
+	}
+
+	config, err := r.state.GetPoolConfig(ctx, virtualPool.Account.Config)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("pool config(%s) not found: %w", virtualPool.Account.Config, err)
+	}
+
+	venue, err := helpers.GetRouterVenue(virtualPool.Account, config)
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	switch venue {
+	case types.RouterVenueDammV1:
+		return r.quoteDammV1(ctx, virtualPool.PublicKey, virtualPool.Account, config, side, amountIn, param)
+	case types.RouterVenueDammV2:
+		return r.quoteDammV2(ctx, virtualPool.PublicKey, virtualPool.Account, config, side, amountIn, param)
+	}
+	return r.quoteDbc(ctx, virtualPool.PublicKey, virtualPool.Account, config, side, amountIn, param)
+}
+
+// quoteDbc quotes a swap on the DBC curve.
+func (r *RouterService) quoteDbc(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	side types.SwapSide,
+	amountIn *big.Int,
+	param types.RouterQuoteParam,
+) (types.RouterQuoteResult, error) {
+	currentPoint, err := helpers.GetCurrentPoint(r.state.conn, types.ActivationType(config.ActivationType))
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	transferFeeParam, err := r.state.GetTransferFeeParam(ctx, virtualPool, config)
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	swapBaseForQuote := side == types.SwapSideSell
+	quote, err := maths.SwapQuoteWithTransferFee(
+		virtualPool,
+		config,
+		swapBaseForQuote,
+		amountIn,
+		param.SlippageBps,
+		!param.ReferralTokenAccount.IsZero(),
+		currentPoint,
+		transferFeeParam,
+	)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("cannot quote dbc pool(%s): %w", poolAddress, err)
+	}
+
+	inputMint, outputMint := helpers.GetRouterSwapMints(virtualPool.BaseMint, config.QuoteMint, side)
+
+	result := types.RouterQuoteResult{
+		Venue:            types.RouterVenueDBC,
+		Pool:             poolAddress,
+		InputMint:        inputMint,
+		OutputMint:       outputMint,
+		AmountIn:         amountIn.Uint64(),
+		AmountOut:        quote.NetOutputAmount,
+		MinimumAmountOut: quote.MinimumAmountOut,
+	}
+	if param.Owner.IsZero() {
+		return result, nil
+	}
+
+	if result.Ixns, err = r.pool.swapIxns(ctx, types.SwapParam{
+		Owner:                param.Owner,
+		Pool:                 poolAddress,
+		AmountIn:             amountIn.Uint64(),
+		MinimumAmountOut:     quote.MinimumAmountOut,
+		SwapBaseForQuote:     swapBaseForQuote,
+		ReferralTokenAccount: param.ReferralTokenAccount,
+		Payer:                param.Payer,
+	}, virtualPool, config, currentPoint); err != nil {
+		return result, fmt.Errorf("cannot build dbc swap instructions: %w", err)
+	}
+	return result, nil
+}
+
+// quoteDammV1 quotes a swap on the DAMM V1 pool a virtual pool migrated to.
+func (r *RouterService) quoteDammV1(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	side types.SwapSide,
+	amountIn *big.Int,
+	param types.RouterQuoteParam,
+) (types.RouterQuoteResult, error) {
+	migrationMetadata, err := r.state.GetDammV1MigrationMetadata(ctx, poolAddress)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("damm v1 migration metadata of pool(%s) not found: %w", poolAddress, err)
+	}
+
+	// the metadata records the LP mint of the pool the virtual pool migrated to
+	dammPool, err := r.state.GetDammV1PoolByLpMint(ctx, migrationMetadata.LpMint)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("damm v1 pool of pool(%s) not found: %w", poolAddress, err)
+	}
+
+	dammPoolState, err := r.state.GetDammV1PoolState(ctx, dammPool)
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	currentTime, err := helpers.GetCurrentPoint(r.state.conn, types.ActivationTypeTimestamp)
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	inputMint, outputMint := helpers.GetRouterSwapMints(virtualPool.BaseMint, config.QuoteMint, side)
+
+	quote, err := mathsDammv1.SwapQuote(types.DammV1SwapQuoteParam{
+		DammV1PoolState: dammPoolState,
+		InTokenMint:     inputMint,
+		InAmount:        amountIn,
+		SlippageBps:     param.SlippageBps,
+		CurrentTime:     currentTime.Uint64(),
+	})
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("cannot quote damm v1 pool(%s): %w", dammPool, err)
+	}
+
+	pool := dammPoolState.Pool
+	protocolTokenFee := helpers.GetDammV1ProtocolTokenFee(pool, inputMint)
+
+	result := types.RouterQuoteResult{
+		Venue:            types.RouterVenueDammV1,
+		Pool:             dammPool,
+		InputMint:        inputMint,
+		OutputMint:       outputMint,
+		AmountIn:         amountIn.Uint64(),
+		AmountOut:        quote.SwapOutAmount.Uint64(),
+		MinimumAmountOut: quote.MinSwapOutAmount.Uint64(),
+	}
+	if param.Owner.IsZero() {
+		return result, nil
+	}
+
+	if result.Ixns, err = r.swapIxns(
+		ctx,
+		param,
+		inputMint, outputMint,
+		solana.TokenProgramID, solana.TokenProgramID,
+		amountIn.Uint64(),
+		func(inputTokenAccount, outputTokenAccount solana.PublicKey) (solana.Instruction, error) {
+			return dammv1.NewSwapInstruction(
+				amountIn.Uint64(),
+				quote.MinSwapOutAmount.Uint64(),
+				dammPool,
+				inputTokenAccount,
+				outputTokenAccount,
+				pool.AVault,
+				pool.BVault,
+				dammPoolState.VaultA.TokenVault,
+				dammPoolState.VaultB.TokenVault,
+				dammPoolState.VaultA.LpMint,
+				dammPoolState.VaultB.LpMint,
+				pool.AVaultLp,
+				pool.BVaultLp,
+				protocolTokenFee,
+				param.Owner,
+				constants.VaultProgramId,
+				solana.TokenProgramID,
+			).ValidateAndBuild()
+		},
+	); err != nil {
+		return result, fmt.Errorf("cannot build damm v1 swap instructions: %w", err)
+	}
+	return result, nil
+}
+
+// quoteDammV2 quotes a swap on the DAMM V2 pool a virtual pool migrated to.
+func (r *RouterService) quoteDammV2(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+	virtualPool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+	side types.SwapSide,
+	amountIn *big.Int,
+	param types.RouterQuoteParam,
+) (types.RouterQuoteResult, error) {
+	if _, err := r.state.GetDammV2MigrationMetadata(ctx, poolAddress); err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("damm v2 migration metadata of pool(%s) not found: %w", poolAddress, err)
+	}
+
+	// the DAMM V2 metadata does not record the pool, which the program creates with the config of the
+	// migration fee option
+	if int(config.MigrationFeeOption) >= len(constants.DammV2MigrationFeeAddresses) {
+		return types.RouterQuoteResult{}, fmt.Errorf("invalid migrationFeeOption(%d) for damm v2", config.MigrationFeeOption)
+	}
+	dammPool := helpers.DeriveDammV2PoolAddress(
+		constants.DammV2MigrationFeeAddresses[config.MigrationFeeOption],
+		virtualPool.BaseMint,
+		config.QuoteMint,
+	)
+
+	pool, err := r.state.GetDammV2Pool(ctx, dammPool)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("damm v2 pool(%s) not found: %w", dammPool, err)
+	}
+
+	currentPoint, err := helpers.GetCurrentPoint(r.state.conn, types.ActivationType(pool.ActivationType))
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	swapAToB := helpers.IsDammV2SwapAToB(pool.TokenAMint, virtualPool.BaseMint, side)
+
+	tokenAProgram, tokenBProgram := helpers.GetTokenProgram(pool.TokenAFlag), helpers.GetTokenProgram(pool.TokenBFlag)
+	inputMint, outputMint, inputProgram, outputProgram :=
+		pool.TokenBMint, pool.TokenAMint, tokenBProgram, tokenAProgram
+	if swapAToB {
+		inputMint, outputMint, inputProgram, outputProgram =
+			pool.TokenAMint, pool.TokenBMint, tokenAProgram, tokenBProgram
+	}
+
+	inputTransferFee, outputTransferFee, err := r.getTransferFees(ctx, inputMint, outputMint, inputProgram, outputProgram)
+	if err != nil {
+		return types.RouterQuoteResult{}, err
+	}
+
+	quote, err := mathsDammv2.SwapQuoteExactInWithTransferFee(
+		pool,
+		swapAToB,
+		amountIn,
+		param.SlippageBps,
+		!param.ReferralTokenAccount.IsZero(),
+		currentPoint,
+		inputTransferFee,
+		outputTransferFee,
+	)
+	if err != nil {
+		return types.RouterQuoteResult{}, fmt.Errorf("cannot quote damm v2 pool(%s): %w", dammPool, err)
+	}
+
+	referralTokenAccount := constants.DammV2ProgramId
+	if !param.ReferralTokenAccount.IsZero() {
+		referralTokenAccount = param.ReferralTokenAccount
+	}
+
+	result := types.RouterQuoteResult{
+		Venue:            types.RouterVenueDammV2,
+		Pool:             dammPool,
+		InputMint:        inputMint,
+		OutputMint:       outputMint,
+		AmountIn:         amountIn.Uint64(),
+		AmountOut:        quote.NetOutputAmount,
+		MinimumAmountOut: quote.MinimumAmountOut,
+	}
+	if param.Owner.IsZero() {
+		return result, nil
+	}
+
+	if result.Ixns, err = r.swapIxns(
+		ctx,
+		param,
+		inputMint, outputMint,
+		inputProgram, outputProgram,
+		amountIn.Uint64(),
+		func(inputTokenAccount, outputTokenAccount solana.PublicKey) (solana.Instruction, error) {
+			return dammv2.NewSwapInstruction(
+				dammv2.SwapParameters{
+					AmountIn:         amountIn.Uint64(),
+					MinimumAmountOut: quote.MinimumAmountOut,
+				},
+				helpers.DeriveDammV2PoolAuthority(),
+				dammPool,
+				inputTokenAccount,
+				outputTokenAccount,
+				pool.TokenAVault,
+				pool.TokenBVault,
+				pool.TokenAMint,
+				pool.TokenBMint,
+				param.Owner,
+				tokenAProgram,
+				tokenBProgram,
+				referralTokenAccount,
+				helpers.DeriveDammV2EventAuthority(),
+				constants.DammV2ProgramId,
+			).ValidateAndBuild()
+		},
+	); err != nil {
+		return result, fmt.Errorf("cannot build damm v2 swap instructions: %w", err)
+	}
+	return result, nil
+}
+
+// getTransferFees gets the Token-2022 transfer fees of the input and output mints in the current epoch.
+func (r *RouterService) getTransferFees(
+	ctx context.Context,
+	inputMint, outputMint, inputTokenProgram, outputTokenProgram solana.PublicKey,
+) (input, output types.TransferFee, err error) {
+	if !inputTokenProgram.Equals(solana.Token2022ProgramID) && !outputTokenProgram.Equals(solana.Token2022ProgramID) {
+		return types.TransferFee{}, types.TransferFee{}, nil
+	}
+
+	epochInfo, err := r.state.conn.GetEpochInfo(ctx, r.state.commitment)
+	if err != nil {
+		return types.TransferFee{}, types.TransferFee{}, fmt.Errorf("cannot get epoch info: %w", err)
+	}
+
+	if inputTokenProgram.Equals(solana.Token2022ProgramID) {
+		transferFeeConfig, err := helpers.GetTransferFeeConfig(ctx, r.state.conn, inputMint)
+		if err != nil {
+			return types.TransferFee{}, types.TransferFee{}, err
+		}
+		input = maths.GetEpochTransferFee(transferFeeConfig, epochInfo.Epoch)
+	}
+
+	if outputTokenProgram.Equals(solana.Token2022ProgramID) {
+		transferFeeConfig, err := helpers.GetTransferFeeConfig(ctx, r.state.conn, outputMint)
+		if err != nil {
+			return types.TransferFee{}, types.TransferFee{}, err
+		}
+		output = maths.GetEpochTransferFee(transferFeeConfig, epochInfo.Epoch)
+	}
+
+	return input, output, nil
+}
+
+// swapIxns wraps a DAMM swap instruction with ATA creation and SOL wrapping / unwrapping.
+func (r *RouterService) swapIxns(
+	ctx context.Context,
+	param types.RouterQuoteParam,
+	inputMint, outputMint, inputTokenProgram, outputTokenProgram solana.PublicKey,
+	amountIn uint64,
+	swapIx func(inputTokenAccount, outputTokenAccount solana.PublicKey) (solana.Instruction, error),
+) ([]solana.Instruction, error) {
+	prepareTokenAccounts, err := r.state.prepareTokenAccounts(
+		ctx,
+		types.PrepareTokenAccountParams{
+			Owner:         param.Owner,
+			Payer:         param.Payer,
+			TokenAMint:    inputMint,
+			TokenBMint:    outputMint,
+			TokenAProgram: inputTokenProgram,
+			TokenBProgram: outputTokenProgram,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(prepareTokenAccounts.CreateATAIxns)+4)
+	ixns = append(ixns, prepareTokenAccounts.CreateATAIxns...)
+
+	// add SOL wrapping instructions if needed
+	if inputMint.Equals(solana.WrappedSol) {
+		ixns = append(ixns,
+			helpers.WrapSOLInstruction(
+				param.Owner,
+				prepareTokenAccounts.TokenAAta,
+				amountIn,
+			)...,
+		)
+	}
+
+	currentIx, err := swapIx(prepareTokenAccounts.TokenAAta, prepareTokenAccounts.TokenBAta)
+	if err != nil {
+		return nil, err
+	}
+	ixns = append(ixns, currentIx)
+
+	// add SOL unwrapping instruction if needed
+	if inputMint.Equals(solana.WrappedSol) || outputMint.Equals(solana.WrappedSol) {
+		ix, err := helpers.UnwrapSOLInstruction(
+			param.Owner,
+			param.Owner,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ixns = append(ixns, ix)
+	}
+
+	return ixns, nil
+}
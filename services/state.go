@@ -3,13 +3,17 @@ package services
 import (
 	"context"
 	"dbcGoSDK/anchor"
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/generated/dammv2"
 	"dbcGoSDK/generated/dbc"
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
 	"dbcGoSDK/helpers"
 	"dbcGoSDK/types"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
@@ -140,10 +144,10 @@ func (s *StateService) GetPoolsByCreator(
 func (s *StateService) GetPoolByBaseMint(
 	ctx context.Context,
 	baseMint solana.PublicKey,
-) (anchor.ProgramAccount[*dbc.PoolConfigAccount], error) {
+) (anchor.ProgramAccount[*dbc.VirtualPoolAccount], error) {
 	pools, err := anchor.NewPgAccounts(
 		s.conn,
-		func() *dbc.PoolConfigAccount { return &dbc.PoolConfigAccount{} },
+		func() *dbc.VirtualPoolAccount { return &dbc.VirtualPoolAccount{} },
 	).All(
 		ctx,
 		s.GetProgramID(),
@@ -152,10 +156,10 @@ func (s *StateService) GetPoolByBaseMint(
 		nil,
 	)
 	if err != nil {
-		return anchor.ProgramAccount[*dbc.PoolConfigAccount]{}, err
+		return anchor.ProgramAccount[*dbc.VirtualPoolAccount]{}, err
 	}
 	if len(pools) == 0 {
-		return anchor.ProgramAccount[*dbc.PoolConfigAccount]{}, errors.New("len of pool as zero")
+		return anchor.ProgramAccount[*dbc.VirtualPoolAccount]{}, errors.New("len of pool as zero")
 	}
 
 	return pools[0], nil
@@ -207,6 +211,75 @@ func (s *StateService) GetPoolCurveProgress(
 	return math.Min(math.Max(f64, 0), 1), nil
 }
 
+// GetPoolPriceMetrics get the current price, market cap, fully diluted value and migration market cap of a pool.
+// Values are in quote token units, or in the reference currency of priceSource when it is not nil.
+func (s *StateService) GetPoolPriceMetrics(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+	priceSource types.PriceSource,
+) (types.PoolPriceMetrics, error) {
+	pool, err := s.GetPool(ctx, poolAddress)
+	if err != nil {
+		return types.PoolPriceMetrics{}, fmt.Errorf("pool not found: error: %w", err)
+	}
+
+	config, err := s.GetPoolConfig(ctx, pool.Config)
+	if err != nil {
+		return types.PoolPriceMetrics{}, err
+	}
+
+	quoteDecimal, err := helpers.GetTokenDecimals(s.conn, config.QuoteMint)
+	if err != nil {
+		return types.PoolPriceMetrics{}, fmt.Errorf("cannot get quote mint decimals: %w", err)
+	}
+
+	metrics, err := helpers.GetPoolPriceMetrics(
+		pool,
+		config,
+		types.TokenDecimal(config.TokenDecimal),
+		types.TokenDecimal(quoteDecimal),
+	)
+	if err != nil || priceSource == nil {
+		return metrics, err
+	}
+
+	quoteTokenPrice, err := priceSource.GetPrice(ctx, config.QuoteMint)
+	if err != nil {
+		return types.PoolPriceMetrics{}, fmt.Errorf("cannot get quote mint price: %w", err)
+	}
+
+	return helpers.ConvertPoolPriceMetrics(metrics, quoteTokenPrice), nil
+}
+
+// GetTransferFeeParam get the Token-2022 transfer fee configs of the pool mints and the current epoch.
+// Set Epoch to the next epoch to quote against the newer transfer fee.
+func (s *StateService) GetTransferFeeParam(
+	ctx context.Context,
+	pool *dbc.VirtualPoolAccount,
+	config *dbc.PoolConfigAccount,
+) (types.TransferFeeParam, error) {
+	epochInfo, err := s.conn.GetEpochInfo(ctx, s.commitment)
+	if err != nil {
+		return types.TransferFeeParam{}, fmt.Errorf("cannot get epoch info: %w", err)
+	}
+
+	param := types.TransferFeeParam{Epoch: epochInfo.Epoch}
+
+	if config.TokenType == uint8(types.TokenTypeToken2022) {
+		if param.BaseTransferFeeConfig, err = helpers.GetTransferFeeConfig(ctx, s.conn, pool.BaseMint); err != nil {
+			return types.TransferFeeParam{}, err
+		}
+	}
+
+	if config.QuoteTokenFlag == uint8(types.TokenTypeToken2022) {
+		if param.QuoteTransferFeeConfig, err = helpers.GetTransferFeeConfig(ctx, s.conn, config.QuoteMint); err != nil {
+			return types.TransferFeeParam{}, err
+		}
+	}
+
+	return param, nil
+}
+
 // GetPoolMetadata get pool metadata.
 func (s *StateService) GetPoolMetadata(
 	ctx context.Context,
@@ -361,3 +434,192 @@ func (s *StateService) GetDammV1MigrationMetadata(
 	).Fetch(ctx, migrationMetadataAddress, &rpc.GetAccountInfoOpts{})
 
 }
+
+// GetDammV1PoolByLpMint gets the address of the DAMM V1 pool minting lpMint, as recorded by a migration metadata.
+func (s *StateService) GetDammV1PoolByLpMint(
+	ctx context.Context,
+	lpMint solana.PublicKey,
+) (solana.PublicKey, error) {
+	// the LP mint is the first field of a pool
+	pools, err := anchor.NewPgAccounts(
+		s.conn,
+		func() *dammv1.PoolAccount { return &dammv1.PoolAccount{} },
+	).All(
+		ctx,
+		dammv1.ProgramID,
+		dammv1.PoolAccountDiscriminator,
+		rpc.GetProgramAccountsOpts{Commitment: s.commitment},
+		lpMint.Bytes(),
+	)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	if len(pools) != 1 {
+		return solana.PublicKey{}, fmt.Errorf("found %d damm v1 pools of lp mint(%s)", len(pools), lpMint)
+	}
+	return pools[0].PublicKey, nil
+}
+
+// GetDammV1PoolState gets a DAMM V1 pool with its vaults, vault LP holdings and vault LP supplies.
+func (s *StateService) GetDammV1PoolState(
+	ctx context.Context,
+	dammPoolAddress solana.PublicKey,
+) (types.DammV1PoolState, error) {
+	pool, err := anchor.NewPgAccounts(
+		s.conn,
+		func() *dammv1.PoolAccount { return &dammv1.PoolAccount{} },
+	).Fetch(ctx, dammPoolAddress, &rpc.GetAccountInfoOpts{Commitment: s.commitment})
+	if err != nil {
+		return types.DammV1PoolState{}, fmt.Errorf("damm v1 pool(%s) not found: %w", dammPoolAddress, err)
+	}
+
+	vaults, err := anchor.NewPgAccounts(
+		s.conn,
+		func() *dynamic_vault.VaultAccount { return &dynamic_vault.VaultAccount{} },
+	).FetchMultiple(ctx, []solana.PublicKey{pool.AVault, pool.BVault}, &rpc.GetMultipleAccountsOpts{Commitment: s.commitment})
+	if err != nil {
+		return types.DammV1PoolState{}, fmt.Errorf("cannot fetch pool vaults: %w", err)
+	}
+	if len(vaults) != 2 || vaults[0] == nil || vaults[1] == nil {
+		return types.DammV1PoolState{}, errors.New("pool vaults not found")
+	}
+
+	state := types.DammV1PoolState{
+		Pool:   pool,
+		VaultA: vaults[0],
+		VaultB: vaults[1],
+	}
+
+	for _, v := range []struct {
+		tokenAccount, lpMint solana.PublicKey
+		balance, supply      **big.Int
+	}{
+		{pool.AVaultLp, vaults[0].LpMint, &state.PoolVaultALp, &state.VaultALpSupply},
+		{pool.BVaultLp, vaults[1].LpMint, &state.PoolVaultBLp, &state.VaultBLpSupply},
+	} {
+		lpAccount, err := helpers.GetAccount(ctx, s.conn, v.tokenAccount, s.commitment, solana.TokenProgramID)
+		if err != nil {
+			return types.DammV1PoolState{}, fmt.Errorf("cannot fetch vault lp account(%s): %w", v.tokenAccount, err)
+		}
+		*v.balance = new(big.Int).SetUint64(lpAccount.Amount)
+
+		supply, err := s.conn.GetTokenSupply(ctx, v.lpMint, s.commitment)
+		if err != nil {
+			return types.DammV1PoolState{}, fmt.Errorf("cannot fetch vault lp supply(%s): %w", v.lpMint, err)
+		}
+		lpSupply, ok := new(big.Int).SetString(supply.Value.Amount, 10)
+		if !ok {
+			return types.DammV1PoolState{}, fmt.Errorf("invalid vault lp supply(%s)", supply.Value.Amount)
+		}
+		*v.supply = lpSupply
+	}
+
+	return state, nil
+}
+
+// GetDammV2MigrationMetadata gets DAMM V2 migration metadata.
+func (s *StateService) GetDammV2MigrationMetadata(
+	ctx context.Context,
+	poolAdress solana.PublicKey,
+) (*dbc.MeteoraDammV2MetadataAccount, error) {
+	migrationMetadataAddress := helpers.DeriveDammV2MigrationMetadataAddress(poolAdress)
+
+	return anchor.NewPgAccounts(
+		s.conn, func() *dbc.MeteoraDammV2MetadataAccount { return &dbc.MeteoraDammV2MetadataAccount{} },
+	).Fetch(ctx, migrationMetadataAddress, &rpc.GetAccountInfoOpts{Commitment: s.commitment})
+}
+
+// GetDammV2Pool gets DAMM V2 pool data.
+func (s *StateService) GetDammV2Pool(
+	ctx context.Context,
+	dammPoolAddress solana.PublicKey,
+) (*dammv2.PoolAccount, error) {
+	return anchor.NewPgAccounts(
+		s.conn,
+		func() *dammv2.PoolAccount { return &dammv2.PoolAccount{} },
+	).Fetch(ctx, dammPoolAddress, &rpc.GetAccountInfoOpts{Commitment: s.commitment})
+}
+
+// GetPoolSurplus get the partner, creator and protocol surplus and the base token leftover of a pool.
+func (s *StateService) GetPoolSurplus(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+) (types.PoolSurplusResult, error) {
+	pool, err := s.GetPool(ctx, poolAddress)
+	if err != nil {
+		return types.PoolSurplusResult{}, fmt.Errorf("pool not found: error: %w", err)
+	}
+
+	config, err := s.GetPoolConfig(ctx, pool.Config)
+	if err != nil {
+		return types.PoolSurplusResult{}, err
+	}
+
+	var baseVaultAmount uint64
+	if config.FixedTokenSupplyFlag == 1 && pool.IsMigrated == 1 {
+		balance, err := s.conn.GetTokenAccountBalance(ctx, pool.BaseVault, s.commitment)
+		if err != nil {
+			return types.PoolSurplusResult{}, fmt.Errorf("cannot get base vault(%s) balance: %w", pool.BaseVault, err)
+		}
+		if baseVaultAmount, err = strconv.ParseUint(balance.Value.Amount, 10, 64); err != nil {
+			return types.PoolSurplusResult{}, fmt.Errorf("invalid base vault balance(%s): %w", balance.Value.Amount, err)
+		}
+	}
+
+	return helpers.GetPoolSurplus(pool, config, baseVaultAmount)
+}
+
+// DescribeConfig get a pool config in human units, see helpers.DescribeConfig.
+func (s *StateService) DescribeConfig(
+	ctx context.Context,
+	configAddress solana.PublicKey,
+) (types.ConfigDescription, error) {
+	config, err := s.GetPoolConfig(ctx, configAddress)
+	if err != nil {
+		return types.ConfigDescription{}, fmt.Errorf("config (%s) not found: error: %w", configAddress, err)
+	}
+
+	quoteDecimal, err := helpers.GetTokenDecimals(s.conn, config.QuoteMint)
+	if err != nil {
+		return types.ConfigDescription{}, fmt.Errorf("cannot get quote mint(%s) decimals: %w", config.QuoteMint, err)
+	}
+
+	return helpers.DescribeConfig(config, types.TokenDecimal(quoteDecimal))
+}
+
+// GetTokenMetadata get the Metaplex or Token-2022 metadata of a mint, see helpers.GetTokenMetadata.
+func (s *StateService) GetTokenMetadata(
+	ctx context.Context,
+	mint solana.PublicKey,
+) (*types.TokenMetadata, error) {
+	return helpers.GetTokenMetadata(ctx, s.conn, mint, s.commitment)
+}
+
+// GetPoolTokenMetadata get the metadata of a pool base mint and check its update authority against
+// the tokenUpdateAuthority option of the pool config.
+func (s *StateService) GetPoolTokenMetadata(
+	ctx context.Context,
+	poolAddress solana.PublicKey,
+) (*types.TokenMetadata, error) {
+	pool, err := s.GetPool(ctx, poolAddress)
+	if err != nil {
+		return nil, fmt.Errorf("pool(%s) not found: error: %w", poolAddress, err)
+	}
+
+	config, err := s.GetPoolConfig(ctx, pool.Config)
+	if err != nil {
+		return nil, fmt.Errorf("pool config(%s) not found: error: %w", pool.Config, err)
+	}
+
+	metadata, err := s.GetTokenMetadata(ctx, pool.BaseMint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helpers.ValidateTokenUpdateAuthority(
+		metadata, config.TokenUpdateAuthority, pool.Creator, config.FeeClaimer,
+	); err != nil {
+		return nil, fmt.Errorf("base mint(%s): %w", pool.BaseMint, err)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"dbcGoSDK/anchor"
+	"dbcGoSDK/constants"
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
+	"dbcGoSDK/helpers"
+	mathsDynamicVault "dbcGoSDK/maths/dynamicVault"
+	"dbcGoSDK/types"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+type VaultService struct {
+	state *StateService
+}
+
+func NewVaultService(
+	conn *rpc.Client,
+	commitment rpc.CommitmentType,
+) *VaultService {
+	return &VaultService{
+		state: NewStateService(conn, commitment),
+	}
+}
+
+// GetVault gets the permissionless dynamic vault of a token mint.
+func (v *VaultService) GetVault(
+	ctx context.Context,
+	tokenMint solana.PublicKey,
+) (*dynamic_vault.VaultAccount, error) {
+	return anchor.NewPgAccounts(
+		v.state.conn,
+		func() *dynamic_vault.VaultAccount { return &dynamic_vault.VaultAccount{} },
+	).Fetch(
+		ctx,
+		helpers.DeriveVaultAddress(tokenMint, constants.BaseAddress),
+		&rpc.GetAccountInfoOpts{Commitment: v.state.commitment},
+	)
+}
+
+// GetDepositQuote quotes the vault LP minted for depositing tokenAmount of tokenMint.
+func (v *VaultService) GetDepositQuote(
+	ctx context.Context,
+	tokenMint solana.PublicKey,
+	tokenAmount uint64,
+	slippageBps uint64,
+) (types.VaultDepositQuote, error) {
+	vault, lpSupply, currentTime, err := v.getVaultState(ctx, tokenMint)
+	if err != nil {
+		return types.VaultDepositQuote{}, err
+	}
+
+	return mathsDynamicVault.GetDepositQuote(
+		vault, lpSupply, new(big.Int).SetUint64(tokenAmount), slippageBps, currentTime,
+	)
+}
+
+// GetWithdrawQuote quotes the tokenMint amount received for burning lpAmount vault LP.
+func (v *VaultService) GetWithdrawQuote(
+	ctx context.Context,
+	tokenMint solana.PublicKey,
+	lpAmount uint64,
+	slippageBps uint64,
+) (types.VaultWithdrawQuote, error) {
+	vault, lpSupply, currentTime, err := v.getVaultState(ctx, tokenMint)
+	if err != nil {
+		return types.VaultWithdrawQuote{}, err
+	}
+
+	return mathsDynamicVault.GetWithdrawQuote(
+		vault, lpSupply, new(big.Int).SetUint64(lpAmount), slippageBps, currentTime,
+	)
+}
+
+// Deposit deposits tokens into the vault of tokenMint, creating the user token and LP accounts when missing.
+// SOL is wrapped before the deposit.
+func (v *VaultService) Deposit(
+	ctx context.Context,
+	param types.VaultDepositParam,
+) ([]solana.Instruction, error) {
+
+	vault, lpSupply, currentTime, err := v.getVaultState(ctx, param.TokenMint)
+	if err != nil {
+		return nil, err
+	}
+	if vault.Enabled == 0 {
+		return nil, fmt.Errorf("vault of mint(%s) is disabled for deposits", param.TokenMint)
+	}
+
+	quote, err := mathsDynamicVault.GetDepositQuote(
+		vault, lpSupply, new(big.Int).SetUint64(param.Amount), param.SlippageBps, currentTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payer := param.User
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+
+	prepareTokenAccounts, err := v.state.prepareTokenAccounts(
+		ctx,
+		types.PrepareTokenAccountParams{
+			Owner:         param.User,
+			Payer:         payer,
+			TokenAMint:    param.TokenMint,
+			TokenBMint:    vault.LpMint,
+			TokenAProgram: solana.TokenProgramID,
+			TokenBProgram: solana.TokenProgramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	currentIx, err := dynamic_vault.NewDepositInstruction(
+		param.Amount,
+		quote.MinLpAmount.Uint64(),
+		helpers.DeriveVaultAddress(param.TokenMint, constants.BaseAddress),
+		vault.TokenVault,
+		vault.LpMint,
+		prepareTokenAccounts.TokenAAta,
+		prepareTokenAccounts.TokenBAta,
+		param.User,
+		solana.TokenProgramID,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, len(prepareTokenAccounts.CreateATAIxns)+3)
+	ixns = append(ixns, prepareTokenAccounts.CreateATAIxns...)
+	if param.TokenMint.Equals(solana.WrappedSol) {
+		ixns = append(ixns, helpers.WrapSOLInstruction(param.User, prepareTokenAccounts.TokenAAta, param.Amount)...)
+	}
+	return append(ixns, currentIx), nil
+}
+
+// Withdraw burns vault LP for tokens of tokenMint, creating the user token account when missing.
+// When the vault reserve cannot cover the withdrawal, withdraw2 pulls the rest from the strategy holding
+// the most liquidity. SOL is unwrapped after the withdrawal.
+func (v *VaultService) Withdraw(
+	ctx context.Context,
+	param types.VaultWithdrawParam,
+) ([]solana.Instruction, error) {
+
+	vault, lpSupply, currentTime, err := v.getVaultState(ctx, param.TokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := mathsDynamicVault.GetWithdrawQuote(
+		vault, lpSupply, new(big.Int).SetUint64(param.LpAmount), param.SlippageBps, currentTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// withdraw only pays out of the vault reserve, withdraw2 pulls the rest from a strategy
+	reserve, err := helpers.GetAccount(ctx, v.state.conn, vault.TokenVault, v.state.commitment, solana.TokenProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch vault reserve(%s): %w", vault.TokenVault, err)
+	}
+
+	var strategyAccounts []*solana.AccountMeta
+	if quote.TokenAmount.Cmp(new(big.Int).SetUint64(reserve.Amount)) > 0 {
+		if strategyAccounts, err = v.getWithdrawStrategyAccounts(
+			ctx, vault, new(big.Int).Sub(quote.TokenAmount, new(big.Int).SetUint64(reserve.Amount)),
+		); err != nil {
+			return nil, err
+		}
+		strategyAccounts = append(strategyAccounts, param.StrategyAccounts...)
+	}
+
+	payer := param.User
+	if !param.Payer.IsZero() {
+		payer = param.Payer
+	}
+
+	tokenAccount, createTokenAccountIx, err := helpers.GetOrCreateATAInstruction(
+		ctx,
+		v.state.conn,
+		param.TokenMint,
+		param.User,
+		payer,
+		true,
+		solana.TokenProgramID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lpAccount, _, err := solana.FindAssociatedTokenAddress(param.User, vault.LpMint)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentIx solana.Instruction
+	if len(strategyAccounts) == 0 {
+		currentIx, err = dynamic_vault.NewWithdrawInstruction(
+			param.LpAmount,
+			quote.MinTokenAmount.Uint64(),
+			helpers.DeriveVaultAddress(param.TokenMint, constants.BaseAddress),
+			vault.TokenVault,
+			vault.LpMint,
+			tokenAccount,
+			lpAccount,
+			param.User,
+			solana.TokenProgramID,
+		).ValidateAndBuild()
+	} else {
+		withdraw2Ptr := dynamic_vault.NewWithdraw2Instruction(
+			param.LpAmount,
+			quote.MinTokenAmount.Uint64(),
+			helpers.DeriveVaultAddress(param.TokenMint, constants.BaseAddress),
+			vault.TokenVault,
+			vault.LpMint,
+			tokenAccount,
+			lpAccount,
+			param.User,
+			solana.TokenProgramID,
+		)
+		withdraw2Ptr.AccountMetaSlice = append(withdraw2Ptr.AccountMetaSlice, strategyAccounts...)
+		currentIx, err = withdraw2Ptr.ValidateAndBuild()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ixns := make([]solana.Instruction, 0, 3)
+	if createTokenAccountIx != nil {
+		ixns = append(ixns, createTokenAccountIx)
+	}
+	ixns = append(ixns, currentIx)
+
+	if param.TokenMint.Equals(solana.WrappedSol) {
+		unwrapSolIx, err := helpers.UnwrapSOLInstruction(param.User, param.User, true)
+		if err != nil {
+			return nil, err
+		}
+		ixns = append(ixns, unwrapSolIx)
+	}
+
+	return ixns, nil
+}
+
+// getWithdrawStrategyAccounts picks the strategy holding the most liquidity, which must cover shortfall,
+// and gives the accounts withdraw2 needs to pull from it: strategy, reserve, strategy program,
+// collateral vault and fee vault.
+func (v *VaultService) getWithdrawStrategyAccounts(
+	ctx context.Context,
+	vault *dynamic_vault.VaultAccount,
+	shortfall *big.Int,
+) ([]*solana.AccountMeta, error) {
+
+	strategyAddresses := make([]solana.PublicKey, 0, len(vault.Strategies))
+	for _, strategy := range vault.Strategies {
+		if !strategy.IsZero() {
+			strategyAddresses = append(strategyAddresses, strategy)
+		}
+	}
+	if len(strategyAddresses) == 0 {
+		return nil, fmt.Errorf("vault reserve cannot cover withdraw, shortfall(%s), and vault has no strategy", shortfall)
+	}
+
+	strategies, err := anchor.NewPgAccounts(
+		v.state.conn,
+		func() *dynamic_vault.StrategyAccount { return &dynamic_vault.StrategyAccount{} },
+	).FetchMultiple(ctx, strategyAddresses, &rpc.GetMultipleAccountsOpts{Commitment: v.state.commitment})
+	if err != nil {
+		return nil, err
+	}
+
+	selected := -1
+	for i, strategy := range strategies {
+		if strategy == nil || strategy.IsDisable != 0 || strategy.StrategyType == dynamic_vault.StrategyTypeVault {
+			continue
+		}
+		if selected < 0 || strategy.CurrentLiquidity > strategies[selected].CurrentLiquidity {
+			selected = i
+		}
+	}
+	if selected < 0 || new(big.Int).SetUint64(strategies[selected].CurrentLiquidity).Cmp(shortfall) < 0 {
+		return nil, fmt.Errorf("vault reserve and strategies cannot cover withdraw, shortfall(%s)", shortfall)
+	}
+
+	strategy := strategies[selected]
+	strategyProgram, ok := constants.VaultStrategyProgramIds[strategy.StrategyType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported strategy type(%s) of strategy(%s)", strategy.StrategyType, strategyAddresses[selected])
+	}
+
+	return []*solana.AccountMeta{
+		solana.Meta(strategyAddresses[selected]).WRITE(),
+		solana.Meta(strategy.Reserve).WRITE(),
+		solana.Meta(strategyProgram),
+		solana.Meta(strategy.CollateralVault).WRITE(),
+		solana.Meta(vault.FeeVault).WRITE(),
+	}, nil
+}
+
+func (v *VaultService) getVaultState(
+	ctx context.Context,
+	tokenMint solana.PublicKey,
+) (*dynamic_vault.VaultAccount, *big.Int, uint64, error) {
+
+	vault, err := v.GetVault(ctx, tokenMint)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("vault of mint(%s) not found: error: %w", tokenMint.String(), err)
+	}
+
+	supply, err := v.state.conn.GetTokenSupply(ctx, vault.LpMint, v.state.commitment)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("cannot fetch vault lp supply(%s): %w", vault.LpMint, err)
+	}
+	lpSupply, ok := new(big.Int).SetString(supply.Value.Amount, 10)
+	if !ok {
+		return nil, nil, 0, errors.New("invalid vault lp supply")
+	}
+
+	currentTime, err := helpers.GetCurrentPoint(v.state.conn, types.ActivationTypeTimestamp)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return vault, lpSupply, currentTime.Uint64(), nil
+}
@@ -99,3 +99,58 @@ const (
 	// TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority means the partner can update both update_authority and mint_authority.
 	TokenUpdateAuthorityOptionPartnerUpdateAndMintAuthority
 )
+
+type SwapSide uint8
+
+const (
+	SwapSideBuy  SwapSide = iota // quote token in, base token out
+	SwapSideSell                 // base token in, quote token out
+)
+
+type RouterVenue uint8
+
+const (
+	RouterVenueDBC RouterVenue = iota
+	RouterVenueDammV1
+	RouterVenueDammV2
+)
+
+type LaunchConfigFormat uint8
+
+const (
+	LaunchConfigFormatJSON LaunchConfigFormat = iota
+	LaunchConfigFormatYAML
+)
+
+type MigrationProgress uint8
+
+const (
+	MigrationProgressPreBondingCurve  MigrationProgress = iota
+	MigrationProgressPostBondingCurve                   // curve complete, locker not created
+	MigrationProgressLockedVesting                      // ready to migrate
+	MigrationProgressCreatedPool                        // migrated
+)
+
+type MigrationStep uint8
+
+const (
+	MigrationStepCreateLocker MigrationStep = iota
+	MigrationStepCreateMigrationMetadata
+	MigrationStepMigrate
+	MigrationStepLockPartnerLp
+	MigrationStepLockCreatorLp
+	MigrationStepClaimPartnerLp
+	MigrationStepClaimCreatorLp
+	MigrationStepWithdrawLeftover
+	MigrationStepPartnerWithdrawSurplus
+	MigrationStepCreatorWithdrawSurplus
+	MigrationStepPartnerWithdrawMigrationFee
+	MigrationStepCreatorWithdrawMigrationFee
+)
+
+type TokenMetadataSource uint8
+
+const (
+	TokenMetadataSourceMetaplex  TokenMetadataSource = iota // Metaplex token metadata account
+	TokenMetadataSourceToken2022                            // Token-2022 token metadata extension
+)
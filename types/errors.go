@@ -0,0 +1,33 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Swap pre-flight errors, match with errors.Is.
+var (
+	ErrPoolMigrated        = errors.New("pool is already migrated")
+	ErrPoolCurveComplete   = errors.New("pool curve is complete")
+	ErrPoolNotActivated    = errors.New("pool is not activated yet")
+	ErrZeroAmount          = errors.New("amount must be greater than zero")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrSlippageExceeded    = errors.New("slippage exceeded")
+)
+
+// InsufficientBalanceError reports the balance shortfall of a mint, match with errors.As.
+type InsufficientBalanceError struct {
+	Mint      solana.PublicKey
+	Required  uint64
+	Available uint64
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("%s: mint(%s) requires %d, available %d", ErrInsufficientBalance, e.Mint, e.Required, e.Available)
+}
+
+func (e *InsufficientBalanceError) Unwrap() error {
+	return ErrInsufficientBalance
+}
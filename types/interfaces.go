@@ -1,6 +1,11 @@
 package types
 
-import "math/big"
+import (
+	"context"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+)
 
 type BaseFeeHandler interface {
 	Validate(
@@ -22,3 +27,37 @@ type BaseFeeHandler interface {
 		includedFeeAmount *big.Int,
 	) (*big.Int, error)
 }
+
+// PriceSource gives the price of one whole token of a mint in a reference currency (e.g. USD).
+type PriceSource interface {
+	GetPrice(ctx context.Context, mint solana.PublicKey) (*big.Rat, error)
+}
+
+// TransactionSigner signs transactions with the keys it holds, Payer pays the fees.
+type TransactionSigner interface {
+	Payer() solana.PublicKey
+	CanSign(key solana.PublicKey) bool
+	Sign(ctx context.Context, tx *solana.Transaction) error
+}
+
+// TransactionSender lands signed transactions, on a cluster or on a local stand-in.
+type TransactionSender interface {
+	GetLatestBlockhash(ctx context.Context) (solana.Hash, error)
+	SendAndConfirm(ctx context.Context, tx *solana.Transaction) (solana.Signature, error)
+}
+
+// MigrationKeeperCheckpointStore persists the keeper progress so a restarted keeper resumes where it stopped.
+type MigrationKeeperCheckpointStore interface {
+	Load(ctx context.Context) (MigrationKeeperCheckpoint, error)
+	Save(ctx context.Context, checkpoint MigrationKeeperCheckpoint) error
+}
+
+// MigrationKeeperReporter receives the outcome of every migration step the keeper runs.
+type MigrationKeeperReporter interface {
+	Report(ctx context.Context, outcome MigrationKeeperOutcome)
+}
+
+// LaunchKeypairProvider gives the new config and base mint keypairs signing a launch.
+type LaunchKeypairProvider interface {
+	NextLaunchKeypairs(ctx context.Context) (LaunchKeypairs, error)
+}
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"dbcGoSDK/generated/dammv1"
+	"dbcGoSDK/generated/dammv2"
 	"dbcGoSDK/generated/dbc"
+	dynamic_vault "dbcGoSDK/generated/dynamicVault"
 	"math/big"
+	"time"
 
 	"github.com/gagliardetto/solana-go"
 )
@@ -33,6 +37,20 @@ type PoolFeeMetrics struct {
 	Total   FeeTotal     `json:"total"`
 }
 
+type PoolPriceMetrics struct {
+	// price of one base token in quote token (or reference currency) units
+	Price *big.Rat `json:"price"`
+	// price * circulating supply
+	MarketCap *big.Rat `json:"marketCap"`
+	// price * total supply
+	FullyDilutedValue *big.Rat `json:"fullyDilutedValue"`
+	// migration price * total supply
+	MigrationMarketCap *big.Rat `json:"migrationMarketCap"`
+	// base token amounts in lamports
+	CirculatingSupply *big.Int `json:"circulatingSupply"`
+	TotalSupply       *big.Int `json:"totalSupply"`
+}
+
 type PoolFeeByConfigOrCreator struct {
 	PoolAddress          solana.PublicKey
 	PartnerBaseFee       uint64
@@ -153,6 +171,10 @@ type PreCreatePoolParam struct {
 	URI         string
 	PoolCreator solana.PublicKey
 	BaseMint    solana.PublicKey
+	// KeypairProvider, when set, gives the base mint, and the config of a config created with the pool,
+	// in place of BaseMint and Config. The keypairs are copied to LaunchKeypairs, required with it, to sign.
+	KeypairProvider LaunchKeypairProvider
+	LaunchKeypairs  *LaunchKeypairs
 }
 
 type CreateConfigAndPoolParam struct {
@@ -237,6 +259,7 @@ type SwapQuote2Param struct {
 	CurrentPoint     *big.Int
 	SlippageBps      uint64
 	SwapMode         SwapMode
+	TransferFeeParam // optional, for Token-2022 mints with transfer fee
 
 	// ExactIn & PartialFill
 	AmountIn         *big.Int
@@ -250,6 +273,60 @@ type SwapQuote2Param struct {
 type SwapQuoteResult struct {
 	dbc.SwapResult
 	MinimumAmountOut uint64
+	TransferFeeResult
+}
+
+type TransferFee struct {
+	Epoch                  uint64
+	MaximumFee             uint64
+	TransferFeeBasisPoints uint16
+}
+
+// TransferFeeConfig is the Token-2022 TransferFeeConfig mint extension.
+type TransferFeeConfig struct {
+	TransferFeeConfigAuthority solana.PublicKey
+	WithdrawWithheldAuthority  solana.PublicKey
+	WithheldAmount             uint64
+	OlderTransferFee           TransferFee
+	NewerTransferFee           TransferFee
+}
+
+// TokenMetadata is the metadata of a mint, read from Metaplex or from the Token-2022 metadata extension.
+type TokenMetadata struct {
+	Source               TokenMetadataSource
+	Address              solana.PublicKey // account holding the metadata
+	Mint                 solana.PublicKey
+	Name                 string
+	Symbol               string
+	URI                  string
+	UpdateAuthority      solana.PublicKey // zero when the Token-2022 metadata has no update authority
+	IsMutable            bool
+	SellerFeeBasisPoints uint16                 // Metaplex only
+	Creators             []TokenMetadataCreator // Metaplex only
+	AdditionalMetadata   [][2]string            // Token-2022 only, key and value pairs
+}
+
+type TokenMetadataCreator struct {
+	Address  solana.PublicKey
+	Verified bool
+	Share    uint8 // percentage
+}
+
+type TransferFeeParam struct {
+	BaseTransferFeeConfig  *TransferFeeConfig // nil when the base mint has no transfer fee
+	QuoteTransferFeeConfig *TransferFeeConfig // nil when the quote mint has no transfer fee
+	Epoch                  uint64             // epoch the transfer happens in
+}
+
+type TransferFeeResult struct {
+	InputTransferFee  uint64 // withheld from the amount the user sends
+	OutputTransferFee uint64 // withheld from the amount the pool sends
+	NetOutputAmount   uint64 // output amount less OutputTransferFee, what the user receives
+}
+
+type TransferFeeAmountResult struct {
+	Amount      *big.Int
+	TransferFee *big.Int
 }
 
 type QuoteFee struct {
@@ -290,6 +367,7 @@ type SwapQuoteParam struct {
 	SlippageBps      uint64 // optional
 	HasReferral      bool
 	CurrentPoint     *big.Int
+	TransferFeeParam // optional, for Token-2022 mints with transfer fee
 }
 
 type SwapQuoteExactInParam struct {
@@ -362,6 +440,31 @@ type PartnerWithdrawSurplusParam struct {
 	FeeClaimer  solana.PublicKey
 	VirtualPool solana.PublicKey
 }
+
+type CreateClaimFeeOperatorParam struct {
+	Admin    solana.PublicKey
+	Operator solana.PublicKey
+}
+
+type CloseClaimFeeOperatorParam struct {
+	Admin        solana.PublicKey
+	Operator     solana.PublicKey
+	RentReceiver solana.PublicKey
+}
+
+type ClaimProtocolFeeParam struct {
+	Operator solana.PublicKey
+	Pool     solana.PublicKey
+	Receiver solana.PublicKey // owner of the token accounts the program accepts as protocol treasury
+	Payer    solana.PublicKey // optional, defaults to operator
+}
+
+type ProtocolWithdrawSurplusParam struct {
+	Pool     solana.PublicKey
+	Receiver solana.PublicKey // owner of the quote token account the program accepts as protocol treasury
+	Payer    solana.PublicKey // optional, defaults to receiver
+}
+
 type CreateLockerParam struct {
 	Payer       solana.PublicKey
 	VirtualPool solana.PublicKey
@@ -404,6 +507,114 @@ type MigrateToDammV2Response struct {
 	Ixns                                              []solana.Instruction
 }
 
+type MigrationStepsParam struct {
+	Pool                    *dbc.VirtualPoolAccount
+	Config                  *dbc.PoolConfigAccount
+	MigrationMetadataExists bool
+	// DAMM V1 only, nil until the migration metadata is created
+	DammV1MigrationMetadata *dbc.MeteoraDammMigrationMetadataAccount
+	Surplus                 PoolSurplusResult
+}
+
+type MigrationOrchestratorParam struct {
+	Payer       solana.PublicKey
+	VirtualPool solana.PublicKey
+}
+
+type MigrationStepInstructions struct {
+	Step     MigrationStep
+	Ixns     []solana.Instruction
+	Signers  []solana.PublicKey  // besides the payer
+	Keypairs []solana.PrivateKey // new accounts that sign the transaction
+}
+
+type MigrationKeeperParam struct {
+	Configs         []solana.PublicKey // pool configs polled for completed curves
+	WatchEvents     bool               // also scan program transactions for EvtCurveComplete
+	PollInterval    time.Duration      // optional, defaults to 30 seconds
+	MaxAttempts     int                // optional, failed attempts before a pool is abandoned, defaults to 5
+	BaseBackoff     time.Duration      // optional, delay after the first failure, defaults to 5 seconds
+	MaxBackoff      time.Duration      // optional, defaults to 10 minutes
+	Signer          TransactionSigner
+	Sender          TransactionSender
+	CheckpointStore MigrationKeeperCheckpointStore // optional, kept in memory when nil
+	Reporter        MigrationKeeperReporter        // optional
+}
+
+type MigrationKeeperCheckpoint struct {
+	// newest program transaction scanned for EvtCurveComplete
+	LastSignature solana.Signature                              `json:"lastSignature"`
+	Pools         map[solana.PublicKey]MigrationKeeperPoolState `json:"pools"`
+}
+
+type MigrationKeeperPoolState struct {
+	Attempts    int       `json:"attempts"` // consecutive failures
+	NextAttempt time.Time `json:"nextAttempt"`
+	LastError   string    `json:"lastError,omitempty"`
+	Done        bool      `json:"done"`      // no step left the signer can sign
+	Abandoned   bool      `json:"abandoned"` // MaxAttempts reached
+}
+
+type MigrationKeeperOutcome struct {
+	Pool      solana.PublicKey // zero when a round fails to discover pools
+	Step      MigrationStep
+	Signature solana.Signature // zero unless the step landed
+	Attempt   int
+	Err       error
+	Done      bool // no step left for the signer, Step is unset
+	Abandoned bool
+}
+
+// TransactionGroup is a set of instructions that must land in the same transaction.
+type TransactionGroup struct {
+	PreInstructions  []solana.Instruction // deduplicated within a transaction, e.g. idempotent ATA creation
+	Instructions     []solana.Instruction
+	PostInstructions []solana.Instruction // deduplicated within a transaction, e.g. wSOL unwrapping
+}
+
+type ClaimAllPartnerTradingFeeParam struct {
+	FeeClaimer  solana.PublicKey
+	Payer       solana.PublicKey
+	Config      solana.PublicKey
+	Receiver    solana.PublicKey // optional, defaults to the fee claimer
+	TempWSolAcc solana.PublicKey // required when Receiver is not the fee claimer and the quote mint is SOL
+	// dust threshold, a pool is claimed once its base or quote fee reaches it
+	MinBaseFee             uint64
+	MinQuoteFee            uint64
+	MaxPoolsPerTransaction int // optional, bounds the compute used by a transaction
+}
+
+type ClaimAllCreatorTradingFeeParam struct {
+	Creator     solana.PublicKey
+	Payer       solana.PublicKey
+	Receiver    solana.PublicKey // optional, defaults to the creator
+	TempWSolAcc solana.PublicKey // required when Receiver is not the creator and a quote mint is SOL
+	// dust threshold, a pool is claimed once its base or quote fee reaches it
+	MinBaseFee             uint64
+	MinQuoteFee            uint64
+	MaxPoolsPerTransaction int // optional, bounds the compute used by a transaction
+}
+
+type ClaimAllTradingFeeResponse struct {
+	Pools        []solana.PublicKey     // claimed pools
+	Transactions [][]solana.Instruction // size checked, in any order
+}
+
+// LaunchKeypairs are the new accounts of a launch, use Config.PublicKey() for CreateConfigParam.Config and
+// BaseMint.PublicKey() for PreCreatePoolParam.BaseMint, or set PreCreatePoolParam.KeypairProvider, then
+// sign the transaction with both.
+type LaunchKeypairs struct {
+	Config   solana.PrivateKey
+	BaseMint solana.PrivateKey
+}
+
+type VanityKeypairParam struct {
+	Prefix        string // base58
+	Suffix        string // base58
+	CaseSensitive bool
+	Workers       int // optional, defaults to the number of CPUs
+}
+
 type BuildCurveBaseParam struct {
 	TotalTokenSupply            uint64
 	MigrationOption             MigrationOption
@@ -458,6 +669,72 @@ type BuildCurveWithLiquidityWeightsParam struct {
 	LiquidityWeights   []float64
 }
 
+type CurveCheckpoint struct {
+	// set one of MarketCap or Price, in quote token units
+	MarketCap float64
+	Price     float64
+	// cumulative percentage of the total supply sold when the checkpoint is reached
+	SupplySoldPercentage float64
+}
+
+type BuildCurveFromCheckpointsParam struct {
+	BuildCurveBaseParam
+	InitialMarketCap float64
+	Checkpoints      []CurveCheckpoint // the last checkpoint is the migration point
+}
+
+type BuildCurveRatParam struct {
+	BuildCurveBaseParam
+	PercentageSupplyOnMigration *big.Rat
+	MigrationQuoteThreshold     *big.Rat
+}
+
+type BuildCurveWithMarketCapRatParam struct {
+	BuildCurveBaseParam
+	InitialMarketCap   *big.Rat
+	MigrationMarketCap *big.Rat
+}
+
+type BuildCurveWithTwoSegmentsRatParam struct {
+	BuildCurveBaseParam
+	InitialMarketCap            *big.Rat
+	MigrationMarketCap          *big.Rat
+	PercentageSupplyOnMigration uint8
+}
+
+type BuildCurveWithLiquidityWeightsRatParam struct {
+	BuildCurveBaseParam
+	InitialMarketCap   *big.Rat
+	MigrationMarketCap *big.Rat
+	LiquidityWeights   []*big.Rat
+}
+
+type CurveCheckpointRat struct {
+	// set one of MarketCap or Price, in quote token units
+	MarketCap *big.Rat
+	Price     *big.Rat
+	// cumulative percentage of the total supply sold when the checkpoint is reached
+	SupplySoldPercentage *big.Rat
+}
+
+type BuildCurveFromCheckpointsRatParam struct {
+	BuildCurveBaseParam
+	InitialMarketCap *big.Rat
+	Checkpoints      []CurveCheckpointRat // the last checkpoint is the migration point
+}
+
+// CheckpointReport compares a checkpoint with what the built curve achieves.
+type CheckpointReport struct {
+	TargetMarketCap            float64
+	ActualMarketCap            float64
+	TargetSupplySoldPercentage float64
+	ActualSupplySoldPercentage float64
+	// (actual - target) / target * 100
+	MarketCapDeviationPercentage float64
+	// actual - target, in percentage points
+	SupplySoldDeviation float64
+}
+
 type LockedVestingParams struct {
 	TotalLockedVestingAmount       uint64
 	NumberOfVestingPeriod          uint64
@@ -510,4 +787,456 @@ type SwapQuote2Result struct {
 	dbc.SwapResult2
 	MinimumAmountOut uint64
 	MaximumAmountIn  uint64
+	TransferFeeResult
+}
+
+type DammV1PoolState struct {
+	Pool           *dammv1.PoolAccount
+	VaultA         *dynamic_vault.VaultAccount
+	VaultB         *dynamic_vault.VaultAccount
+	PoolVaultALp   *big.Int // pool a vault lp token account amount
+	PoolVaultBLp   *big.Int // pool b vault lp token account amount
+	VaultALpSupply *big.Int // vault a lp mint supply
+	VaultBLpSupply *big.Int // vault b lp mint supply
+}
+
+type DammV1SwapQuoteParam struct {
+	DammV1PoolState
+	InTokenMint solana.PublicKey
+	InAmount    *big.Int
+	SlippageBps uint64
+	CurrentTime uint64
+}
+
+type DammV1SwapQuoteResult struct {
+	SwapInAmount     *big.Int
+	SwapOutAmount    *big.Int
+	MinSwapOutAmount *big.Int
+	TradeFee         *big.Int
+	ProtocolFee      *big.Int
+	PriceImpact      *big.Rat
+}
+
+type DammV1LockEscrowFee struct {
+	LpAmount     *big.Int // pool lp burnt for the fee
+	TokenAAmount *big.Int
+	TokenBAmount *big.Int
+	VirtualPrice *big.Int
+}
+
+type DammV1ClaimLockEscrowFeeParam struct {
+	DammPool  solana.PublicKey
+	Owner     solana.PublicKey
+	MaxAmount uint64           // optional, lp to claim, all claimable fee when zero
+	Receiver  solana.PublicKey // optional, defaults to owner
+	Payer     solana.PublicKey // optional, defaults to owner
+}
+
+type VaultDepositQuote struct {
+	TokenAmount *big.Int
+	LpAmount    *big.Int
+	MinLpAmount *big.Int
+}
+
+type VaultWithdrawQuote struct {
+	LpAmount       *big.Int
+	TokenAmount    *big.Int
+	MinTokenAmount *big.Int
+}
+
+type VaultDepositParam struct {
+	TokenMint   solana.PublicKey
+	User        solana.PublicKey
+	Amount      uint64
+	SlippageBps uint64
+	Payer       solana.PublicKey // optional, defaults to user
+}
+
+type VaultWithdrawParam struct {
+	TokenMint   solana.PublicKey
+	User        solana.PublicKey
+	LpAmount    uint64
+	SlippageBps uint64
+	Payer       solana.PublicKey // optional, defaults to user
+	// StrategyAccounts are the lending protocol accounts of the strategy withdraw2 pulls from when the
+	// vault reserve cannot cover the withdrawal, they follow the strategy fee vault. Optional.
+	StrategyAccounts []*solana.AccountMeta
+}
+
+type DammV2SwapQuoteResult struct {
+	dammv2.SwapResult
+	IncludedFeeInputAmount uint64
+	ExcludedFeeInputAmount uint64
+	MinimumAmountOut       uint64
+	MaximumAmountIn        uint64
+	TransferFeeResult
+}
+
+type RouterQuoteParam struct {
+	Owner                solana.PublicKey // optional, the result has no instructions without it
+	Payer                solana.PublicKey // optional, defaults to Owner
+	SlippageBps          uint64
+	ReferralTokenAccount solana.PublicKey // optional
+}
+
+type RouterQuoteResult struct {
+	Venue            RouterVenue
+	Pool             solana.PublicKey // DBC virtual pool or DAMM pool
+	InputMint        solana.PublicKey
+	OutputMint       solana.PublicKey
+	AmountIn         uint64
+	AmountOut        uint64
+	MinimumAmountOut uint64
+	Ixns             []solana.Instruction
+}
+
+type PoolSurplusResult struct {
+	TotalSurplus               uint64 // quote token
+	PartnerSurplus             uint64 // quote token
+	CreatorSurplus             uint64 // quote token
+	ProtocolSurplus            uint64 // quote token
+	LeftoverBaseAmount         uint64 // base token, fixed supply pools only
+	IsPartnerSurplusWithdrawn  bool
+	IsCreatorSurplusWithdrawn  bool
+	IsProtocolSurplusWithdrawn bool
+	IsLeftoverWithdrawn        bool
+}
+
+type VestingUnlock struct {
+	// unix timestamp of the unlock
+	Time uint64 `json:"time"`
+	// base token amounts in lamports
+	Amount           *big.Int `json:"amount"`
+	CumulativeAmount *big.Int `json:"cumulativeAmount"`
+	// base token amounts in human units
+	AmountUI           *big.Rat `json:"amountUI"`
+	CumulativeAmountUI *big.Rat `json:"cumulativeAmountUI"`
+}
+
+type VestingSchedule struct {
+	StartTime     uint64          `json:"startTime"`
+	CliffTime     uint64          `json:"cliffTime"`
+	EndTime       uint64          `json:"endTime"`
+	TotalAmount   *big.Int        `json:"totalAmount"`
+	TotalAmountUI *big.Rat        `json:"totalAmountUI"`
+	Unlocks       []VestingUnlock `json:"unlocks"` // cliff unlock first, then one per period
+}
+
+type VestingStatus struct {
+	// base token amounts in lamports
+	UnlockedAmount  *big.Int `json:"unlockedAmount"`
+	ClaimableAmount *big.Int `json:"claimableAmount"`
+	LockedAmount    *big.Int `json:"lockedAmount"`
+	// base token amounts in human units
+	UnlockedAmountUI  *big.Rat `json:"unlockedAmountUI"`
+	ClaimableAmountUI *big.Rat `json:"claimableAmountUI"`
+	LockedAmountUI    *big.Rat `json:"lockedAmountUI"`
+	// unix timestamp of the next unlock, zero once fully unlocked
+	NextUnlockTime uint64 `json:"nextUnlockTime"`
+}
+
+type MigrationLpShare struct {
+	// DAMM v1 LP tokens or DAMM v2 position liquidity
+	Liquid *big.Int
+	Locked *big.Int
+}
+
+type MigrationLpDistribution struct {
+	MigrationOption MigrationOption
+	// quote token
+	MigrationFee        uint64
+	PartnerMigrationFee uint64
+	CreatorMigrationFee uint64
+	// deposited into the DAMM pool
+	BaseAmount  uint64
+	QuoteAmount uint64
+	// DAMM v1 LP tokens minted or DAMM v2 liquidity
+	TotalLp *big.Int
+	Partner MigrationLpShare
+	Creator MigrationLpShare
+}
+
+type BaseFeeDescription struct {
+	Mode string `json:"mode"`
+	// fee scheduler, EndingFeeBps equals StartingFeeBps without a schedule
+	StartingFeeBps  float64 `json:"startingFeeBps"`
+	EndingFeeBps    float64 `json:"endingFeeBps"`
+	NumberOfPeriod  uint16  `json:"numberOfPeriod,omitempty"`
+	PeriodFrequency uint64  `json:"periodFrequency,omitempty"` // in slots or seconds
+	ReductionFactor uint64  `json:"reductionFactor,omitempty"`
+	// rate limiter
+	FeeIncrementBps    uint16  `json:"feeIncrementBps,omitempty"`
+	MaxLimiterDuration uint64  `json:"maxLimiterDuration,omitempty"` // in slots or seconds
+	ReferenceAmount    float64 `json:"referenceAmount,omitempty"`    // quote token
+}
+
+type DynamicFeeDescription struct {
+	BinStep                  uint16 `json:"binStep"`
+	FilterPeriod             uint16 `json:"filterPeriod"`
+	DecayPeriod              uint16 `json:"decayPeriod"`
+	ReductionFactor          uint16 `json:"reductionFactor"`
+	MaxVolatilityAccumulator uint32 `json:"maxVolatilityAccumulator"`
+	VariableFeeControl       uint32 `json:"variableFeeControl"`
+}
+
+type LockedVestingDescription struct {
+	// base token
+	TotalAmount     float64 `json:"totalAmount"`
+	CliffAmount     float64 `json:"cliffAmount"`
+	AmountPerPeriod float64 `json:"amountPerPeriod"`
+	NumberOfPeriod  uint64  `json:"numberOfPeriod"`
+	// seconds
+	Frequency                      uint64 `json:"frequency"`
+	CliffDurationFromMigrationTime uint64 `json:"cliffDurationFromMigrationTime"`
+}
+
+// ConfigDescription is a pool config in human units, amounts are in base or quote token units.
+type ConfigDescription struct {
+	QuoteMint         solana.PublicKey `json:"quoteMint"`
+	FeeClaimer        solana.PublicKey `json:"feeClaimer"`
+	LeftoverReceiver  solana.PublicKey `json:"leftoverReceiver"`
+	TokenType         string           `json:"tokenType"`
+	TokenBaseDecimal  uint8            `json:"tokenBaseDecimal"`
+	TokenQuoteDecimal uint8            `json:"tokenQuoteDecimal"`
+	ActivationType    string           `json:"activationType"`
+
+	InitialPrice            float64 `json:"initialPrice"`
+	InitialMarketCap        float64 `json:"initialMarketCap"`
+	MigrationPrice          float64 `json:"migrationPrice"`
+	MigrationMarketCap      float64 `json:"migrationMarketCap"`
+	MigrationQuoteThreshold float64 `json:"migrationQuoteThreshold"`
+
+	FixedTokenSupply            bool    `json:"fixedTokenSupply"`
+	TotalSupply                 float64 `json:"totalSupply"`
+	CurveSupplyPercentage       float64 `json:"curveSupplyPercentage"`
+	MigrationSupplyPercentage   float64 `json:"migrationSupplyPercentage"`
+	VestingSupplyPercentage     float64 `json:"vestingSupplyPercentage"`
+	LeftoverSupplyPercentage    float64 `json:"leftoverSupplyPercentage"`
+	CollectFeeMode              string  `json:"collectFeeMode"`
+	CreatorTradingFeePercentage uint8   `json:"creatorTradingFeePercentage"`
+
+	BaseFee    BaseFeeDescription     `json:"baseFee"`
+	DynamicFee *DynamicFeeDescription `json:"dynamicFee"` // nil when disabled
+
+	PartnerLpPercentage       uint8 `json:"partnerLpPercentage"`
+	PartnerLockedLpPercentage uint8 `json:"partnerLockedLpPercentage"`
+	CreatorLpPercentage       uint8 `json:"creatorLpPercentage"`
+	CreatorLockedLpPercentage uint8 `json:"creatorLockedLpPercentage"`
+
+	MigrationTarget               string `json:"migrationTarget"`
+	MigrationFeeOption            string `json:"migrationFeeOption"`
+	MigrationFeePercentage        uint8  `json:"migrationFeePercentage"`
+	CreatorMigrationFeePercentage uint8  `json:"creatorMigrationFeePercentage"`
+	MigratedPoolFeeBps            uint16 `json:"migratedPoolFeeBps,omitempty"`
+	MigratedCollectFeeMode        string `json:"migratedCollectFeeMode,omitempty"`
+	MigratedDynamicFee            bool   `json:"migratedDynamicFee,omitempty"`
+
+	LockedVesting        *LockedVestingDescription `json:"lockedVesting"` // nil without vesting
+	TokenUpdateAuthority string                    `json:"tokenUpdateAuthority"`
+
+	Summary string `json:"summary"`
+}
+
+// LaunchConfig describes a launch in human units, it is loaded from and saved to JSON or YAML files.
+type LaunchConfig struct {
+	// marketCap (default), percentage, twoSegments, liquidityWeights or checkpoints
+	Builder string `json:"builder,omitempty" yaml:"builder,omitempty"`
+
+	TotalTokenSupply  uint64 `json:"totalTokenSupply" yaml:"totalTokenSupply"`
+	TokenBaseDecimal  uint8  `json:"tokenBaseDecimal" yaml:"tokenBaseDecimal"`
+	TokenQuoteDecimal uint8  `json:"tokenQuoteDecimal" yaml:"tokenQuoteDecimal"`
+	TokenType         string `json:"tokenType" yaml:"tokenType"`           // spl or token2022
+	ActivationType    string `json:"activationType" yaml:"activationType"` // slot or timestamp
+	Leftover          uint64 `json:"leftover,omitempty" yaml:"leftover,omitempty"`
+	// creator, immutable, partner, creatorAndMint or partnerAndMint
+	TokenUpdateAuthority string `json:"tokenUpdateAuthority" yaml:"tokenUpdateAuthority"`
+
+	// curve, in quote token units, the fields used depend on Builder
+	InitialMarketCap            float64                  `json:"initialMarketCap,omitempty" yaml:"initialMarketCap,omitempty"`
+	MigrationMarketCap          float64                  `json:"migrationMarketCap,omitempty" yaml:"migrationMarketCap,omitempty"`
+	PercentageSupplyOnMigration float64                  `json:"percentageSupplyOnMigration,omitempty" yaml:"percentageSupplyOnMigration,omitempty"`
+	MigrationQuoteThreshold     float64                  `json:"migrationQuoteThreshold,omitempty" yaml:"migrationQuoteThreshold,omitempty"`
+	LiquidityWeights            []float64                `json:"liquidityWeights,omitempty" yaml:"liquidityWeights,omitempty"`
+	Checkpoints                 []LaunchConfigCheckpoint `json:"checkpoints,omitempty" yaml:"checkpoints,omitempty"`
+
+	Fee       LaunchConfigFee       `json:"fee" yaml:"fee"`
+	Vesting   LaunchConfigVesting   `json:"vesting" yaml:"vesting"`
+	Lp        LaunchConfigLp        `json:"lp" yaml:"lp"`
+	Migration LaunchConfigMigration `json:"migration" yaml:"migration"`
+}
+
+type LaunchConfigCheckpoint struct {
+	MarketCap            float64 `json:"marketCap,omitempty" yaml:"marketCap,omitempty"`
+	Price                float64 `json:"price,omitempty" yaml:"price,omitempty"`
+	SupplySoldPercentage float64 `json:"supplySoldPercentage" yaml:"supplySoldPercentage"`
+}
+
+type LaunchConfigFee struct {
+	Mode string `json:"mode" yaml:"mode"` // linear, exponential or rateLimiter
+	// fee scheduler, durations are in slots or seconds following the activation type
+	StartingFeeBps uint64 `json:"startingFeeBps,omitempty" yaml:"startingFeeBps,omitempty"`
+	EndingFeeBps   uint64 `json:"endingFeeBps,omitempty" yaml:"endingFeeBps,omitempty"`
+	NumberOfPeriod uint16 `json:"numberOfPeriod,omitempty" yaml:"numberOfPeriod,omitempty"`
+	TotalDuration  uint64 `json:"totalDuration,omitempty" yaml:"totalDuration,omitempty"`
+	// rate limiter
+	BaseFeeBps         uint64  `json:"baseFeeBps,omitempty" yaml:"baseFeeBps,omitempty"`
+	FeeIncrementBps    uint16  `json:"feeIncrementBps,omitempty" yaml:"feeIncrementBps,omitempty"`
+	ReferenceAmount    float64 `json:"referenceAmount,omitempty" yaml:"referenceAmount,omitempty"`
+	MaxLimiterDuration uint64  `json:"maxLimiterDuration,omitempty" yaml:"maxLimiterDuration,omitempty"`
+
+	DynamicFee                  bool   `json:"dynamicFee" yaml:"dynamicFee"`
+	CollectFeeMode              string `json:"collectFeeMode" yaml:"collectFeeMode"` // quote or output
+	CreatorTradingFeePercentage uint8  `json:"creatorTradingFeePercentage" yaml:"creatorTradingFeePercentage"`
+}
+
+type LaunchConfigVesting struct {
+	TotalLockedAmount uint64  `json:"totalLockedAmount,omitempty" yaml:"totalLockedAmount,omitempty"`
+	CliffUnlockAmount uint64  `json:"cliffUnlockAmount,omitempty" yaml:"cliffUnlockAmount,omitempty"`
+	NumberOfPeriod    uint64  `json:"numberOfPeriod,omitempty" yaml:"numberOfPeriod,omitempty"`
+	TotalDurationDays float64 `json:"totalDurationDays,omitempty" yaml:"totalDurationDays,omitempty"`
+	CliffDurationDays float64 `json:"cliffDurationDays,omitempty" yaml:"cliffDurationDays,omitempty"`
+}
+
+type LaunchConfigLp struct {
+	PartnerPercentage       uint8 `json:"partnerPercentage" yaml:"partnerPercentage"`
+	PartnerLockedPercentage uint8 `json:"partnerLockedPercentage" yaml:"partnerLockedPercentage"`
+	CreatorPercentage       uint8 `json:"creatorPercentage" yaml:"creatorPercentage"`
+	CreatorLockedPercentage uint8 `json:"creatorLockedPercentage" yaml:"creatorLockedPercentage"`
+}
+
+type LaunchConfigMigration struct {
+	Option               string  `json:"option" yaml:"option"`       // dammV1 or dammV2
+	FeeOption            string  `json:"feeOption" yaml:"feeOption"` // 25bps, 30bps, 100bps, 200bps, 400bps, 600bps or customizable
+	FeePercentage        float64 `json:"feePercentage" yaml:"feePercentage"`
+	CreatorFeePercentage float64 `json:"creatorFeePercentage" yaml:"creatorFeePercentage"`
+	// customizable fee option only
+	PoolFeeBps     uint16 `json:"poolFeeBps,omitempty" yaml:"poolFeeBps,omitempty"`
+	CollectFeeMode string `json:"collectFeeMode,omitempty" yaml:"collectFeeMode,omitempty"` // both or quote
+	DynamicFee     bool   `json:"dynamicFee,omitempty" yaml:"dynamicFee,omitempty"`
+}
+
+type BacktestTrade struct {
+	ElapsedSeconds   uint64 // seconds since pool activation
+	SwapBaseForQuote bool
+	AmountIn         uint64 // input token lamports, fee included
+	HasReferral      bool
+}
+
+type BacktestParam struct {
+	Config            dbc.ConfigParameters
+	Trades            []BacktestTrade // sorted by ElapsedSeconds
+	TokenQuoteDecimal TokenDecimal
+}
+
+type BacktestSyntheticTradesParam struct {
+	NumberOfTrades  int
+	IntervalSeconds uint64
+	BuyAmount       uint64  // average quote lamports per buy
+	SellAmount      uint64  // average base lamports per sell
+	SellRatio       float64 // share of trades that sell, 0 to 1
+	Jitter          float64 // amounts vary uniformly by ±Jitter, 0 to 1
+	Seed            uint64
+}
+
+type BacktestPricePoint struct {
+	ElapsedSeconds uint64
+	SqrtPrice      *big.Int
+	Price          float64 // quote per base token
+	QuoteReserve   uint64
+}
+
+type BacktestRejection struct {
+	TradeIndex int
+	Reason     string
+}
+
+type BacktestFees struct {
+	PartnerBaseFee   uint64
+	PartnerQuoteFee  uint64
+	CreatorBaseFee   uint64
+	CreatorQuoteFee  uint64
+	ProtocolBaseFee  uint64
+	ProtocolQuoteFee uint64
+	ReferralBaseFee  uint64
+	ReferralQuoteFee uint64
+}
+
+type BacktestResult struct {
+	Migrated        bool
+	TimeToMigration uint64 // seconds since activation, set when Migrated
+
+	TradesExecuted int
+	TradesSkipped  int // trades after migration
+	Rejections     []BacktestRejection
+
+	Fees BacktestFees
+
+	FinalBaseReserve  uint64
+	FinalQuoteReserve uint64
+	FinalSqrtPrice    *big.Int
+	FinalPrice        float64
+	Surplus           PoolSurplusResult
+
+	PricePath []BacktestPricePoint
+}
+
+type DammV2UserPosition struct {
+	Position           solana.PublicKey
+	PositionNft        solana.PublicKey
+	PositionNftAccount solana.PublicKey
+	PositionState      *dammv2.PositionAccount
+	Pool               solana.PublicKey
+	PoolState          *dammv2.PoolAccount
+	UnclaimedFeeA      *big.Int
+	UnclaimedFeeB      *big.Int
+}
+
+type DammV2ClaimPositionFeeParam struct {
+	Owner    solana.PublicKey
+	Position solana.PublicKey
+	Receiver solana.PublicKey // optional, defaults to owner
+	Payer    solana.PublicKey // optional, defaults to owner
+}
+
+type DammV2AddLiquidityParam struct {
+	Owner          solana.PublicKey
+	Position       solana.PublicKey
+	LiquidityDelta *big.Int // optional, the largest delta the max amounts allow when nil
+	MaxAmountA     uint64
+	MaxAmountB     uint64
+	Payer          solana.PublicKey // optional, defaults to owner
+}
+
+type DammV2RemoveLiquidityParam struct {
+	Owner          solana.PublicKey
+	Position       solana.PublicKey
+	LiquidityDelta *big.Int // optional, all unlocked liquidity when nil
+	MinAmountA     uint64
+	MinAmountB     uint64
+	Payer          solana.PublicKey // optional, defaults to owner
+}
+
+type DammV2LockPositionParam struct {
+	Owner              solana.PublicKey
+	Position           solana.PublicKey
+	Vesting            solana.PublicKey // new keypair, signs the transaction
+	Payer              solana.PublicKey // optional, defaults to owner
+	CliffPoint         *uint64          // optional, defaults to the current point on chain
+	PeriodFrequency    uint64
+	CliffUnlock        *big.Int
+	LiquidityPerPeriod *big.Int
+	NumberOfPeriod     uint16
+}
+
+type DammV2PermanentLockPositionParam struct {
+	Owner     solana.PublicKey
+	Position  solana.PublicKey
+	Liquidity *big.Int // optional, all unlocked liquidity when nil
+}
+
+type DammV2ClosePositionParam struct {
+	Owner        solana.PublicKey
+	Position     solana.PublicKey
+	RentReceiver solana.PublicKey // optional, defaults to owner
+	Payer        solana.PublicKey // optional, defaults to owner
 }